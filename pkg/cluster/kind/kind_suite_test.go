@@ -0,0 +1,13 @@
+package kind
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKind(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kind Suite")
+}
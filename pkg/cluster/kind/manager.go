@@ -27,20 +27,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"time"
 
+	"github.com/day0ops/lok8s/pkg/cluster/report"
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
 	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util"
 	"github.com/day0ops/lok8s/pkg/util/docker"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
 	"github.com/day0ops/lok8s/pkg/util/helm"
 	"github.com/day0ops/lok8s/pkg/util/k8s"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/kind/pkg/cluster"
 )
 
@@ -50,56 +60,151 @@ type Manager struct {
 	helmManager          *helm.HelmManager
 	metallbManager       *services.MetalLBManager
 	ciliumManager        *services.CiliumManager
+	calicoManager        *services.CalicoManager
+	flannelManager       *services.FlannelManager
+	metricsServerManager *services.MetricsServerManager
 	cloudProviderManager *services.CloudProviderKindManager
+	portsMu              sync.Mutex
+	reservedPorts        map[string]bool // control-plane ports handed out to clusters still being created
+	containerEngine      string          // engine saved for the project, detected from enginePreference when empty
+	enginePreference     []string
+	containerRuntime     string // resolved engine the node, network and registry containers run on
 }
 
 // CreateOptions contains options for creating kind clusters
 type CreateOptions struct {
-	Project                  string
-	GatewayIP                string
-	SubnetCIDR               string
-	NumClusters              int
-	NodeCount                int
-	K8sVersion               string
-	InstallMetalLB           bool
-	InstallCloudProvider     bool
-	CNI                      string
-	ContainerRuntime         string
-	PreferredContainerEngine string
-	Recreate                 bool
+	Project                   string
+	ClusterPrefix             string // base name of the kind clusters, kind1, kind2, ... when empty
+	GatewayIP                 string
+	SubnetCIDR                string
+	PodSubnet                 string
+	ServiceSubnet             string
+	IPFamily                  string // ipv4 (default), ipv6 or dual
+	NumClusters               int
+	NodeCount                 int
+	K8sVersion                string
+	NodeImage                 string // overrides the kindest/node image derived from K8sVersion
+	InstallMetalLB            bool
+	MetalLBPoolSize           int
+	MetalLBIPRanges           []string                 // exact pool of each cluster, generated when empty
+	MetalLBBGPPeer            *services.MetalLBBGPPeer // advertise over BGP to this peer, nil for L2
+	InstallCloudProvider      bool
+	CloudProviderVersion      string // pinned cloud-provider-kind release, empty for the latest
+	CNI                       string
+	CPU                       string // limit applied to each node container, empty for no limit
+	Memory                    string // limit applied to each node container, empty for no limit
+	ContainerRuntime          string
+	PreferredContainerEngine  string
+	ContainerEnginePreference []string // engines to auto-detect in order when PreferredContainerEngine is empty
+	Recreate                  bool
+	Parallel                  bool
+	AssumeYes                 bool
+	RegistryPort              int // set to the resolved registry host port after creation
+	RegistryMirrors           map[string]string
+	RegistryMirrorHosts       []string                        // only start the mirrors of these hosts, all of them when empty
+	NoRegistryMirrors         bool                            // start no mirrors, only the local registry
+	RegistryAuth              map[string]string               // host -> username:password of the mirror upstreams, $VAR references expanded
+	RegistryTLS               bool                            // serve the local registry over https with a self-signed certificate
+	CleanupOnFailure          bool                            // delete the clusters this create added when it fails or times out
+	NodeLabels                map[string]string               // applied to every worker node
+	NodeTaints                []string                        // key=value:Effect, applied to every worker node
+	NodeGPUs                  int                             // fake nvidia.com/gpu advertised by every worker node
+	WorkerNodes               map[int]config.WorkerNodeConfig // per worker overrides keyed by worker index
+	ExtraPortMappings         []string                        // hostPort:containerPort[/protocol] on the control-plane
+	Mounts                    []string                        // host:container directories mounted into every node
+	EnableStorageClass        bool                            // mark the local-path storageclass as the default
+	StorageClass              string                          // extra local-path storageclass made the default in place of standard
+	EnableMetrics             bool
+	ReadinessTimeout          time.Duration // defaults to config.DefaultReadinessTimeout, config.DefaultCNIReadinessTimeout for Cilium and Calico
+	DryRun                    bool
+	HelmSet                   []string // release.key=value overrides merged over the built-in Helm values
+	CiliumChartVersion        string   // pinned chart versions, empty for the latest
+	MetalLBChartVersion       string
+	CiliumChart               string // local chart directories or archives, empty for the repo charts
+	MetalLBChart              string
+	CiliumValuesFile          string // Helm values files merged beneath HelmSet
+	MetalLBValuesFile         string
+	CiliumClusterMesh         bool           // connect the Cilium installs of all clusters into a cluster mesh
+	CiliumClusterIDs          map[string]int // cluster IDs by context, previous IDs are kept and set to the assigned ones after creation
 }
 
 // DeleteOptions contains options for deleting kind clusters
 type DeleteOptions struct {
-	Project     string
-	NumClusters int
-	Force       bool
+	Project         string
+	ClusterPrefix   string
+	NumClusters     int
+	ClusterIndex    int // only delete this cluster (1-NumClusters) and keep the project, 0 deletes every cluster
+	Force           bool
+	RegistryMirrors map[string]string
 }
 
 // StatusOptions contains options for checking kind cluster status
 type StatusOptions struct {
-	Project     string
-	NumClusters int
+	Project       string
+	ClusterPrefix string
+	NumClusters   int
+	RegistryPort  int
+	CNI           string
+	OutputFormat  string // table (default) or json
+}
+
+// StartOptions contains options for starting stopped kind clusters
+type StartOptions struct {
+	Project       string
+	ClusterPrefix string
+	NumClusters   int
+	CNI           string // nodes are not waited on with none, they stay NotReady until a CNI is applied
+}
+
+// StopOptions contains options for stopping kind clusters
+type StopOptions struct {
+	Project       string
+	ClusterPrefix string
+	NumClusters   int
 }
 
 // LoadImageOptions contains options for loading images into kind clusters
 type LoadImageOptions struct {
-	Project     string
-	Image       string
-	NumClusters int
+	Project       string
+	ClusterPrefix string
+	Images        []string
+	NumClusters   int
+}
+
+// ClusterName returns the kind cluster name for a cluster of a project. Without a prefix the clusters
+// are named kind1, kind2, ... otherwise the prefix is used as is for a single cluster and suffixed with the
+// cluster number when there are more
+func ClusterName(prefix string, clusterIndex, numClusters int) string {
+	if prefix == "" {
+		return fmt.Sprintf("kind%d", clusterIndex)
+	}
+	if numClusters == 1 {
+		return prefix
+	}
+	return fmt.Sprintf("%s-%d", prefix, clusterIndex)
+}
+
+// kindContextName returns the kube context name for a cluster of a project
+func kindContextName(project string, clusterIndex, numClusters int) string {
+	if numClusters == 1 {
+		// if only one cluster, don't add suffix
+		return project
+	}
+	return fmt.Sprintf("%s-%d", project, clusterIndex)
 }
 
 // getAvailablePortPrefix finds an available port prefix in the 70XX range, if not search for an available port
-func getAvailablePortPrefix(clusterIndex int) (string, error) {
+// skipping any ports in reserved
+func getAvailablePortPrefix(clusterIndex int, reserved map[string]bool) (string, error) {
 	// try the preferred port first (70XX where XX is cluster index)
 	preferredPort := config.KindControlPlanePort + clusterIndex
-	if isPortAvailable(preferredPort) {
+	if !reserved[fmt.Sprintf("%d", preferredPort)] && isPortAvailable(preferredPort) {
 		return fmt.Sprintf("%d", preferredPort), nil
 	}
 
 	// if preferred port is not available, find any available port in 29000 - 30100 range
 	for port := 29000; port <= 30100; port++ {
-		if isPortAvailable(port) {
+		if !reserved[fmt.Sprintf("%d", port)] && isPortAvailable(port) {
 			return fmt.Sprintf("%d", port), nil
 		}
 	}
@@ -107,6 +212,20 @@ func getAvailablePortPrefix(clusterIndex int) (string, error) {
 	return "", errors.New("no available ports found in range 29000 - 30100")
 }
 
+// reserveControlPlanePort picks the API server host port for a cluster, making sure clusters created
+// in parallel don't pick the same port before kind has bound it
+func (m *Manager) reserveControlPlanePort(clusterIndex int) (string, error) {
+	m.portsMu.Lock()
+	defer m.portsMu.Unlock()
+
+	port, err := getAvailablePortPrefix(clusterIndex, m.reservedPorts)
+	if err != nil {
+		return "", err
+	}
+	m.reservedPorts[port] = true
+	return port, nil
+}
+
 // isPortAvailable checks if a port is available for binding
 func isPortAvailable(port int) bool {
 	addr := fmt.Sprintf(":%d", port)
@@ -143,18 +262,145 @@ func NewManager() *Manager {
 	return &Manager{
 		provider:             cluster.NewProvider(),
 		helmManager:          helmManager,
-		metallbManager:       services.NewMetalLBManager(helmManager),
+		metallbManager:       services.NewMetalLBManagerWithOptions(helmManager, config.MetalLBRangeMinLastOctet, config.MetalLBRangeMaxLastOctet, config.MetalLBDefaultIPsPerCluster),
 		ciliumManager:        services.NewCiliumManager(helmManager, nil), // kind doesn't need binary manager
+		calicoManager:        services.NewCalicoManager(helmManager, nil),
+		flannelManager:       services.NewFlannelManager(),
+		metricsServerManager: services.NewMetricsServerManager(helmManager),
 		cloudProviderManager: services.NewCloudProviderKindManager(),
+		reservedPorts:        make(map[string]bool),
 	}
 }
 
-// CreateClusters creates multiple kind clusters
-func (m *Manager) CreateClusters(opts *CreateOptions) error {
+// NewManagerWithContainerEngine creates a new kind manager running on the container engine, the first available
+// one from the preference list when no engine is given
+func NewManagerWithContainerEngine(engine string, preference []string) *Manager {
+	m := NewManager()
+	m.containerEngine = engine
+	m.enginePreference = preference
+
+	// the kind provider lists and deletes clusters on the resolved engine, errors surface on first use
+	if _, err := m.runtime(); err != nil {
+		logger.Debugf("container runtime not resolved yet: %v", err)
+	}
+	return m
+}
+
+// runtime returns the container runtime of the manager, resolving it on first use
+func (m *Manager) runtime() (string, error) {
+	if m.containerRuntime != "" {
+		return m.containerRuntime, nil
+	}
+
+	containerRuntime, err := docker.ResolveContainerRuntime(m.containerEngine, m.enginePreference)
+	if err != nil {
+		return "", fmt.Errorf("unable to detect container runtime: %w", err)
+	}
+	m.setRuntime(containerRuntime)
+	return containerRuntime, nil
+}
+
+// setRuntime points the manager and the kind provider at the container runtime
+func (m *Manager) setRuntime(containerRuntime string) {
+	m.containerRuntime = containerRuntime
+	if containerRuntime == "podman" {
+		m.provider = cluster.NewProvider(cluster.ProviderWithPodman())
+	} else {
+		m.provider = cluster.NewProvider(cluster.ProviderWithDocker())
+	}
+}
+
+// CreateClusters creates multiple kind clusters, provisioning stops once the context is done
+func (m *Manager) CreateClusters(ctx context.Context, opts *CreateOptions) error {
 	logger.Infof("-----> 📢 creating %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	started := time.Now()
+
+	// default and validate the in-cluster networking ranges before touching anything
+	if opts.IPFamily == "" {
+		opts.IPFamily = config.IPFamilyIPv4
+	}
+	if opts.PodSubnet == "" {
+		opts.PodSubnet = config.KindPodSubnet
+	}
+	if opts.ServiceSubnet == "" {
+		opts.ServiceSubnet = config.KindServiceSubnet
+	}
+	opts.PodSubnet = subnetsForIPFamily(opts.IPFamily, opts.PodSubnet, config.KindPodSubnet, config.KindPodSubnetIPv6)
+	opts.ServiceSubnet = subnetsForIPFamily(opts.IPFamily, opts.ServiceSubnet, config.KindServiceSubnet, config.KindServiceSubnetIPv6)
+	if err := validateClusterSubnets(opts.IPFamily, opts.PodSubnet, opts.ServiceSubnet, opts.SubnetCIDR); err != nil {
+		return fmt.Errorf("invalid cluster networking: %w", err)
+	}
+	// the flannel manifest is only rendered with an IPv4 network
+	if opts.CNI == "flannel" && opts.IPFamily != config.IPFamilyIPv4 {
+		return fmt.Errorf("flannel is only supported on ipv4 clusters, use cilium or calico for %s", opts.IPFamily)
+	}
+	if err := validateWorkerNodes(opts); err != nil {
+		return fmt.Errorf("invalid worker node configuration: %w", err)
+	}
+	if err := validatePortMappings(opts.ExtraPortMappings, opts.NumClusters); err != nil {
+		return fmt.Errorf("invalid extra port mappings: %w", err)
+	}
+	if err := config.CheckMountHostPaths(opts.Mounts); err != nil {
+		return err
+	}
+	// Helm waits for the add-on pods, which can't be scheduled until the user applies a CNI
+	if opts.CNI == "none" && (opts.InstallMetalLB || opts.EnableMetrics) {
+		logger.Warnf("⚠️ skipping MetalLB and metrics-server, they can't start until a CNI is installed")
+		opts.InstallMetalLB = false
+		opts.EnableMetrics = false
+	}
+
+	// the CNIs keep their longer default unless a timeout was asked for
+	if opts.ReadinessTimeout > 0 {
+		m.ciliumManager.SetReadinessTimeout(opts.ReadinessTimeout)
+		m.calicoManager.SetReadinessTimeout(opts.ReadinessTimeout)
+	} else {
+		opts.ReadinessTimeout = config.DefaultReadinessTimeout
+	}
+	m.metallbManager.SetReadinessTimeout(opts.ReadinessTimeout)
+	m.flannelManager.SetReadinessTimeout(opts.ReadinessTimeout)
+	m.metricsServerManager.SetReadinessTimeout(opts.ReadinessTimeout)
+	m.ciliumManager.SetChartVersion(opts.CiliumChartVersion)
+	m.ciliumManager.SetChart(opts.CiliumChart)
+	m.ciliumManager.SetIPFamily(opts.IPFamily)
+	m.metallbManager.SetChartVersion(opts.MetalLBChartVersion)
+	m.metallbManager.SetChart(opts.MetalLBChart)
+	m.cloudProviderManager.SetVersion(opts.CloudProviderVersion)
+
+	valueOverrides, err := helm.LoadValueOverrides(map[string]string{
+		"cilium":  opts.CiliumValuesFile,
+		"metallb": opts.MetalLBValuesFile,
+	}, opts.HelmSet)
+	if err != nil {
+		return err
+	}
+	m.helmManager.SetValueOverrides(valueOverrides)
+
+	if opts.CiliumClusterMesh {
+		if opts.CNI != "cilium" {
+			return fmt.Errorf("cilium cluster mesh requires the cilium CNI, got %s", opts.CNI)
+		}
+		if opts.NumClusters < 2 {
+			logger.Warnf("⚠️ skipping the Cilium cluster mesh, it needs at least 2 clusters")
+			opts.CiliumClusterMesh = false
+		}
+	}
+
+	mirrors, err := registryMirrors(opts)
+	if err != nil {
+		return err
+	}
+	credentials, err := registryCredentials(opts.RegistryAuth, mirrors)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		return m.dryRunCreate(opts)
+	}
 
 	// check prerequisites
-	if err := m.checkPrerequisites(opts.PreferredContainerEngine); err != nil {
+	if err := m.checkPrerequisites(opts.PreferredContainerEngine, opts.ContainerEnginePreference); err != nil {
 		return fmt.Errorf("prerequisites check failed: %w", err)
 	}
 
@@ -164,17 +410,22 @@ func (m *Manager) CreateClusters(opts *CreateOptions) error {
 	}
 
 	// get kubernetes version
-	kindestNode, err := m.getKindestNodeImage(opts.K8sVersion)
+	kindestNode, err := m.resolveNodeImage(opts)
 	if err != nil {
 		return fmt.Errorf("failed to get kind node image: %w", err)
 	}
 
 	// create docker network
-	actualGatewayIP, err := m.createDockerNetwork(opts.GatewayIP, opts.SubnetCIDR)
+	actualGatewayIP, actualSubnet, err := m.createDockerNetwork(opts.GatewayIP, opts.SubnetCIDR, opts.IPFamily)
 	if err != nil {
 		return fmt.Errorf("failed to create Docker network: %w", err)
 	}
-	// Update gateway IP if it was generated from subnetCIDR
+	// Update subnet if a free subnet was selected or the existing network uses another one
+	if actualSubnet != opts.SubnetCIDR {
+		logger.Debugf("using subnet %s (updated from %s)", actualSubnet, opts.SubnetCIDR)
+		opts.SubnetCIDR = actualSubnet
+	}
+	// Update gateway IP if it was generated from subnetCIDR or taken from the existing network
 	if actualGatewayIP != opts.GatewayIP {
 		opts.GatewayIP = actualGatewayIP
 		logger.Debugf("using generated gateway IP %s (from subnet %s)", actualGatewayIP, opts.SubnetCIDR)
@@ -189,79 +440,326 @@ func (m *Manager) CreateClusters(opts *CreateOptions) error {
 	} else {
 		logger.Debugf("using registry port %d for all clusters", regPort)
 	}
+	opts.RegistryPort = regPort
+
+	// the registry mirrors and MetalLB tracking are shared by all clusters, so set them up once before creating any
+	certDir, err := prepareRegistryCert(opts.RegistryTLS)
+	if err != nil {
+		return err
+	}
+	if err := m.setupKindRegistryMirrors(regPort, config.KindRegistryName, config.KindNetworkName, mirrors, credentials, certDir); err != nil {
+		logger.Warnf("failed to setup registry mirrors: %v", err)
+		// Don't fail cluster creation if registry setup fails
+	}
+	if opts.InstallMetalLB {
+		m.metallbManager.SetIPsPerCluster(opts.MetalLBPoolSize)
+		m.metallbManager.SetIPRanges(opts.MetalLBIPRanges)
+		m.metallbManager.SetSubnet(opts.SubnetCIDR)
+		m.metallbManager.SetBGPPeer(opts.MetalLBBGPPeer)
+		if err := m.metallbManager.InitializeTracking(opts.Project); err != nil {
+			logger.Warnf("failed to initialize MetalLB tracking: %v", err)
+		}
+	}
+
+	if opts.CiliumClusterMesh {
+		if err := m.enableClusterMesh(opts); err != nil {
+			return err
+		}
+	}
 
 	// create clusters
+	m.helmManager.SetContext(ctx)
+	results := make([]report.CreateResult, opts.NumClusters)
+	var createErr error
+	if opts.Parallel && opts.NumClusters > 1 {
+		createErr = m.provisionClustersParallel(ctx, opts, kindestNode, regPort, results)
+	} else {
+		for i := 1; i <= opts.NumClusters; i++ {
+			if createErr = m.provisionCluster(ctx, i, opts, kindestNode, regPort, &results[i-1]); createErr != nil {
+				break
+			}
+		}
+	}
+	if createErr != nil {
+		report.PrintCreateSummary(logger.Output(), results, time.Since(started))
+		if opts.CleanupOnFailure {
+			m.cleanupFailedCreate(opts, results)
+		}
+		return createErr
+	}
+
+	// the mesh can only be connected once Cilium is up on every cluster, it spans them all so a failure is
+	// recorded on each of them
+	if opts.CiliumClusterMesh {
+		if err := m.connectClusterMesh(opts); err != nil {
+			logger.Errorf("failed to connect the Cilium cluster mesh: %v", err)
+			for i := range results {
+				results[i].AddAddonError("clustermesh", err)
+			}
+		}
+	}
+
+	report.PrintCreateSummary(logger.Output(), results, time.Since(started))
+	if report.AddonsFailed(results) {
+		logger.Warnf("⚠️ created %d Kind cluster(s), but some of their add-ons failed", opts.NumClusters)
+		return nil
+	}
+	logger.Infof("🎉 successfully created %d Kind cluster(s)", opts.NumClusters)
+	return nil
+}
+
+// enableClusterMesh assigns every cluster its Cilium cluster ID, so Cilium is installed ready to be meshed
+func (m *Manager) enableClusterMesh(opts *CreateOptions) error {
+	contextNames := make([]string, 0, opts.NumClusters)
 	for i := 1; i <= opts.NumClusters; i++ {
-		var clusterName, contextName string
-		if opts.NumClusters == 1 {
-			// if only one cluster, don't add suffix
-			clusterName = "kind1"
-			contextName = opts.Project
-		} else {
-			clusterName = fmt.Sprintf("kind%d", i)
-			contextName = fmt.Sprintf("%s-%d", opts.Project, i)
+		contextNames = append(contextNames, kindContextName(opts.Project, i, opts.NumClusters))
+	}
+
+	clusterIDs, err := services.AssignClusterMeshIDs(contextNames, opts.CiliumClusterIDs)
+	if err != nil {
+		return fmt.Errorf("failed to assign cilium cluster IDs: %w", err)
+	}
+	if err := m.ciliumManager.EnableClusterMesh(clusterIDs); err != nil {
+		return err
+	}
+	opts.CiliumClusterIDs = clusterIDs
+	return nil
+}
+
+// connectClusterMesh connects the clustermesh-apiservers of all clusters, reached on the control-plane node IPs
+func (m *Manager) connectClusterMesh(opts *CreateOptions) error {
+	clusters := make([]services.ClusterMeshCluster, 0, opts.NumClusters)
+	for i := 1; i <= opts.NumClusters; i++ {
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+		contextName := kindContextName(opts.Project, i, opts.NumClusters)
+
+		clusterIP, err := m.getKindClusterIP(clusterName)
+		if err != nil {
+			return err
+		}
+		clusters = append(clusters, services.ClusterMeshCluster{
+			Name:    contextName,
+			ID:      opts.CiliumClusterIDs[contextName],
+			Address: clusterIP,
+		})
+	}
+
+	if err := m.ciliumManager.ConnectClusterMesh(clusters); err != nil {
+		return err
+	}
+	logger.Infof("✅ connected %d clusters into a Cilium cluster mesh", len(clusters))
+	return nil
+}
+
+// provisionCluster creates a single kind cluster and installs its CNI and add-ons. Add-on failures are recorded on
+// the result rather than returned so the cluster itself is still usable
+func (m *Manager) provisionCluster(ctx context.Context, clusterIndex int, opts *CreateOptions, kindestNode string, regPort int, result *report.CreateResult) error {
+	clusterName := ClusterName(opts.ClusterPrefix, clusterIndex, opts.NumClusters)
+	contextName := kindContextName(opts.Project, clusterIndex, opts.NumClusters)
+
+	// time the cluster and each of its steps for the create summary
+	started := time.Now()
+	result.Cluster = clusterName
+	result.CNI = opts.CNI
+	result.LoadBalancer = loadBalancerName(opts)
+	defer func() { result.Duration = time.Since(started) }()
+
+	// kind can't cancel a cluster that is being created, so the context is checked around it
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return fmt.Errorf("aborted creating cluster %s: %w", clusterName, err)
+	}
+	contextName, err := m.createCluster(clusterName, contextName, kindestNode, clusterIndex, opts, regPort)
+	result.Created = !errors.Is(err, errClusterKept)
+	if err != nil {
+		result.Err = err
+		return fmt.Errorf("failed to create cluster %s: %w", clusterName, err)
+	}
+	result.AddStep("cluster", started)
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return fmt.Errorf("aborted provisioning cluster %s: %w", clusterName, err)
+	}
+
+	if opts.CPU != "" || opts.Memory != "" {
+		if err := m.limitNodeResources(clusterName, opts.CPU, opts.Memory); err != nil {
+			result.Err = err
+			return fmt.Errorf("failed to limit the node resources of %s: %w", clusterName, err)
 		}
+	}
+
+	if err := advertiseFakeGPUs(contextName, clusterName, workerNodeConfigs(opts)); err != nil {
+		logger.Errorf("failed to advertise fake GPUs on %s: %v", clusterName, err)
+	}
 
-		if err := m.createCluster(clusterName, contextName, kindestNode, opts.NodeCount, i, opts, regPort); err != nil {
-			return fmt.Errorf("failed to create cluster %s: %w", clusterName, err)
+	// the CNI has to be ready before the load balancer, otherwise the MetalLB speakers crashloop.
+	// kindnet is deployed by kind itself, so there is nothing to install for it
+	step := time.Now()
+	// install cilium after cluster creation (only if cilium CNI is selected)
+	if opts.CNI == "cilium" {
+		if err := m.ciliumManager.InstallCilium(contextName, clusterIndex); err != nil {
+			logger.Errorf("failed to install Cilium on %s: %v", contextName, err)
+			result.AddAddonError("cilium", err)
 		}
+	}
 
-		if opts.InstallMetalLB {
-			// initialize tracking before first cluster configuration
-			if i == 1 {
-				if err := m.metallbManager.InitializeTracking(opts.Project); err != nil {
-					logger.Warnf("failed to initialize MetalLB tracking: %v", err)
-				}
-			}
+	// install calico after cluster creation (only if calico CNI is selected)
+	if opts.CNI == "calico" {
+		if err := m.calicoManager.InstallCalico(contextName); err != nil {
+			logger.Errorf("failed to install Calico on %s: %v", contextName, err)
+			result.AddAddonError("calico", err)
+		}
+	}
 
-			if err := m.metallbManager.InstallMetalLB(contextName); err != nil {
-				logger.Errorf("failed to install MetalLB on %s: %v", contextName, err)
+	// install flannel after cluster creation (only if flannel CNI is selected)
+	if opts.CNI == "flannel" {
+		if err := m.flannelManager.InstallFlannel(contextName, opts.PodSubnet); err != nil {
+			logger.Errorf("failed to install Flannel on %s: %v", contextName, err)
+			result.AddAddonError("flannel", err)
+		}
+	}
+
+	if opts.CNI == "none" {
+		logger.Infof("no CNI installed on %s, its nodes stay NotReady until one is applied", contextName)
+	}
+	if opts.CNI == "cilium" || opts.CNI == "calico" || opts.CNI == "flannel" {
+		result.AddStep(opts.CNI, step)
+	}
+
+	if opts.InstallMetalLB {
+		step = time.Now()
+		if err := m.metallbManager.InstallMetalLB(contextName); err != nil {
+			logger.Errorf("failed to install MetalLB on %s: %v", contextName, err)
+			result.AddAddonError("metallb", err)
+		} else {
+			// configure MetalLB after installation
+			// get cluster IP for kind (using container runtime inspect)
+			clusterIP, err := m.getKindClusterIP(clusterName)
+			if err != nil {
+				logger.Errorf("failed to get Kind cluster IP for %s: %v", clusterName, err)
+				result.AddAddonError("metallb", err)
 			} else {
-				// configure MetalLB after installation
-				// get cluster IP for kind (using container runtime inspect)
-				clusterIP, err := m.getKindClusterIP(clusterName)
-				if err != nil {
-					logger.Errorf("failed to get Kind cluster IP for %s: %v", clusterName, err)
-				} else {
-					if err := m.metallbManager.ConfigureMetalLB(contextName, clusterIP, i, opts.NumClusters, opts.Project); err != nil {
-						logger.Errorf("failed to configure MetalLB on %s: %v", contextName, err)
-					}
+				if err := m.metallbManager.ConfigureMetalLB(contextName, clusterIP, clusterIndex, opts.NumClusters, opts.Project); err != nil {
+					logger.Errorf("failed to configure MetalLB on %s: %v", contextName, err)
+					result.AddAddonError("metallb", err)
 				}
 			}
 		}
+		result.AddStep("metallb", step)
+	}
 
-		if opts.InstallCloudProvider {
-			if err := m.cloudProviderManager.Install(contextName, false); err != nil {
-				logger.Errorf("failed to install cloud-provider-kind on %s: %v", contextName, err)
-			}
+	if opts.InstallCloudProvider {
+		step = time.Now()
+		if err := m.cloudProviderManager.Install(contextName, false); err != nil {
+			logger.Errorf("failed to install cloud-provider-kind on %s: %v", contextName, err)
+			result.AddAddonError("cloud-provider-kind", err)
 		}
+		result.AddStep("cloud-provider-kind", step)
+	}
 
-		// install cilium after cluster creation (only if cilium CNI is selected)
-		if opts.CNI == "cilium" {
-			if err := m.ciliumManager.InstallCilium(contextName); err != nil {
-				logger.Errorf("failed to install Cilium on %s: %v", contextName, err)
-			}
+	// charts written for a cloud expect its class name, so the alias takes over as the default
+	defaultStorageClass := config.KindDefaultStorageClass
+	if opts.StorageClass != "" && opts.StorageClass != config.KindDefaultStorageClass {
+		if err := m.createStorageClass(contextName, opts.StorageClass); err != nil {
+			logger.Errorf("failed to create the %s storageclass on %s: %v", opts.StorageClass, contextName, err)
+		} else {
+			defaultStorageClass = opts.StorageClass
 		}
 	}
 
-	logger.Infof("🎉 successfully created %d Kind cluster(s)", opts.NumClusters)
+	// make the bundled local-path storageclass, or its alias, the default
+	if opts.EnableStorageClass {
+		if err := m.setDefaultStorageClass(contextName, defaultStorageClass); err != nil {
+			logger.Errorf("failed to set the default storageclass on %s: %v", contextName, err)
+		}
+	}
+
+	// install metrics-server
+	if opts.EnableMetrics {
+		step = time.Now()
+		if err := m.metricsServerManager.InstallMetricsServer(contextName); err != nil {
+			logger.Errorf("failed to install metrics-server on %s: %v", contextName, err)
+			result.AddAddonError("metrics-server", err)
+		}
+		result.AddStep("metrics-server", step)
+	}
+
+	return nil
+}
+
+// cleanupFailedCreate deletes the clusters a failed create (re)created along with their contexts and MetalLB
+// allocations, clusters it left alone are kept
+func (m *Manager) cleanupFailedCreate(opts *CreateOptions, results []report.CreateResult) {
+	currentClusters, err := m.ClusterNames()
+	if err != nil {
+		logger.Errorf("failed to clean up after the failed create: %v", err)
+		return
+	}
+
+	for i, result := range results {
+		if !result.Created || !slices.Contains(currentClusters, result.Cluster) {
+			continue
+		}
+		logger.Warnf("⚠️ deleting the partially created cluster %s", result.Cluster)
+		if err := m.DeleteClusters(&DeleteOptions{
+			Project:       opts.Project,
+			ClusterPrefix: opts.ClusterPrefix,
+			NumClusters:   opts.NumClusters,
+			ClusterIndex:  i + 1,
+		}); err != nil {
+			logger.Errorf("failed to delete cluster %s: %v", result.Cluster, err)
+		}
+	}
+}
+
+// loadBalancerName returns the load balancers installed on new clusters for the create summary, empty for none
+func loadBalancerName(opts *CreateOptions) string {
+	var names []string
+	if opts.InstallMetalLB {
+		names = append(names, "metallb")
+	}
+	if opts.InstallCloudProvider {
+		names = append(names, "cloud-provider-kind")
+	}
+	return strings.Join(names, "+")
+}
+
+// provisionClustersParallel provisions the clusters concurrently and, once all of them are done, reports every cluster that failed
+func (m *Manager) provisionClustersParallel(ctx context.Context, opts *CreateOptions, kindestNode string, regPort int, results []report.CreateResult) error {
+	logger.Infof("creating %d Kind clusters in parallel", opts.NumClusters)
+
+	errs := util.RunParallel(opts.NumClusters, config.MaxParallelClusters, func(clusterIndex int) error {
+		return m.provisionCluster(ctx, clusterIndex, opts, kindestNode, regPort, &results[clusterIndex-1])
+	})
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			logger.Errorf("❌ %v", err)
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d Kind cluster(s) failed to create: %w", len(failed), opts.NumClusters, errors.Join(failed...))
+	}
+
 	return nil
 }
 
 // DeleteClusters deletes multiple kind clusters
 func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
-	logger.Infof("-----> 🚨 deleting %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	if opts.ClusterIndex > 0 {
+		logger.Infof("-----> 🚨 deleting Kind cluster %d of project %s <-----", opts.ClusterIndex, opts.Project)
+	} else {
+		logger.Infof("-----> 🚨 deleting %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	}
 
 	for i := 1; i <= opts.NumClusters; i++ {
-		var clusterName, contextName string
-		if opts.NumClusters == 1 {
-			// if only one cluster, don't add suffix
-			clusterName = "kind1"
-			contextName = opts.Project
-		} else {
-			clusterName = fmt.Sprintf("kind%d", i)
-			contextName = fmt.Sprintf("%s-%d", opts.Project, i)
+		if opts.ClusterIndex > 0 && i != opts.ClusterIndex {
+			continue
 		}
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+		contextName := kindContextName(opts.Project, i, opts.NumClusters)
 
 		status := logger.NewStatus()
 		status.Start(fmt.Sprintf("deleting Kind cluster %s", clusterName))
@@ -272,7 +770,7 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 			logger.Warnf("failed to terminate cloud-provider-kind process for context %s: %v", contextName, err)
 		}
 
-		if err := m.provider.Delete(clusterName, ""); err != nil {
+		if err := m.provider.Delete(clusterName, k8s.ExplicitKubeConfigPath()); err != nil {
 			success = false
 			logger.Errorf("failed to delete cluster %s: %v", clusterName, err)
 		}
@@ -286,6 +784,16 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 		status.End(success)
 	}
 
+	// the rest of the project stays, only free the deleted cluster's MetalLB range
+	if opts.ClusterIndex > 0 {
+		contextName := kindContextName(opts.Project, opts.ClusterIndex, opts.NumClusters)
+		if err := m.metallbManager.ReleaseAllocation(opts.Project, contextName); err != nil {
+			logger.Warnf("failed to release MetalLB allocation for %s: %v", contextName, err)
+		}
+		logger.Infof("successfully deleted Kind cluster %s", ClusterName(opts.ClusterPrefix, opts.ClusterIndex, opts.NumClusters))
+		return nil
+	}
+
 	// clean up project configuration file
 	configManager := config.NewConfigManager()
 	if err := configManager.DeleteConfig(opts.Project); err != nil {
@@ -296,7 +804,7 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 
 	// Delete kind-registry container if force flag is set
 	if opts.Force {
-		if err := m.deleteKindRegistry(); err != nil {
+		if err := m.deleteKindRegistry(opts.RegistryMirrors); err != nil {
 			logger.Warnf("failed to delete %s container: %v", config.KindRegistryName, err)
 		} else {
 			logger.Infof("deleted %s container", config.KindRegistryName)
@@ -309,12 +817,57 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 
 // StatusClusters shows the status of kind clusters
 func (m *Manager) StatusClusters(opts *StatusOptions) error {
-	logger.Infof("-----> 📊 checking status of %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	if opts.OutputFormat == "" {
+		opts.OutputFormat = report.FormatTable
+	}
+	if err := report.ValidateFormat(opts.OutputFormat); err != nil {
+		return err
+	}
+
+	// keep stdout machine readable for json output
+	if opts.OutputFormat == report.FormatTable {
+		logger.Infof("-----> 📊 checking status of %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	}
+
+	statuses, err := m.collectStatuses(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.OutputFormat == report.FormatJSON {
+		return report.PrintJSON(os.Stdout, statuses)
+	}
+
+	// print table
+	out := logger.Output()
+	fmt.Fprintf(out, "\nProject: %s\n", opts.Project)
+	if opts.RegistryPort > 0 {
+		fmt.Fprintf(out, "Registry: localhost:%d\n", opts.RegistryPort)
+	}
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tCONTEXT\tSTATUS\tNODES\tVERSION\tIP")
+	fmt.Fprintln(w, "-------\t-------\t------\t-----\t-------\t---")
+
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Cluster, s.Context, s.Status, s.Nodes, s.Version, s.IP)
+	}
+
+	w.Flush()
+	return nil
+}
+
+// collectStatuses gathers the status of each kind cluster in the project
+func (m *Manager) collectStatuses(opts *StatusOptions) ([]report.ClusterStatus, error) {
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return nil, err
+	}
 
 	// get list of existing kind clusters
 	existingClusters, err := m.provider.List()
 	if err != nil {
-		return fmt.Errorf("failed to list kind clusters: %w", err)
+		return nil, fmt.Errorf("failed to list kind clusters: %w", err)
 	}
 
 	// create a map of existing cluster names for quick lookup
@@ -323,43 +876,51 @@ func (m *Manager) StatusClusters(opts *StatusOptions) error {
 		clusterMap[clusterName] = true
 	}
 
-	// prepare table data
-	type clusterStatus struct {
-		clusterName string
-		contextName string
-		status      string
-		ip          string
-	}
-
-	var statuses []clusterStatus
+	var statuses []report.ClusterStatus
 
 	for i := 1; i <= opts.NumClusters; i++ {
-		var clusterName, contextName string
-		if opts.NumClusters == 1 {
-			// if only one cluster, don't add suffix
-			clusterName = "kind1"
-			contextName = opts.Project
-		} else {
-			clusterName = fmt.Sprintf("kind%d", i)
-			contextName = fmt.Sprintf("%s-%d", opts.Project, i)
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+		contextName := kindContextName(opts.Project, i, opts.NumClusters)
+
+		// kind doesn't report host, kubelet or api server state separately
+		clusterStatus := report.ClusterStatus{
+			Cluster:   clusterName,
+			Context:   contextName,
+			IP:        "N/A",
+			Nodes:     "N/A",
+			Version:   "N/A",
+			Host:      "N/A",
+			Kubelet:   "N/A",
+			APIServer: "N/A",
 		}
 
 		// check if cluster exists
 		if !clusterMap[clusterName] {
-			statuses = append(statuses, clusterStatus{
-				clusterName: clusterName,
-				contextName: contextName,
-				status:      "Not Found",
-				ip:          "N/A",
-			})
+			clusterStatus.Status = "Not Found"
+			statuses = append(statuses, clusterStatus)
+			continue
+		}
+
+		// kind has no native stop, so a stopped control-plane container means a stopped cluster
+		if running, err := docker.IsContainerRunning(containerRuntime, clusterName+"-control-plane"); err == nil && !running {
+			clusterStatus.Status = "Stopped"
+			statuses = append(statuses, clusterStatus)
+			continue
+		}
+
+		// without a cluster prefix another project may have created a cluster under the same name
+		if owner, err := m.ClusterProject(clusterName); err != nil {
+			logger.Debugf("failed to get the project of cluster %s: %v", clusterName, err)
+		} else if owner != "" && owner != opts.Project {
+			clusterStatus.Status = fmt.Sprintf("Owned by project %s", owner)
+			statuses = append(statuses, clusterStatus)
 			continue
 		}
 
 		// get cluster IP
-		ip := "N/A"
 		clusterIP, err := m.getKindClusterIP(clusterName)
 		if err == nil {
-			ip = clusterIP
+			clusterStatus.IP = clusterIP
 		}
 
 		// check if cluster is ready by trying to get nodes
@@ -368,60 +929,160 @@ func (m *Manager) StatusClusters(opts *StatusOptions) error {
 		if err != nil {
 			status = "Not Ready (kubeconfig issue)"
 		} else {
-			nodes, err := clientManager.GetClientset().CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+			ready, total, err := clientManager.CountReadyNodes()
 			if err != nil {
 				status = "Not Ready (API server not responding)"
-			} else if len(nodes.Items) == 0 {
+			} else if total == 0 {
 				status = "Not Ready (no nodes found)"
 			} else {
-				// check if all nodes are ready
-				allReady := true
-				for _, node := range nodes.Items {
-					for _, condition := range node.Status.Conditions {
-						if condition.Type == "Ready" && condition.Status != "True" {
-							allReady = false
-							break
-						}
-					}
-					if !allReady {
-						break
+				clusterStatus.Nodes = fmt.Sprintf("%d/%d", ready, total)
+				if ready != total {
+					status = "Not Ready (nodes not ready)"
+					// nodes never become ready without a CNI, which is expected until the user applies one
+					if opts.CNI == "none" {
+						status = "No CNI installed"
 					}
 				}
-				if !allReady {
-					status = "Not Ready (nodes not ready)"
+				if serverVersion, err := clientManager.GetServerVersion(); err == nil {
+					clusterStatus.Version = serverVersion
 				}
 			}
 		}
 
-		statuses = append(statuses, clusterStatus{
-			clusterName: clusterName,
-			contextName: contextName,
-			status:      status,
-			ip:          ip,
-		})
+		clusterStatus.Status = status
+		statuses = append(statuses, clusterStatus)
 	}
 
-	// print table
-	fmt.Printf("\nProject: %s\n\n", opts.Project)
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "CLUSTER\tCONTEXT\tSTATUS\tIP")
-	fmt.Fprintln(w, "-------\t-------\t------\t---")
+	return statuses, nil
+}
 
-	for _, s := range statuses {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.clusterName, s.contextName, s.status, s.ip)
+// StartClusters starts the node containers of previously stopped kind clusters
+func (m *Manager) StartClusters(opts *StartOptions) error {
+	logger.Infof("-----> 📢 starting %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
 	}
 
-	w.Flush()
+	for i := 1; i <= opts.NumClusters; i++ {
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+		contextName := kindContextName(opts.Project, i, opts.NumClusters)
+
+		nodeNames, err := m.getNodeContainerNames(clusterName)
+		if err != nil {
+			return err
+		}
+
+		status := logger.NewStatus()
+		status.Start(fmt.Sprintf("starting Kind cluster %s (%d/%d)", clusterName, i, opts.NumClusters))
+
+		if err := docker.StartContainers(containerRuntime, nodeNames); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to start cluster %s: %w", clusterName, err)
+		}
+
+		// wait for the API server and nodes to come back, without a CNI the nodes stay NotReady until the user applies one
+		if opts.CNI != "none" {
+			clientManager, err := k8s.NewClientManagerForContext(contextName)
+			if err != nil {
+				status.End(false)
+				return fmt.Errorf("failed to create client for context %s: %w", contextName, err)
+			}
+			if err := clientManager.WaitForNodesReady(5 * time.Minute); err != nil {
+				status.End(false)
+				return fmt.Errorf("cluster %s did not become ready: %w", clusterName, err)
+			}
+		}
+		status.End(true)
+	}
+
+	logger.Infof("✓ successfully started %d Kind cluster(s)", opts.NumClusters)
+	return nil
+}
+
+// StopClusters stops the node containers of kind clusters without deleting them
+func (m *Manager) StopClusters(opts *StopOptions) error {
+	logger.Infof("-----> 🚨 stopping %d Kind cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+
+		nodeNames, err := m.getNodeContainerNames(clusterName)
+		if err != nil {
+			return err
+		}
+
+		status := logger.NewStatus()
+		status.Start(fmt.Sprintf("stopping Kind cluster %s (%d/%d)", clusterName, i, opts.NumClusters))
+
+		if err := docker.StopContainers(containerRuntime, nodeNames); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to stop cluster %s: %w", clusterName, err)
+		}
+		status.End(true)
+	}
+
+	logger.Infof("✓ successfully stopped %d Kind cluster(s)", opts.NumClusters)
 	return nil
 }
 
+// getNodeContainerNames returns the container names backing the nodes of a kind cluster
+func (m *Manager) getNodeContainerNames(clusterName string) ([]string, error) {
+	nodes, err := m.provider.ListNodes(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for cluster %s: %w", clusterName, err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.String())
+	}
+	return names, nil
+}
+
+// ClusterProject returns the project recorded on the nodes of the kind cluster, empty for clusters created before
+// lok8s labelled its nodes
+func (m *Manager) ClusterProject(clusterName string) (string, error) {
+	kubeconfig, err := m.provider.KubeConfig(clusterName, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig of cluster %s: %w", clusterName, err)
+	}
+	return k8s.ClusterProject(kubeconfig)
+}
+
+// limitNodeResources applies CPU and memory limits to the node containers of a kind cluster,
+// kind's config has no resource settings so they are updated through the container runtime
+func (m *Manager) limitNodeResources(clusterName, cpu, memory string) error {
+	nodeNames, err := m.getNodeContainerNames(clusterName)
+	if err != nil {
+		return err
+	}
+
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("limiting node containers of %s to cpus=%q memory=%q", clusterName, cpu, memory)
+	return docker.UpdateContainerResources(containerRuntime, nodeNames, cpu, memory)
+}
+
 // ListClusters lists all kind clusters using the SDK
 func (m *Manager) ListClusters() error {
 	logger.Info("📋 Kind clusters:")
 
-	clusters, err := m.provider.List()
+	clusters, err := m.ClusterNames()
 	if err != nil {
-		return fmt.Errorf("failed to list kind clusters: %w", err)
+		return err
 	}
 
 	if len(clusters) == 0 {
@@ -436,9 +1097,18 @@ func (m *Manager) ListClusters() error {
 	return nil
 }
 
-// LoadImage loads a Docker image into kind clusters
+// ClusterNames returns the names of the existing kind clusters
+func (m *Manager) ClusterNames() ([]string, error) {
+	clusters, err := m.provider.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+	return clusters, nil
+}
+
+// LoadImage loads Docker images into kind clusters
 func (m *Manager) LoadImage(opts *LoadImageOptions) error {
-	logger.Infof("-----> 📦 loading image %s into %d Kind cluster(s) for project %s <-----", opts.Image, opts.NumClusters, opts.Project)
+	logger.Infof("-----> 📦 loading %d image(s) into %d Kind cluster(s) for project %s <-----", len(opts.Images), opts.NumClusters, opts.Project)
 
 	// check if kind binary is available
 	kindPath, err := exec.LookPath("kind")
@@ -446,68 +1116,100 @@ func (m *Manager) LoadImage(opts *LoadImageOptions) error {
 		return fmt.Errorf("kind binary not found in PATH: %w", err)
 	}
 
-	for i := 1; i <= opts.NumClusters; i++ {
-		var clusterName string
-		if opts.NumClusters == 1 {
-			// if only one cluster, don't add suffix
-			clusterName = "kind1"
-		} else {
-			clusterName = fmt.Sprintf("kind%d", i)
-		}
+	// verify clusters exist using SDK
+	existingClusters, err := m.provider.List()
+	if err != nil {
+		return fmt.Errorf("failed to list kind clusters: %w", err)
+	}
 
-		// verify cluster exists using SDK
-		existingClusters, err := m.provider.List()
-		if err != nil {
-			return fmt.Errorf("failed to list kind clusters: %w", err)
-		}
+	clusterMap := make(map[string]bool)
+	for _, existingCluster := range existingClusters {
+		clusterMap[existingCluster] = true
+	}
 
-		clusterExists := false
-		for _, existingCluster := range existingClusters {
-			if existingCluster == clusterName {
-				clusterExists = true
-				break
-			}
+	// validate archives before iterating clusters
+	for _, image := range opts.Images {
+		if strings.HasSuffix(image, ".tar") && !docker.IsImageArchive(image) {
+			return fmt.Errorf("image archive %s does not exist", image)
 		}
+	}
 
-		if !clusterExists {
+	// track clusters each image failed to load into for the summary
+	failures := make(map[string][]string)
+	loadedClusters := 0
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+
+		if !clusterMap[clusterName] {
 			logger.Warnf("cluster %s not found, skipping image load", clusterName)
 			continue
 		}
+		loadedClusters++
 
-		status := logger.NewStatus()
-		status.Start(fmt.Sprintf("loading image %s into cluster %s (%d/%d)", opts.Image, clusterName, i, opts.NumClusters))
+		for _, image := range opts.Images {
+			status := logger.NewStatus()
+			status.Start(fmt.Sprintf("loading image %s into cluster %s (%d/%d)", image, clusterName, i, opts.NumClusters))
+
+			// archives are loaded from disk rather than the local image store
+			loadType := "docker-image"
+			if docker.IsImageArchive(image) {
+				loadType = "image-archive"
+			}
 
-		cmd := exec.Command(kindPath, "load", "docker-image", opts.Image, "--name", clusterName)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+			cmd := exec.Command(kindPath, "load", loadType, image, "--name", clusterName)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
 
-		if err := cmd.Run(); err != nil {
-			status.End(false)
-			return fmt.Errorf("failed to load image %s into cluster %s: %w", opts.Image, clusterName, err)
+			if err := cmd.Run(); err != nil {
+				status.End(false)
+				logger.Errorf("failed to load image %s into cluster %s: %v", image, clusterName, err)
+				failures[image] = append(failures[image], clusterName)
+				continue
+			}
+
+			status.End(true)
+			logger.Infof("✓ successfully loaded image %s into cluster %s", image, clusterName)
+		}
+	}
+
+	logger.Infof("📦 image load summary:")
+	for _, image := range opts.Images {
+		if failed, ok := failures[image]; ok {
+			logger.Errorf("  ✗ %s failed on %d/%d cluster(s): %s", image, len(failed), loadedClusters, strings.Join(failed, ", "))
+		} else {
+			logger.Infof("  ✓ %s loaded into %d Kind cluster(s)", image, loadedClusters)
 		}
+	}
 
-		status.End(true)
-		logger.Infof("✓ successfully loaded image %s into cluster %s", opts.Image, clusterName)
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to load %d of %d image(s)", len(failures), len(opts.Images))
 	}
 
-	logger.Infof("🎉 successfully loaded image %s into %d Kind cluster(s)", opts.Image, opts.NumClusters)
+	logger.Infof("🎉 successfully loaded %d image(s) into %d Kind cluster(s)", len(opts.Images), loadedClusters)
 	return nil
 }
 
 // checkPrerequisites checks if required tools are installed and running
-func (m *Manager) checkPrerequisites(preferredContainerEngine string) error {
-	var containerRuntime string
-
+func (m *Manager) checkPrerequisites(preferredContainerEngine string, enginePreference []string) error {
 	// Use preferred container engine if specified, otherwise auto-detect
-	if preferredContainerEngine != "" {
-		containerRuntime = preferredContainerEngine
+	if preferredContainerEngine != "" || len(enginePreference) > 0 {
+		m.containerEngine = preferredContainerEngine
+		m.enginePreference = enginePreference
+		m.containerRuntime = ""
+	}
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+	if m.containerEngine != "" {
 		logger.Infof("using preferred container engine: %s", containerRuntime)
 	} else {
-		return errors.New("unable to detect container runtime")
+		logger.Infof("using detected container engine: %s", containerRuntime)
 	}
 
 	// Verify that the container runtime is actually running
-	if err := m.verifyContainerRuntimeRunning(containerRuntime); err != nil {
+	if err := docker.VerifyRuntimeRunning(containerRuntime); err != nil {
 		return fmt.Errorf("container runtime not running: %w", err)
 	}
 
@@ -520,35 +1222,38 @@ func (m *Manager) checkPrerequisites(preferredContainerEngine string) error {
 	return nil
 }
 
-// verifyContainerRuntimeRunning verifies that the container runtime daemon is actually running
-func (m *Manager) verifyContainerRuntimeRunning(runtime string) error {
-	logger.Debugf("verifying %s daemon is running", runtime)
+// Doctor runs the kind prerequisite checks without provisioning anything
+func (m *Manager) Doctor(preferredContainerEngine string, enginePreference []string) []report.Check {
+	containerRuntime := preferredContainerEngine
+	if containerRuntime == "" {
+		detected, err := docker.DetectContainerRuntime(enginePreference)
+		if err != nil {
+			return []report.Check{{Name: "container runtime", Err: err}}
+		}
+		containerRuntime = detected
+	}
 
-	// Use 'info' command to check if daemon is running
-	// This will fail if the daemon is not running
-	cmd := exec.Command(runtime, "info")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s daemon is not running: %w", runtime, err)
+	return []report.Check{
+		{Name: fmt.Sprintf("container runtime (%s) running", containerRuntime), Err: docker.VerifyRuntimeRunning(containerRuntime)},
 	}
+}
 
-	logger.Debugf("%s daemon is running", runtime)
-	return nil
+// resolveNodeImage returns the node image to use, preferring an explicit image over the version lookup
+func (m *Manager) resolveNodeImage(opts *CreateOptions) (string, error) {
+	if opts.NodeImage != "" {
+		logger.Debugf("using custom node image %s", opts.NodeImage)
+		return opts.NodeImage, nil
+	}
+	return m.getKindestNodeImage(opts.K8sVersion)
 }
 
 // getKindestNodeImage returns the appropriate kind node image for the given Kubernetes version
 func (m *Manager) getKindestNodeImage(k8sVersion string) (string, error) {
 	if k8sVersion == "stable" {
-		// Get the latest version (first one in the map, which should be the highest)
-		var latestVersion string
-		var latestImage string
-		for version, image := range config.KindK8sVersions {
-			if latestVersion == "" || version > latestVersion {
-				latestVersion = version
-				latestImage = image
-			}
-		}
-		if latestImage == "" {
-			return "", fmt.Errorf("no Kubernetes versions available")
+		// get the latest version
+		_, latestImage, err := config.LatestK8sVersion(config.KindK8sVersions)
+		if err != nil {
+			return "", err
 		}
 		return fmt.Sprintf("kindest/node:%s", latestImage), nil
 	}
@@ -564,12 +1269,59 @@ func (m *Manager) getKindestNodeImage(k8sVersion string) (string, error) {
 		return fmt.Sprintf("kindest/node:%s", version), nil
 	}
 
-	return "", fmt.Errorf("unsupported Kubernetes version: %s", k8sVersion)
+	return "", fmt.Errorf("unsupported Kubernetes version: %s (supported: stable, %s)", k8sVersion, strings.Join(config.SupportedK8sVersions(config.KindK8sVersions), ", "))
 }
 
-// createDockerNetwork creates a Docker network for kind clusters
-// Returns the actual gateway IP used (may be generated from subnetCIDR)
-func (m *Manager) createDockerNetwork(gatewayIP, subnetCIDR string) (string, error) {
+// createDockerNetwork creates a Docker network for kind clusters, with an IPv6 subnet for ipv6 and dual-stack clusters
+// Returns the actual gateway IP and subnet used (a free subnet is picked if subnetCIDR overlaps another network)
+func (m *Manager) createDockerNetwork(gatewayIP, subnetCIDR, ipFamily string) (string, string, error) {
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return "", "", err
+	}
+
+	exists, err := docker.NetworkExists(containerRuntime, config.KindNetworkName)
+	if err != nil {
+		return "", "", err
+	}
+
+	// an existing kind network keeps its own subnet, report that one so the saved config matches it
+	if exists {
+		logger.Infof("network %s already exists", config.KindNetworkName)
+		if ipFamily != config.IPFamilyIPv4 {
+			ipv6Subnet, err := docker.GetNetworkIPv6Subnet(containerRuntime, config.KindNetworkName)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to get IPv6 subnet of existing %s network: %w", config.KindNetworkName, err)
+			}
+			if err := checkNetworkIPFamily(ipFamily, ipv6Subnet); err != nil {
+				return "", "", err
+			}
+		}
+
+		actualSubnet, actualGatewayIP, err := docker.GetNetworkSubnet(containerRuntime, config.KindNetworkName)
+		if err != nil {
+			logger.Warnf("failed to get subnet of existing %s network: %v, assuming %s", config.KindNetworkName, err, subnetCIDR)
+			return gatewayIP, subnetCIDR, nil
+		}
+		if actualSubnet != subnetCIDR {
+			logger.Infof("network %s already exists with subnet %s, using it instead of %s", config.KindNetworkName, actualSubnet, subnetCIDR)
+		}
+		if actualGatewayIP == "" {
+			actualGatewayIP = gatewayIP
+		}
+		return actualGatewayIP, actualSubnet, nil
+	}
+
+	// check if subnet is free and find a free subnet if needed
+	freeSubnetCIDR, err := docker.FindFreeDockerSubnet(containerRuntime, subnetCIDR, config.DefaultSubnetSearchStep, config.DefaultSubnetSearchLimit)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find free subnet starting from %s: %w", subnetCIDR, err)
+	}
+	if freeSubnetCIDR != subnetCIDR {
+		logger.Infof("subnet %s is in use, using free subnet %s instead", subnetCIDR, freeSubnetCIDR)
+		subnetCIDR = freeSubnetCIDR
+	}
+
 	// generate gateway IP from subnetCIDR if subnetCIDR has changed from the default
 	actualGatewayIP := gatewayIP
 	if subnetCIDR != config.DefaultNetworkSubnetCIDR {
@@ -582,14 +1334,29 @@ func (m *Manager) createDockerNetwork(gatewayIP, subnetCIDR string) (string, err
 		}
 	}
 
-	if err := docker.CreateNetwork(config.KindNetworkName, actualGatewayIP, subnetCIDR); err != nil {
-		return "", err
+	ipv6SubnetCIDR := ""
+	if ipFamily != config.IPFamilyIPv4 {
+		ipv6SubnetCIDR = config.KindNetworkSubnetIPv6
 	}
+	// the network is shared by the kind clusters of every project, so it is only marked as created by lok8s
+	if err := docker.CreateNetwork(containerRuntime, config.KindNetworkName, actualGatewayIP, subnetCIDR, ipv6SubnetCIDR, map[string]string{config.ManagedLabel: "true"}); err != nil {
+		return "", "", err
+	}
+
+	return actualGatewayIP, subnetCIDR, nil
+}
 
-	return actualGatewayIP, nil
+// checkNetworkIPFamily returns an error when an existing kind network has no IPv6 subnet for an ipv6 or
+// dual-stack cluster, its nodes would otherwise come up without an IPv6 address
+func checkNetworkIPFamily(ipFamily, ipv6Subnet string) error {
+	if ipFamily == config.IPFamilyIPv4 || ipv6Subnet != "" {
+		return nil
+	}
+	return fmt.Errorf("network %s exists without IPv6, which --ip-family %s needs. Delete the Kind clusters using it, remove the network with 'docker network rm %s' (or 'lok8s prune --force') and create again",
+		config.KindNetworkName, ipFamily, config.KindNetworkName)
 }
 
-// generateGatewayIPFromSubnet generates a gateway IP from a subnet CIDR
+// generateGatewayIPFromSubnet generates a gateway IP from an IPv4 or IPv6 subnet CIDR
 // The gateway IP is the first IP address in the subnet (network IP + 1)
 func generateGatewayIPFromSubnet(subnetCIDR string) (string, error) {
 	_, ipNet, err := net.ParseCIDR(subnetCIDR)
@@ -597,21 +1364,141 @@ func generateGatewayIPFromSubnet(subnetCIDR string) (string, error) {
 		return "", fmt.Errorf("failed to parse subnet CIDR %s: %w", subnetCIDR, err)
 	}
 
+	// keep IPv4 addresses in their 4 byte form so they print as dotted quads
 	ip := ipNet.IP.To4()
 	if ip == nil {
-		ip = ipNet.IP // fallback to original if not IPv4
+		ip = ipNet.IP.To16()
 	}
 
 	// gateway is the first IP in the subnet (network IP + 1)
 	gateway := make(net.IP, len(ip))
 	copy(gateway, ip)
 	gateway[len(gateway)-1]++
+	if !ipNet.Contains(gateway) {
+		return "", fmt.Errorf("subnet CIDR %s is too small to hold a gateway IP", subnetCIDR)
+	}
 
 	return gateway.String(), nil
 }
 
-// confirmRecreation prompts the user to confirm cluster recreation
-func confirmRecreation(clusterName string) bool {
+// subnetsForIPFamily adapts a pod or service subnet to the cluster IP family. The IPv4 default is swapped
+// for the IPv6 default on ipv6 clusters, and a single range is paired with the other family's default on dual-stack clusters
+func subnetsForIPFamily(ipFamily, subnet, defaultIPv4, defaultIPv6 string) string {
+	switch ipFamily {
+	case config.IPFamilyIPv6:
+		if subnet == defaultIPv4 {
+			return defaultIPv6
+		}
+	case config.IPFamilyDual:
+		if strings.Contains(subnet, ",") {
+			return subnet
+		}
+		if ip, _, err := net.ParseCIDR(subnet); err == nil && ip.To4() == nil {
+			return defaultIPv4 + "," + subnet
+		}
+		return subnet + "," + defaultIPv6
+	}
+	return subnet
+}
+
+// parseSubnetsForIPFamily parses a comma separated list of CIDRs and checks it matches the IP family,
+// one IPv4 range for ipv4, one IPv6 range for ipv6, and one of each for dual
+func parseSubnetsForIPFamily(name, subnets, ipFamily string) ([]*net.IPNet, error) {
+	var ipv4Count, ipv6Count int
+	var nets []*net.IPNet
+	for _, subnet := range strings.Split(subnets, ",") {
+		subnet = strings.TrimSpace(subnet)
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s CIDR %s: %w", name, subnet, err)
+		}
+		if ipNet.IP.To4() != nil {
+			ipv4Count++
+		} else {
+			ipv6Count++
+		}
+		nets = append(nets, ipNet)
+	}
+
+	switch ipFamily {
+	case config.IPFamilyIPv4:
+		if ipv4Count != 1 || ipv6Count != 0 {
+			return nil, fmt.Errorf("%s CIDR %s must be a single IPv4 range for an ipv4 cluster", name, subnets)
+		}
+	case config.IPFamilyIPv6:
+		if ipv4Count != 0 || ipv6Count != 1 {
+			return nil, fmt.Errorf("%s CIDR %s must be a single IPv6 range for an ipv6 cluster", name, subnets)
+		}
+	case config.IPFamilyDual:
+		if ipv4Count != 1 || ipv6Count != 1 {
+			return nil, fmt.Errorf("%s CIDR %s must be one IPv4 and one IPv6 range for a dual-stack cluster", name, subnets)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported IP family %s", ipFamily)
+	}
+
+	return nets, nil
+}
+
+// validateClusterSubnets ensures the pod and service ranges are valid for the IP family and don't overlap
+// each other or the docker network subnet the kind nodes are attached to
+func validateClusterSubnets(ipFamily, podSubnet, serviceSubnet, networkSubnet string) error {
+	podNets, err := parseSubnetsForIPFamily("pod", podSubnet, ipFamily)
+	if err != nil {
+		return err
+	}
+	serviceNets, err := parseSubnetsForIPFamily("service", serviceSubnet, ipFamily)
+	if err != nil {
+		return err
+	}
+
+	for _, podNet := range podNets {
+		for _, serviceNet := range serviceNets {
+			if cidrsOverlap(podNet, serviceNet) {
+				return fmt.Errorf("pod CIDR %s overlaps service CIDR %s", podNet, serviceNet)
+			}
+		}
+	}
+
+	if networkSubnet == "" {
+		return nil
+	}
+	_, nodeNet, err := net.ParseCIDR(networkSubnet)
+	if err != nil {
+		return fmt.Errorf("invalid network subnet %s: %w", networkSubnet, err)
+	}
+	for _, podNet := range podNets {
+		if cidrsOverlap(podNet, nodeNet) {
+			return fmt.Errorf("pod CIDR %s overlaps network subnet %s", podNet, networkSubnet)
+		}
+	}
+	for _, serviceNet := range serviceNets {
+		if cidrsOverlap(serviceNet, nodeNet) {
+			return fmt.Errorf("service CIDR %s overlaps network subnet %s", serviceNet, networkSubnet)
+		}
+	}
+
+	return nil
+}
+
+// cidrsOverlap reports whether two networks share any addresses
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// confirmRecreation prompts the user to confirm cluster recreation, refusing when stdin isn't a terminal
+// unless assumeYes is set so non-interactive runs don't block waiting for an answer
+func confirmRecreation(clusterName string, assumeYes bool) bool {
+	if assumeYes {
+		logger.Infof("recreating cluster %s without confirmation", clusterName)
+		return true
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		logger.Warnf("⚠️ stdin is not a terminal, refusing to recreate cluster %s (use --yes to skip the confirmation)", clusterName)
+		return false
+	}
+
 	fmt.Printf("⚠️ cluster '%s' already exists and will be deleted and recreated.\n", clusterName)
 	fmt.Print("Are you sure you want to proceed? [y/N]: ")
 
@@ -626,29 +1513,12 @@ func confirmRecreation(clusterName string) bool {
 	return response == "y" || response == "yes"
 }
 
-// createCluster creates a single kind cluster
-func (m *Manager) createCluster(clusterName, contextName, kindestNode string, nodeCount, clusterIndex int, opts *CreateOptions, regPort int) error {
-	// Get available port
-	cpPort, err := getAvailablePortPrefix(clusterIndex)
-	if err != nil {
-		return fmt.Errorf("failed to get available port prefix: %w", err)
-	}
-
-	// Create temporary config file (needs registry port for containerd config)
-	configPath, err := m.createKindConfig(clusterName, kindestNode, nodeCount, clusterIndex, cpPort, regPort)
-	if err != nil {
-		return fmt.Errorf("failed to create kind config: %w", err)
-	}
-	defer os.Remove(configPath)
-
-	// Setup registry mirrors (only for the first cluster to avoid duplicates)
-	if clusterIndex == 1 {
-		if err := m.setupKindRegistryMirrors(regPort, config.KindRegistryName, config.KindNetworkName); err != nil {
-			logger.Warnf("failed to setup registry mirrors: %v", err)
-			// Don't fail cluster creation if registry setup fails
-		}
-	}
+// errClusterKept is returned when an existing cluster was left as is rather than recreated
+var errClusterKept = errors.New("existing cluster kept")
 
+// createCluster creates a single kind cluster and returns the kube context it can be reached with, kind's own
+// kind-<name> context when renaming it failed
+func (m *Manager) createCluster(clusterName, contextName, kindestNode string, clusterIndex int, opts *CreateOptions, regPort int) (string, error) {
 	// check if cluster already exists
 	clusters, err := m.provider.List()
 	if err == nil {
@@ -656,12 +1526,12 @@ func (m *Manager) createCluster(clusterName, contextName, kindestNode string, no
 			if existingCluster == clusterName {
 				if opts.Recreate {
 					// prompt user for confirmation
-					if !confirmRecreation(clusterName) {
-						return fmt.Errorf("cluster creation cancelled")
+					if !confirmRecreation(clusterName, opts.AssumeYes) {
+						return "", fmt.Errorf("cluster creation cancelled: %w", errClusterKept)
 					}
 
 					logger.Infof("deleting existing cluster %s", clusterName)
-					if err := m.provider.Delete(clusterName, ""); err != nil {
+					if err := m.provider.Delete(clusterName, k8s.ExplicitKubeConfigPath()); err != nil {
 						logger.Warnf("failed to delete existing cluster %s: %v", clusterName, err)
 						// continue anyway, the create might still work
 					} else {
@@ -670,34 +1540,77 @@ func (m *Manager) createCluster(clusterName, contextName, kindestNode string, no
 				} else {
 					logger.Warnf("⚠️ cluster %s already exists", clusterName)
 					logger.Warnf("⚠️ use --recreate flag to delete and recreate existing clusters (DESTRUCTIVE !!!)")
-					return fmt.Errorf("cluster %s already exists, use --recreate to overwrite", clusterName)
+					return "", fmt.Errorf("cluster %s already exists, use --recreate to overwrite: %w", clusterName, errClusterKept)
 				}
 				break
 			}
 		}
 	}
 
+	// Get available port
+	cpPort, err := m.reserveControlPlanePort(clusterIndex)
+	if err != nil {
+		return "", fmt.Errorf("failed to get available port prefix: %w", err)
+	}
+
+	// Create temporary config file (needs registry port for containerd config), only pointing at the mirrors that were started
+	mirrors, err := registryMirrors(opts)
+	if err != nil {
+		return "", err
+	}
+	credentials, err := registryCredentials(opts.RegistryAuth, mirrors)
+	if err != nil {
+		return "", err
+	}
+	certDir, err := registryTLSCertDir(opts.RegistryTLS)
+	if err != nil {
+		return "", err
+	}
+	configPath, err := m.createKindConfig(clusterName, kindestNode, opts.Project, workerNodeConfigs(opts), clusterIndex, cpPort, regPort, mirrors, credentials, certDir, opts.PodSubnet, opts.ServiceSubnet, opts.IPFamily, opts.CNI, opts.ExtraPortMappings, opts.Mounts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create kind config: %w", err)
+	}
+	if config.KeepArtifacts() {
+		logger.Infof("📄 kind config for cluster %s kept at %s", clusterName, configPath)
+	} else {
+		defer os.Remove(configPath)
+	}
+
+	// checked after any recreation so the ports held by the old cluster have been released
+	if err := checkHostPortsAvailable(opts.ExtraPortMappings); err != nil {
+		return "", fmt.Errorf("extra port mappings unavailable: %w", err)
+	}
+
 	// Create the cluster
 	status := logger.NewStatus()
 	status.Start(fmt.Sprintf("creating Kind cluster %s", clusterName))
-	err = m.provider.Create(clusterName, cluster.CreateWithConfigFile(configPath))
+	err = m.provider.Create(clusterName, cluster.CreateWithConfigFile(configPath), cluster.CreateWithKubeconfigPath(k8s.ExplicitKubeConfigPath()))
 	if err != nil {
 		status.End(false)
-		return fmt.Errorf("failed to create kind cluster: %w", err)
+		return "", fmt.Errorf("failed to create kind cluster: %w", err)
 	}
 	status.End(true)
 
 	// Rename context
+	kindContext := fmt.Sprintf("kind-%s", clusterName)
 	status2 := logger.NewStatus()
 	status2.Start(fmt.Sprintf("renaming context for cluster %s", clusterName))
-	if err := k8s.RenameContext(fmt.Sprintf("kind-%s", clusterName), contextName); err != nil {
+	if err := retryKubeconfigEdit("rename context "+kindContext, func() error {
+		return k8s.RenameContext(kindContext, contextName)
+	}); err != nil {
+		// the cluster itself is fine, so it is provisioned through kind's context rather than torn down
 		status2.End(false)
-		return fmt.Errorf("failed to rename context: %w", err)
+		logger.Warnf("⚠️ %v", err)
+		logger.Warnf("⚠️ continuing to provision cluster %s with context %s, rename it afterwards with: kubectl config rename-context %s %s", clusterName, kindContext, kindContext, contextName)
+		contextName = kindContext
+	} else {
+		status2.End(true)
 	}
-	status2.End(true)
 
 	// Update cluster context with correct server URL
-	if err := m.updateClusterContext(clusterIndex, cpPort); err != nil {
+	if err := retryKubeconfigEdit("update cluster context "+contextName, func() error {
+		return m.updateClusterContext(clusterName, cpPort)
+	}); err != nil {
 		logger.Warnf("failed to update cluster context: %v", err)
 	}
 
@@ -711,14 +1624,81 @@ func (m *Manager) createCluster(clusterName, contextName, kindestNode string, no
 		status3.End(true)
 	}
 
+	return contextName, nil
+}
+
+// localPathStorageClassTemplate is a StorageClass backed by the local-path provisioner bundled with kindest/node,
+// with the same settings as its standard class
+const localPathStorageClassTemplate = `apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: %s
+provisioner: rancher.io/local-path
+reclaimPolicy: Delete
+volumeBindingMode: WaitForFirstConsumer
+`
+
+// createStorageClass adds a StorageClass with the given name that provisions through local-path like standard
+func (m *Manager) createStorageClass(contextName, name string) error {
+	clientManager, err := k8s.NewClientManagerForContext(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+	}
+
+	if err := clientManager.ApplyManifest(fmt.Sprintf(localPathStorageClassTemplate, name)); err != nil {
+		return fmt.Errorf("failed to apply storageclass %s: %w", name, err)
+	}
+
+	logger.Infof("💾 created storageclass %s backed by local-path", name)
+	return nil
+}
+
+// setDefaultStorageClass marks the named local-path storageclass as the default
+func (m *Manager) setDefaultStorageClass(contextName, name string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("setting default storageclass for cluster %s", contextName))
+
+	clientManager, err := k8s.NewClientManagerForContext(contextName)
+	if err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+	}
+
+	if err := clientManager.SetDefaultStorageClass(name); err != nil {
+		status.End(false)
+		return err
+	}
+
+	status.End(true)
 	return nil
 }
 
+// kubeconfigRetries is how many times kubeconfig edits made right after creating a cluster are attempted
+const kubeconfigRetries = 5
+
+// kubeconfigRetryInterval is the wait between kubeconfig edit attempts
+var kubeconfigRetryInterval = time.Second
+
+// retryKubeconfigEdit runs edit until it succeeds or runs out of attempts, the kubeconfig may still be locked by
+// another writer or not have kind's context written yet just after the cluster comes up
+func retryKubeconfigEdit(action string, edit func() error) error {
+	var err error
+	for attempt := 1; attempt <= kubeconfigRetries; attempt++ {
+		if err = edit(); err == nil {
+			return nil
+		}
+		if attempt < kubeconfigRetries {
+			logger.Debugf("failed to %s (attempt %d/%d), retrying in %s: %v", action, attempt, kubeconfigRetries, kubeconfigRetryInterval, err)
+			time.Sleep(kubeconfigRetryInterval)
+		}
+	}
+	return fmt.Errorf("failed to %s after %d attempts: %w", action, kubeconfigRetries, err)
+}
+
 // updateClusterContext updates the cluster context with the correct server URL
-func (m *Manager) updateClusterContext(clusterIndex int, port string) error {
-	// Format cluster number
-	number := clusterIndex
-	clusterName := fmt.Sprintf("kind-kind%d", number)
+func (m *Manager) updateClusterContext(kindClusterName, port string) error {
+	// kind names the kubeconfig cluster kind-<cluster name>
+	clusterName := fmt.Sprintf("kind-%s", kindClusterName)
 
 	// Set the cluster server URL using Kubernetes SDK
 	serverURL := fmt.Sprintf("https://%s:%s", "127.0.0.1", port)
@@ -732,65 +1712,160 @@ func (m *Manager) updateClusterContext(clusterIndex int, port string) error {
 }
 
 // createKindConfig creates a kind cluster configuration file
-func (m *Manager) createKindConfig(clusterName, kindestNode string, nodeCount, clusterIndex int, cpPort string, regPort int) (string, error) {
+func (m *Manager) createKindConfig(clusterName, kindestNode, project string, workers []config.WorkerNodeConfig, clusterIndex int, cpPort string, regPort int, mirrors map[string]string, credentials map[string]*docker.RegistryCredentials, registryCertDir, podSubnet, serviceSubnet, ipFamily, cni string, extraPortMappings, mounts []string) (string, error) {
+	clusterConfig := generateKindConfig(kindestNode, project, workers, clusterIndex, cpPort, regPort, mirrors, credentials, registryCertDir, podSubnet, serviceSubnet, ipFamily, cni, extraPortMappings, mounts)
+
+	// Write clusterConfig to temporary file, or the artifacts dir when they are kept
+	configPath, err := config.ArtifactPath(fmt.Sprintf("kind-%s.yaml", clusterName))
+	if err != nil {
+		return "", err
+	}
+
+	// the containerd config holds the registry credentials, so keep it readable by the current user only
+	perm := os.FileMode(0644)
+	if len(credentials) > 0 {
+		perm = 0600
+	}
+	if err := os.WriteFile(configPath, []byte(clusterConfig), perm); err != nil {
+		return "", fmt.Errorf("failed to write kind clusterConfig file: %w", err)
+	}
+
+	return configPath, nil
+}
+
+// generateKindConfig renders the kind cluster configuration YAML, the local registry is served over https when
+// given its certificate directory
+func generateKindConfig(kindestNode, project string, workers []config.WorkerNodeConfig, clusterIndex int, cpPort string, regPort int, mirrors map[string]string, credentials map[string]*docker.RegistryCredentials, registryCertDir, podSubnet, serviceSubnet, ipFamily, cni string, extraPortMappings, mounts []string) string {
 	region := getRegion(clusterIndex - 1)
 	zone := getZone(clusterIndex - 1)
 
+	scheme := "http"
+	if registryCertDir != "" {
+		scheme = "https"
+		// every node trusts the self-signed registry certificate
+		mounts = append(slices.Clip(mounts), filepath.Join(registryCertDir, registryCertFile)+":"+registryNodeCAPath)
+	}
+
 	clusterConfig := fmt.Sprintf(`kind: Cluster
 apiVersion: kind.x-k8s.io/v1alpha4
 containerdConfigPatches:
   - |-
     [plugins."io.containerd.grpc.v1.cri".registry.mirrors."localhost:%d"]
+      endpoint = ["%s://%s:%d"]
+`, regPort, scheme, config.KindRegistryName, regPort)
+	if registryCertDir != "" {
+		clusterConfig += fmt.Sprintf(`    [plugins."io.containerd.grpc.v1.cri".registry.configs."%s:%d".tls]
+      ca_file = "%s"
+`, config.KindRegistryName, regPort, registryNodeCAPath)
+	}
+
+	// point each mirrored registry host at its local pull-through cache
+	for _, host := range sortedRegistryHosts(mirrors) {
+		clusterConfig += fmt.Sprintf(`    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."%s"]
       endpoint = ["http://%s:%d"]
-    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
-      endpoint = ["http://docker:%d"]
-    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."us-docker.pkg.dev"]
-      endpoint = ["http://us-docker:%d"]
-    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."us-central1-docker.pkg.dev"]
-      endpoint = ["http://us-central1-docker:%d"]
-    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."quay.io"]
-      endpoint = ["http://quay:%d"]
-    [plugins."io.containerd.grpc.v1.cri".registry.mirrors."gcr.io"]
-      endpoint = ["http://gcr:%d"]
-nodes:
+`, host, registryMirrorContainerName(host), regPort)
+	}
+	// containerd falls back to the upstream when a mirror fails, so it needs the same credentials
+	for _, host := range sortedRegistryHosts(mirrors) {
+		creds, ok := credentials[host]
+		if !ok {
+			continue
+		}
+		clusterConfig += fmt.Sprintf(`    [plugins."io.containerd.grpc.v1.cri".registry.configs."%s".auth]
+      username = %q
+      password = %q
+`, registryUpstreamHost(host, mirrors[host]), creds.Username, creds.Password)
+	}
+
+	clusterConfig += fmt.Sprintf(`nodes:
   - role: control-plane
     image: %s
     extraPortMappings:
       - containerPort: 6443
         hostPort: %s
-    labels:
+%s%s    labels:
       ingress-ready: "true"
       topology.kubernetes.io/region: %s
       topology.kubernetes.io/zone: %s
-`, regPort, config.KindRegistryName, regPort, regPort, regPort, regPort, regPort, regPort, kindestNode, cpPort, region, zone)
+      %s: "%s"
+`, kindestNode, cpPort, renderPortMappings(extraPortMappings), renderMounts(mounts), region, zone, k8s.ProjectNodeLabel, project)
+
+	// Add worker nodes, every node carries the project label from the moment it registers
+	for _, worker := range workers {
+		labels := maps.Clone(worker.Labels)
+		if labels == nil {
+			labels = make(map[string]string, 1)
+		}
+		labels[k8s.ProjectNodeLabel] = project
+		worker.Labels = labels
+		clusterConfig += renderWorkerNode(kindestNode, worker, mounts)
+	}
 
-	// Add worker nodes
-	for i := 1; i <= nodeCount; i++ {
-		clusterConfig += fmt.Sprintf(`  - role: worker
-    image: %s
-`, kindestNode)
+	// Add advanced network configuration, kindnet is kind's own CNI so it's the only one left enabled
+	clusterConfig += fmt.Sprintf(`networking:
+  disableDefaultCNI: %t
+  serviceSubnet: "%s"
+  podSubnet: "%s"
+`, cni != "kindnet", serviceSubnet, podSubnet)
+	// kind defaults to ipv4, so only set the family for ipv6 and dual-stack clusters
+	if ipFamily != "" && ipFamily != config.IPFamilyIPv4 {
+		clusterConfig += fmt.Sprintf("  ipFamily: %s\n", ipFamily)
 	}
 
-	// Add advanced network configuration
-	clusterConfig += `networking:
-  disableDefaultCNI: true
-  serviceSubnet: "10.255.100.0/24"
-  podSubnet: "10.100.0.0/16"
-`
+	return clusterConfig
+}
 
-	// Write clusterConfig to temporary file
-	tmpDir := os.TempDir()
-	configPath := filepath.Join(tmpDir, fmt.Sprintf("kind-%s.yaml", clusterName))
+// dryRunCreate prints the generated kind config for each cluster without provisioning anything
+func (m *Manager) dryRunCreate(opts *CreateOptions) error {
+	kindestNode, err := m.resolveNodeImage(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get kind node image: %w", err)
+	}
 
-	if err := os.WriteFile(configPath, []byte(clusterConfig), 0644); err != nil {
-		return "", fmt.Errorf("failed to write kind clusterConfig file: %w", err)
+	regPort, err := getAvailableRegistryPort()
+	if err != nil {
+		regPort = config.KindRegistryPort
+	}
+	mirrors, err := registryMirrors(opts)
+	if err != nil {
+		return err
+	}
+	credentials, err := registryCredentials(opts.RegistryAuth, mirrors)
+	if err != nil {
+		return err
+	}
+	// the config is printed, so never show the actual credentials
+	for host := range credentials {
+		credentials[host] = &docker.RegistryCredentials{Username: "***", Password: "***"}
+	}
+	// nothing is generated on a dry run, the certificate only shows up in the config
+	certDir, err := registryTLSCertDir(opts.RegistryTLS)
+	if err != nil {
+		return err
 	}
 
-	return configPath, nil
+	for i := 1; i <= opts.NumClusters; i++ {
+		clusterName := ClusterName(opts.ClusterPrefix, i, opts.NumClusters)
+
+		cpPort, err := getAvailablePortPrefix(i, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get available port prefix: %w", err)
+		}
+
+		fmt.Printf("# cluster %s (%d/%d)\n---\n%s\n", clusterName, i, opts.NumClusters, generateKindConfig(kindestNode, opts.Project, workerNodeConfigs(opts), i, cpPort, regPort, mirrors, credentials, certDir, opts.PodSubnet, opts.ServiceSubnet, opts.IPFamily, opts.CNI, opts.ExtraPortMappings, opts.Mounts))
+	}
+
+	logger.Infof("dry run complete, no clusters were created")
+	return nil
 }
 
 // setupKindRegistryMirrors sets up registry mirrors for kind clusters
-func (m *Manager) setupKindRegistryMirrors(regPort int, regName, networkName string) error {
+func (m *Manager) setupKindRegistryMirrors(regPort int, regName, networkName string, mirrors map[string]string, credentials map[string]*docker.RegistryCredentials, certDir string) error {
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+
 	status := logger.NewStatus()
 	status.Start("setting up kind registry mirrors")
 	defer func() {
@@ -801,13 +1876,14 @@ func (m *Manager) setupKindRegistryMirrors(regPort int, regName, networkName str
 
 	// Start the main registry
 	regPortStr := fmt.Sprintf("%d", regPort)
-	if err := m.createRegistryContainer(regName, networkName, regPortStr); err != nil {
+	if err := m.createRegistryContainer(containerRuntime, regName, networkName, regPortStr, certDir); err != nil {
 		status.End(false)
 		return fmt.Errorf("failed to start registry container: %w", err)
 	}
 
-	for cacheName, cacheURL := range config.KindRegistries {
-		if err := docker.CreateRegistryMirror(cacheName, cacheURL, networkName, regPortStr); err != nil {
+	for _, host := range sortedRegistryHosts(mirrors) {
+		cacheName := registryMirrorContainerName(host)
+		if err := docker.CreateRegistryMirror(containerRuntime, cacheName, mirrors[host], networkName, regPortStr, credentials[host]); err != nil {
 			status.End(false)
 			return fmt.Errorf("failed to start registry mirror %s: %w", cacheName, err)
 		}
@@ -817,11 +1893,11 @@ func (m *Manager) setupKindRegistryMirrors(regPort int, regName, networkName str
 	return nil
 }
 
-// createRegistryContainer starts the main registry container (only for Docker)
-func (m *Manager) createRegistryContainer(regName, networkName, regPort string) error {
+// createRegistryContainer starts the main registry container, served over https when given a cert directory
+func (m *Manager) createRegistryContainer(containerRuntime, regName, networkName, regPort, certDir string) error {
 	// Use the internal registry port (5000) for the container port mapping
 	internalPort := fmt.Sprintf("%d", config.KindRegistryPort)
-	return docker.CreateRegistryContainer(regName, networkName, regPort, internalPort)
+	return docker.CreateRegistryContainer(containerRuntime, regName, networkName, regPort, internalPort, certDir)
 }
 
 // getRegion returns a region name based on index
@@ -844,15 +1920,14 @@ func getZone(index int) string {
 
 // getKindClusterIP gets the IP address of a kind cluster
 func (m *Manager) getKindClusterIP(clusterName string) (string, error) {
-	// get the container runtime that was detected during prerequisite checking
-	containerRuntime, err := docker.GetContainerRuntime()
+	// get the container runtime that was resolved during prerequisite checking
+	containerRuntime, err := m.runtime()
 	if err != nil {
-		return "", fmt.Errorf("failed to get container runtime: %w", err)
+		return "", err
 	}
 
 	// use container runtime inspect to get the cluster IP
-	cmd := exec.Command(containerRuntime, "inspect", "-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", clusterName+"-control-plane")
-	output, err := cmd.Output()
+	output, err := utilexec.Output(containerRuntime, "inspect", "-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", clusterName+"-control-plane")
 	if err != nil {
 		return "", fmt.Errorf("failed to get kind cluster IP for %s: %w", clusterName, err)
 	}
@@ -866,6 +1941,24 @@ func (m *Manager) getKindClusterIP(clusterName string) (string, error) {
 	return ip, nil
 }
 
+// controlPlaneRoleLabels are the role label keys kubeadm puts on control plane nodes, master on older releases.
+// Their values are empty so only the presence of the key counts
+var controlPlaneRoleLabels = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+// kindControlPlaneNodeName matches the names kind gives control plane nodes, <cluster>-control-plane with a
+// number from the second one on
+var kindControlPlaneNodeName = regexp.MustCompile(`-control-plane\d*$`)
+
+// isControlPlaneNode reports whether the node has a control plane role label, or failing that a kind control plane name
+func isControlPlaneNode(node corev1.Node) bool {
+	for _, key := range controlPlaneRoleLabels {
+		if _, ok := node.Labels[key]; ok {
+			return true
+		}
+	}
+	return kindControlPlaneNodeName.MatchString(node.Name)
+}
+
 // removeExcludeLabelFromControlPlane removes the exclude-from-external-load-balancers label from control plane nodes
 func (m *Manager) removeExcludeLabelFromControlPlane(contextName string) error {
 	logger.Debugf("removing exclude-from-external-load-balancers label from control plane nodes in context %s", contextName)
@@ -876,59 +1969,23 @@ func (m *Manager) removeExcludeLabelFromControlPlane(contextName string) error {
 		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
 	}
 
-	// get all nodes
-	nodes, err := clientManager.GetClientset().CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to list nodes: %w", err)
-	}
-
-	// find control plane nodes and remove the label
-	// we want to be able to provision load balancer since we run workloads on it
-	for _, node := range nodes.Items {
-		// check if this is a control plane node
-		isControlPlane := false
-		for _, role := range node.Labels {
-			if role == "control-plane" {
-				isControlPlane = true
-				break
-			}
-		}
-
-		// also check for the node-role.kubernetes.io/control-plane label
-		if node.Labels["node-role.kubernetes.io/control-plane"] == "" && node.Labels["node-role.kubernetes.io/master"] == "" {
-			// check if it's a kind control plane node by name pattern
-			if strings.Contains(node.Name, "-control-plane") {
-				isControlPlane = true
-			}
-		} else {
-			isControlPlane = true
-		}
-
-		if isControlPlane {
-			// check if the exclude label exists
-			if _, exists := node.Labels["node.kubernetes.io/exclude-from-external-load-balancers"]; exists {
-				logger.Debugf("removing exclude-from-external-load-balancers label from control plane node: %s", node.Name)
-
-				// remove the label
-				delete(node.Labels, "node.kubernetes.io/exclude-from-external-load-balancers")
-
-				// update the node
-				_, err := clientManager.GetClientset().CoreV1().Nodes().Update(context.Background(), &node, metav1.UpdateOptions{})
-				if err != nil {
-					return fmt.Errorf("failed to update node %s: %w", node.Name, err)
-				}
-
-				logger.Debugf("successfully removed exclude-from-external-load-balancers label from node: %s", node.Name)
-			} else {
-				logger.Debugf("control plane node %s does not have exclude-from-external-load-balancers label", node.Name)
-			}
-		}
+	if err := removeExcludeLabel(clientManager.GetClientset()); err != nil {
+		return err
 	}
 
 	logger.Debugf("completed exclude-from-external-load-balancers label removal for context: %s", contextName)
 	return nil
 }
 
+// excludeFromLoadBalancersLabel keeps load balancers from sending traffic to the nodes that carry it
+const excludeFromLoadBalancersLabel = "node.kubernetes.io/exclude-from-external-load-balancers"
+
+// removeExcludeLabel removes the exclude-from-external-load-balancers label from the control plane nodes, so load
+// balancers can be provisioned for the workloads we run on them
+func removeExcludeLabel(client kubernetes.Interface) error {
+	return k8s.RemoveNodeLabel(client, excludeFromLoadBalancersLabel, isControlPlaneNode)
+}
+
 // validateLoadBalancerOptions validates that MetalLB and cloud-provider-kind are not both enabled
 // and sets default to cloud-provider-kind for kind clusters
 func (m *Manager) validateLoadBalancerOptions(opts *CreateOptions) error {
@@ -953,6 +2010,11 @@ func (m *Manager) validateLoadBalancerOptions(opts *CreateOptions) error {
 		return fmt.Errorf("MetalLB and cloud-provider-kind cannot be installed together - they conflict with each other")
 	}
 
+	// MetalLB address pools are carved out of the IPv4 node network, which an ipv6 cluster doesn't use
+	if opts.InstallMetalLB && opts.IPFamily == config.IPFamilyIPv6 {
+		return fmt.Errorf("MetalLB is not supported on ipv6 clusters, use --skip-metallb-install with --install-cloud-provider instead")
+	}
+
 	if opts.InstallCloudProvider {
 		logger.Infof("cloud-provider-kind will be installed for load balancer functionality")
 	} else if opts.InstallMetalLB {
@@ -963,16 +2025,10 @@ func (m *Manager) validateLoadBalancerOptions(opts *CreateOptions) error {
 }
 
 // deleteKindRegistry deletes the kind-registry container and its associated mirror containers
-func (m *Manager) deleteKindRegistry() error {
-	// List of registry containers to delete
-	registryContainers := []string{
-		config.KindRegistryName,
-		"docker",
-		"us-docker",
-		"us-central1-docker",
-		"quay",
-		"gcr",
-	}
-
-	return docker.DeleteRegistryContainers(registryContainers)
+func (m *Manager) deleteKindRegistry(mirrors map[string]string) error {
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+	return docker.DeleteRegistryContainers(containerRuntime, registryContainerNames(mirrors))
 }
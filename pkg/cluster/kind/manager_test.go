@@ -0,0 +1,244 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("generateKindConfig", func() {
+	render := func(cni string) string {
+		return generateKindConfig("kindest/node:v1.31.2", "myproject", nil, 1, "7001", 5000, nil, nil, "",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, cni, nil, nil)
+	}
+
+	It("should keep kind's default CNI for kindnet", func() {
+		Expect(render("kindnet")).To(ContainSubstring("disableDefaultCNI: false"))
+	})
+
+	It("should disable kind's default CNI for every other CNI", func() {
+		for _, cni := range []string{"cilium", "calico", "flannel", "none"} {
+			Expect(render(cni)).To(ContainSubstring("disableDefaultCNI: true"), cni)
+		}
+	})
+
+	It("should mount the host directories into every node", func() {
+		workers := []config.WorkerNodeConfig{{}, {}}
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", workers, 1, "7001", 5000, nil, nil, "",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, "cilium", nil, []string{"/home/dev/src:/src"})
+
+		Expect(strings.Count(rendered, "    extraMounts:\n      - hostPath: \"/home/dev/src\"\n        containerPath: \"/src\"\n")).To(Equal(3))
+	})
+
+	It("should label every node with the project", func() {
+		workers := []config.WorkerNodeConfig{{}, {Labels: map[string]string{"tier": "apps"}}}
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", workers, 1, "7001", 5000, nil, nil, "",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, "cilium", nil, nil)
+
+		Expect(strings.Count(rendered, "      lok8s.dev/project: \"myproject\"\n")).To(Equal(3))
+		Expect(rendered).To(ContainSubstring("      tier: \"apps\"\n"))
+		Expect(workers[1].Labels).NotTo(HaveKey("lok8s.dev/project"))
+	})
+
+	It("should only render the IP family of ipv6 and dual-stack clusters", func() {
+		Expect(render("cilium")).NotTo(ContainSubstring("ipFamily:"))
+
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", nil, 1, "7001", 5000, nil, nil, "",
+			config.KindPodSubnet+","+config.KindPodSubnetIPv6, config.KindServiceSubnet+","+config.KindServiceSubnetIPv6, config.IPFamilyDual, "cilium", nil, nil)
+		Expect(rendered).To(ContainSubstring("  ipFamily: dual\n"))
+	})
+})
+
+var _ = Describe("IP families", func() {
+	DescribeTable("subnetsForIPFamily",
+		func(ipFamily, subnet, expected string) {
+			Expect(subnetsForIPFamily(ipFamily, subnet, config.KindPodSubnet, config.KindPodSubnetIPv6)).To(Equal(expected))
+		},
+		Entry("keeps ipv4 ranges", config.IPFamilyIPv4, "10.200.0.0/16", "10.200.0.0/16"),
+		Entry("swaps the ipv4 default on ipv6", config.IPFamilyIPv6, config.KindPodSubnet, config.KindPodSubnetIPv6),
+		Entry("keeps a custom ipv6 range", config.IPFamilyIPv6, "fd00:20::/56", "fd00:20::/56"),
+		Entry("pairs an ipv4 range with the ipv6 default on dual", config.IPFamilyDual, "10.200.0.0/16", "10.200.0.0/16,"+config.KindPodSubnetIPv6),
+		Entry("pairs an ipv6 range with the ipv4 default on dual", config.IPFamilyDual, "fd00:20::/56", config.KindPodSubnet+",fd00:20::/56"),
+		Entry("keeps both ranges on dual", config.IPFamilyDual, "10.200.0.0/16,fd00:20::/56", "10.200.0.0/16,fd00:20::/56"),
+	)
+
+	DescribeTable("parseSubnetsForIPFamily",
+		func(subnets, ipFamily, expectedErr string) {
+			nets, err := parseSubnetsForIPFamily("pod", subnets, ipFamily)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(nets).To(HaveLen(len(strings.Split(subnets, ","))))
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("single ipv4 range", "10.100.0.0/16", config.IPFamilyIPv4, ""),
+		Entry("single ipv6 range", "fd00:10:100::/56", config.IPFamilyIPv6, ""),
+		Entry("one range of each family", "10.100.0.0/16, fd00:10:100::/56", config.IPFamilyDual, ""),
+		Entry("ipv6 range on ipv4", "fd00:10:100::/56", config.IPFamilyIPv4, "must be a single IPv4 range"),
+		Entry("ipv4 range on ipv6", "10.100.0.0/16", config.IPFamilyIPv6, "must be a single IPv6 range"),
+		Entry("single range on dual", "10.100.0.0/16", config.IPFamilyDual, "must be one IPv4 and one IPv6 range"),
+		Entry("invalid CIDR", "10.100.0.0", config.IPFamilyIPv4, "invalid pod CIDR 10.100.0.0"),
+		Entry("unknown family", "10.100.0.0/16", "ipv5", "unsupported IP family ipv5"),
+	)
+
+	DescribeTable("validateClusterSubnets",
+		func(ipFamily, podSubnet, serviceSubnet, networkSubnet, expectedErr string) {
+			err := validateClusterSubnets(ipFamily, podSubnet, serviceSubnet, networkSubnet)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("defaults", config.IPFamilyIPv4, config.KindPodSubnet, config.KindServiceSubnet, config.DefaultNetworkSubnetCIDR, ""),
+		Entry("dual-stack defaults", config.IPFamilyDual, config.KindPodSubnet+","+config.KindPodSubnetIPv6, config.KindServiceSubnet+","+config.KindServiceSubnetIPv6, config.DefaultNetworkSubnetCIDR, ""),
+		Entry("no network subnet", config.IPFamilyIPv4, config.KindPodSubnet, config.KindServiceSubnet, "", ""),
+		Entry("pod overlapping service", config.IPFamilyIPv4, "10.100.0.0/16", "10.100.5.0/24", "", "pod CIDR 10.100.0.0/16 overlaps service CIDR 10.100.5.0/24"),
+		Entry("pod overlapping network", config.IPFamilyIPv4, "10.89.0.0/20", config.KindServiceSubnet, config.DefaultNetworkSubnetCIDR, "pod CIDR 10.89.0.0/20 overlaps network subnet"),
+		Entry("service overlapping network", config.IPFamilyIPv4, config.KindPodSubnet, "10.89.1.0/24", config.DefaultNetworkSubnetCIDR, "service CIDR 10.89.1.0/24 overlaps network subnet"),
+		Entry("wrong family", config.IPFamilyIPv6, config.KindPodSubnet, config.KindServiceSubnet, "", "must be a single IPv6 range"),
+	)
+
+	It("should generate IPv4 and IPv6 gateway IPs", func() {
+		Expect(generateGatewayIPFromSubnet("10.90.0.0/16")).To(Equal("10.90.0.1"))
+		Expect(generateGatewayIPFromSubnet(config.KindNetworkSubnetIPv6)).To(Equal("fc00:f853:ccd:e793::1"))
+
+		_, err := generateGatewayIPFromSubnet("fd00::1/128")
+		Expect(err).To(MatchError(ContainSubstring("too small")))
+	})
+
+	It("should require an IPv6 subnet on an existing network for ipv6 and dual-stack clusters", func() {
+		Expect(checkNetworkIPFamily(config.IPFamilyIPv4, "")).To(Succeed())
+		Expect(checkNetworkIPFamily(config.IPFamilyDual, config.KindNetworkSubnetIPv6)).To(Succeed())
+		for _, ipFamily := range []string{config.IPFamilyIPv6, config.IPFamilyDual} {
+			Expect(checkNetworkIPFamily(ipFamily, "")).To(MatchError(ContainSubstring("exists without IPv6, which --ip-family " + ipFamily + " needs")))
+		}
+	})
+})
+
+var _ = Describe("Fake GPUs", func() {
+	It("should give the per worker GPUs precedence over the shared count", func() {
+		workers := workerNodeConfigs(&CreateOptions{
+			NodeCount:   3,
+			NodeGPUs:    1,
+			WorkerNodes: map[int]config.WorkerNodeConfig{2: {GPUs: 4}},
+		})
+
+		Expect(workers).To(HaveLen(3))
+		Expect(workers[0].GPUs).To(Equal(1))
+		Expect(workers[1].GPUs).To(Equal(4))
+		Expect(workers[2].GPUs).To(Equal(1))
+	})
+
+	It("should name the workers the way kind does", func() {
+		Expect(kindWorkerNodeName("kind1", 1)).To(Equal("kind1-worker"))
+		Expect(kindWorkerNodeName("kind1", 3)).To(Equal("kind1-worker3"))
+	})
+})
+
+var _ = Describe("retryKubeconfigEdit", func() {
+	BeforeEach(func() {
+		interval := kubeconfigRetryInterval
+		kubeconfigRetryInterval = 0
+		DeferCleanup(func() { kubeconfigRetryInterval = interval })
+	})
+
+	It("should retry until the edit succeeds", func() {
+		attempts := 0
+		err := retryKubeconfigEdit("rename context kind-kind1", func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("kubeconfig is locked")
+			}
+			return nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should give up after the last attempt with the last error", func() {
+		attempts := 0
+		err := retryKubeconfigEdit("rename context kind-kind1", func() error {
+			attempts++
+			return errors.New("context kind-kind1 not found")
+		})
+
+		Expect(attempts).To(Equal(kubeconfigRetries))
+		Expect(err).To(MatchError(ContainSubstring("failed to rename context kind-kind1 after 5 attempts: context kind-kind1 not found")))
+	})
+})
+
+// node returns a node fixture with the name and labels
+func node(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+var _ = Describe("isControlPlaneNode", func() {
+	DescribeTable("should detect control plane nodes by role label key or kind name",
+		func(n *corev1.Node, expected bool) {
+			Expect(isControlPlaneNode(*n)).To(Equal(expected))
+		},
+		Entry("control plane role label with an empty value", node("node-a", map[string]string{"node-role.kubernetes.io/control-plane": ""}), true),
+		Entry("legacy master role label", node("node-a", map[string]string{"node-role.kubernetes.io/master": ""}), true),
+		Entry("kind control plane name without labels", node("kind1-control-plane", nil), true),
+		Entry("second kind control plane", node("kind1-control-plane2", nil), true),
+		Entry("kind worker", node("kind1-worker", map[string]string{"kubernetes.io/hostname": "kind1-worker"}), false),
+		Entry("worker with a control-plane label value", node("kind1-worker2", map[string]string{"tier": "control-plane"}), false),
+		Entry("worker whose name only contains control-plane", node("kind1-control-plane-proxy-worker", nil), false),
+	)
+})
+
+var _ = Describe("removeExcludeLabel", func() {
+	It("should remove the label from every control plane node and leave workers alone", func() {
+		client := fake.NewSimpleClientset(
+			node("kind1-control-plane", map[string]string{excludeFromLoadBalancersLabel: "", "node-role.kubernetes.io/control-plane": ""}),
+			node("kind1-control-plane2", map[string]string{excludeFromLoadBalancersLabel: "", "node-role.kubernetes.io/control-plane": ""}),
+			node("kind1-worker", map[string]string{excludeFromLoadBalancersLabel: ""}),
+		)
+
+		Expect(removeExcludeLabel(client)).To(Succeed())
+
+		for _, name := range []string{"kind1-control-plane", "kind1-control-plane2"} {
+			n, err := client.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n.Labels).NotTo(HaveKey(excludeFromLoadBalancersLabel), name)
+			Expect(n.Labels).To(HaveKey("node-role.kubernetes.io/control-plane"), name)
+		}
+
+		worker, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-worker", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(worker.Labels).To(HaveKey(excludeFromLoadBalancersLabel))
+	})
+})
+
+var _ = Describe("NewManagerWithContainerEngine", func() {
+	BeforeEach(func() {
+		// docker and podman both answer 'version' and nothing else is on the PATH
+		dir := GinkgoT().TempDir()
+		for _, name := range []string{"docker", "podman"} {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 0\n"), 0o755)).To(Succeed())
+		}
+		GinkgoT().Setenv("PATH", dir)
+	})
+
+	It("should run on the first available engine of the preference", func() {
+		m := NewManagerWithContainerEngine("", []string{"podman", "docker"})
+		Expect(m.runtime()).To(Equal("podman"))
+	})
+
+	It("should run on the saved engine over the preference", func() {
+		m := NewManagerWithContainerEngine("docker", []string{"podman", "docker"})
+		Expect(m.runtime()).To(Equal("docker"))
+	})
+})
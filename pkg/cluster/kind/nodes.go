@@ -0,0 +1,355 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kind
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// validTaintEffects are the effects kubernetes accepts on a node taint
+var validTaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// nodeTaint is a parsed key=value:Effect taint
+type nodeTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// parseNodeTaint parses and validates a taint in the key=value:Effect form
+func parseNodeTaint(taint string) (nodeTaint, error) {
+	sep := strings.LastIndex(taint, ":")
+	if sep < 0 {
+		return nodeTaint{}, fmt.Errorf("invalid taint %q, expected key=value:Effect", taint)
+	}
+	keyValue, effect := taint[:sep], taint[sep+1:]
+
+	key, value, found := strings.Cut(keyValue, "=")
+	if !found {
+		return nodeTaint{}, fmt.Errorf("invalid taint %q, expected key=value:Effect", taint)
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return nodeTaint{}, fmt.Errorf("invalid taint key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return nodeTaint{}, fmt.Errorf("invalid taint value %q: %s", value, strings.Join(errs, "; "))
+	}
+
+	for _, validEffect := range validTaintEffects {
+		if effect == validEffect {
+			return nodeTaint{Key: key, Value: value, Effect: effect}, nil
+		}
+	}
+	return nodeTaint{}, fmt.Errorf("invalid taint effect %q in %q, valid effects are: %s", effect, taint, strings.Join(validTaintEffects, ", "))
+}
+
+// validateNodeLabels checks the label keys and values are valid kubernetes labels
+func validateNodeLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid node label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid node label value %q for %s: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateWorkerNodes validates the worker labels and taints, and that every per worker override targets an existing worker
+func validateWorkerNodes(opts *CreateOptions) error {
+	if err := validateNodeLabels(opts.NodeLabels); err != nil {
+		return err
+	}
+	for _, taint := range opts.NodeTaints {
+		if _, err := parseNodeTaint(taint); err != nil {
+			return err
+		}
+	}
+
+	for index, worker := range opts.WorkerNodes {
+		if index < 1 || index > opts.NodeCount {
+			return fmt.Errorf("worker node %d does not exist, clusters have %d worker node(s)", index, opts.NodeCount)
+		}
+		if err := validateNodeLabels(worker.Labels); err != nil {
+			return fmt.Errorf("worker node %d: %w", index, err)
+		}
+		for _, taint := range worker.Taints {
+			if _, err := parseNodeTaint(taint); err != nil {
+				return fmt.Errorf("worker node %d: %w", index, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// workerNodeConfigs returns the labels, taints and fake GPUs for each worker node, with the per worker
+// overrides layered on top of the settings shared by all workers
+func workerNodeConfigs(opts *CreateOptions) []config.WorkerNodeConfig {
+	workers := make([]config.WorkerNodeConfig, opts.NodeCount)
+	for i := range workers {
+		labels := make(map[string]string, len(opts.NodeLabels))
+		for key, value := range opts.NodeLabels {
+			labels[key] = value
+		}
+		taints := append([]string{}, opts.NodeTaints...)
+		gpus := opts.NodeGPUs
+
+		if override, exists := opts.WorkerNodes[i+1]; exists {
+			for key, value := range override.Labels {
+				labels[key] = value
+			}
+			for _, taint := range override.Taints {
+				if !containsString(taints, taint) {
+					taints = append(taints, taint)
+				}
+			}
+			if override.GPUs > 0 {
+				gpus = override.GPUs
+			}
+		}
+
+		workers[i] = config.WorkerNodeConfig{Labels: labels, Taints: taints, GPUs: gpus}
+	}
+	return workers
+}
+
+// renderWorkerNode renders a kind worker node entry, taints are applied through the kubeadm join configuration
+func renderWorkerNode(kindestNode string, worker config.WorkerNodeConfig, mounts []string) string {
+	node := fmt.Sprintf(`  - role: worker
+    image: %s
+`, kindestNode) + renderMounts(mounts)
+
+	if len(worker.Labels) > 0 {
+		keys := make([]string, 0, len(worker.Labels))
+		for key := range worker.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		node += "    labels:\n"
+		for _, key := range keys {
+			node += fmt.Sprintf("      %s: \"%s\"\n", key, worker.Labels[key])
+		}
+	}
+
+	if len(worker.Taints) > 0 {
+		node += `    kubeadmConfigPatches:
+      - |
+        kind: JoinConfiguration
+        nodeRegistration:
+          taints:
+`
+		for _, taint := range worker.Taints {
+			// taints are validated before the config is generated
+			parsed, _ := parseNodeTaint(taint)
+			node += fmt.Sprintf(`            - key: "%s"
+              value: "%s"
+              effect: "%s"
+`, parsed.Key, parsed.Value, parsed.Effect)
+		}
+	}
+
+	return node
+}
+
+// kindWorkerNodeName returns the node name kind gives a worker, by index starting at 1
+func kindWorkerNodeName(clusterName string, index int) string {
+	if index == 1 {
+		return clusterName + "-worker"
+	}
+	return fmt.Sprintf("%s-worker%d", clusterName, index)
+}
+
+// advertiseFakeGPUs patches the capacity of the workers given GPUs with that many fake nvidia.com/gpu, so pods
+// requesting GPUs schedule onto them. Nothing backs the resource, the pods only get the scheduling
+func advertiseFakeGPUs(contextName, clusterName string, workers []config.WorkerNodeConfig) error {
+	var clientManager *k8s.ClientManager
+	for i, worker := range workers {
+		if worker.GPUs <= 0 {
+			continue
+		}
+
+		if clientManager == nil {
+			var err error
+			clientManager, err = k8s.NewClientManagerForContext(contextName)
+			if err != nil {
+				return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+			}
+		}
+
+		nodeName := kindWorkerNodeName(clusterName, i+1)
+		if err := clientManager.SetNodeExtendedResource(nodeName, k8s.GPUResource, int64(worker.GPUs)); err != nil {
+			return err
+		}
+		logger.Infof("🎮 advertised %d fake %s on node %s", worker.GPUs, k8s.GPUResource, nodeName)
+	}
+	return nil
+}
+
+// containsString reports whether the slice contains the value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// portMapping is a parsed hostPort:containerPort[/protocol] mapping
+type portMapping struct {
+	HostPort      int
+	ContainerPort int
+	Protocol      string
+}
+
+// parsePortMapping parses a port mapping in the hostPort:containerPort[/protocol] form, the protocol defaults to TCP
+func parsePortMapping(mapping string) (portMapping, error) {
+	ports, protocol, hasProtocol := strings.Cut(mapping, "/")
+	if !hasProtocol {
+		protocol = "TCP"
+	}
+	protocol = strings.ToUpper(protocol)
+	if protocol != "TCP" && protocol != "UDP" && protocol != "SCTP" {
+		return portMapping{}, fmt.Errorf("invalid protocol %q in port mapping %q, valid protocols are: TCP, UDP, SCTP", protocol, mapping)
+	}
+
+	hostPort, containerPort, found := strings.Cut(ports, ":")
+	if !found {
+		return portMapping{}, fmt.Errorf("invalid port mapping %q, expected hostPort:containerPort[/protocol]", mapping)
+	}
+
+	parsed := portMapping{Protocol: protocol}
+	var err error
+	if parsed.HostPort, err = parsePort(hostPort); err != nil {
+		return portMapping{}, fmt.Errorf("invalid host port in port mapping %q: %w", mapping, err)
+	}
+	if parsed.ContainerPort, err = parsePort(containerPort); err != nil {
+		return portMapping{}, fmt.Errorf("invalid container port in port mapping %q: %w", mapping, err)
+	}
+
+	return parsed, nil
+}
+
+// parsePort parses a port number between 1 and 65535
+func parsePort(port string) (int, error) {
+	value, err := strconv.Atoi(port)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", port)
+	}
+	if value < 1 || value > 65535 {
+		return 0, fmt.Errorf("%d is outside the 1-65535 range", value)
+	}
+	return value, nil
+}
+
+// validatePortMappings checks the extra port mappings parse and don't reuse a host port
+func validatePortMappings(mappings []string, numClusters int) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+	// every cluster's control-plane would try to bind the same host ports
+	if numClusters > 1 {
+		return fmt.Errorf("extra port mappings can only be used with a single cluster")
+	}
+
+	seen := make(map[string]string)
+	for _, mapping := range mappings {
+		parsed, err := parsePortMapping(mapping)
+		if err != nil {
+			return err
+		}
+		if parsed.ContainerPort == 6443 {
+			return fmt.Errorf("port mapping %q conflicts with the API server port 6443", mapping)
+		}
+		key := fmt.Sprintf("%d/%s", parsed.HostPort, parsed.Protocol)
+		if previous, exists := seen[key]; exists {
+			return fmt.Errorf("port mappings %q and %q use the same host port", previous, mapping)
+		}
+		seen[key] = mapping
+	}
+
+	return nil
+}
+
+// checkHostPortsAvailable ensures nothing on the host is bound to the TCP host ports of the extra port mappings
+func checkHostPortsAvailable(mappings []string) error {
+	for _, mapping := range mappings {
+		parsed, err := parsePortMapping(mapping)
+		if err != nil {
+			return err
+		}
+		// only TCP ports can be probed by binding a listener, and privileged ports need root to bind
+		if parsed.Protocol != "TCP" || (parsed.HostPort < 1024 && os.Geteuid() != 0) {
+			logger.Debugf("skipping host port check for port mapping %s", mapping)
+			continue
+		}
+		if !isPortAvailable(parsed.HostPort) {
+			return fmt.Errorf("host port %d for port mapping %q is already in use", parsed.HostPort, mapping)
+		}
+	}
+	return nil
+}
+
+// renderPortMappings renders the extra port mappings as control-plane extraPortMappings entries
+func renderPortMappings(mappings []string) string {
+	var rendered string
+	for _, mapping := range mappings {
+		// port mappings are validated before the config is generated
+		parsed, _ := parsePortMapping(mapping)
+		rendered += fmt.Sprintf(`      - containerPort: %d
+        hostPort: %d
+        protocol: %s
+`, parsed.ContainerPort, parsed.HostPort, parsed.Protocol)
+	}
+	return rendered
+}
+
+// renderMounts renders the host:container mounts as node extraMounts entries
+func renderMounts(mounts []string) string {
+	if len(mounts) == 0 {
+		return ""
+	}
+
+	rendered := "    extraMounts:\n"
+	for _, mount := range mounts {
+		// mounts are validated before the config is generated
+		hostPath, containerPath, _ := config.ParseMount(mount)
+		rendered += fmt.Sprintf(`      - hostPath: "%s"
+        containerPath: "%s"
+`, hostPath, containerPath)
+	}
+	return rendered
+}
@@ -0,0 +1,99 @@
+package kind
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/day0ops/lok8s/pkg/config"
+)
+
+var _ = Describe("Worker nodes", func() {
+	DescribeTable("parseNodeTaint",
+		func(taint string, expected nodeTaint, expectedErr string) {
+			parsed, err := parseNodeTaint(taint)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+		},
+		Entry("valid taint", "dedicated=gpu:NoSchedule", nodeTaint{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"}, ""),
+		Entry("prefixed key and empty value", "example.com/spot=:PreferNoSchedule", nodeTaint{Key: "example.com/spot", Effect: "PreferNoSchedule"}, ""),
+		Entry("missing effect", "dedicated=gpu", nodeTaint{}, `invalid taint "dedicated=gpu", expected key=value:Effect`),
+		Entry("missing value", "dedicated:NoSchedule", nodeTaint{}, `invalid taint "dedicated:NoSchedule", expected key=value:Effect`),
+		Entry("invalid effect", "dedicated=gpu:NoRun", nodeTaint{}, `invalid taint effect "NoRun"`),
+		Entry("invalid key", "-dedicated=gpu:NoSchedule", nodeTaint{}, `invalid taint key "-dedicated"`),
+		Entry("invalid value", "dedicated=a b:NoSchedule", nodeTaint{}, `invalid taint value "a b"`),
+	)
+
+	DescribeTable("validateWorkerNodes",
+		func(opts *CreateOptions, expectedErr string) {
+			err := validateWorkerNodes(opts)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("labels, taints and per worker overrides", &CreateOptions{
+			NodeCount:   2,
+			NodeLabels:  map[string]string{"tier": "apps"},
+			NodeTaints:  []string{"dedicated=apps:NoSchedule"},
+			WorkerNodes: map[int]config.WorkerNodeConfig{2: {Labels: map[string]string{"gpu": "true"}, Taints: []string{"gpu=true:NoExecute"}}},
+		}, ""),
+		Entry("invalid shared taint", &CreateOptions{NodeCount: 1, NodeTaints: []string{"dedicated=apps"}}, "expected key=value:Effect"),
+		Entry("invalid shared label", &CreateOptions{NodeCount: 1, NodeLabels: map[string]string{"tier": "a b"}}, `invalid node label value "a b"`),
+		Entry("worker index above the node count", &CreateOptions{
+			NodeCount:   2,
+			WorkerNodes: map[int]config.WorkerNodeConfig{3: {Labels: map[string]string{"gpu": "true"}}},
+		}, "worker node 3 does not exist, clusters have 2 worker node(s)"),
+		Entry("worker index zero", &CreateOptions{
+			NodeCount:   2,
+			WorkerNodes: map[int]config.WorkerNodeConfig{0: {Labels: map[string]string{"gpu": "true"}}},
+		}, "worker node 0 does not exist"),
+		Entry("invalid worker taint", &CreateOptions{
+			NodeCount:   2,
+			WorkerNodes: map[int]config.WorkerNodeConfig{1: {Taints: []string{"gpu=true:Never"}}},
+		}, `worker node 1: invalid taint effect "Never"`),
+	)
+})
+
+var _ = Describe("Extra port mappings", func() {
+	DescribeTable("parsePortMapping",
+		func(mapping string, expected portMapping, expectedErr string) {
+			parsed, err := parsePortMapping(mapping)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+		},
+		Entry("defaults to tcp", "8080:80", portMapping{HostPort: 8080, ContainerPort: 80, Protocol: "TCP"}, ""),
+		Entry("explicit tcp", "8443:443/tcp", portMapping{HostPort: 8443, ContainerPort: 443, Protocol: "TCP"}, ""),
+		Entry("udp", "5353:53/udp", portMapping{HostPort: 5353, ContainerPort: 53, Protocol: "UDP"}, ""),
+		Entry("bad protocol", "8080:80/http", portMapping{}, `invalid protocol "HTTP" in port mapping "8080:80/http"`),
+		Entry("missing container port", "8080", portMapping{}, `invalid port mapping "8080", expected hostPort:containerPort[/protocol]`),
+		Entry("host port out of range", "70000:80", portMapping{}, "invalid host port in port mapping \"70000:80\": 70000 is outside the 1-65535 range"),
+		Entry("container port out of range", "8080:0", portMapping{}, "invalid container port in port mapping \"8080:0\": 0 is outside the 1-65535 range"),
+		Entry("port not a number", "http:80", portMapping{}, `"http" is not a number`),
+	)
+
+	DescribeTable("validatePortMappings",
+		func(mappings []string, numClusters int, expectedErr string) {
+			err := validatePortMappings(mappings, numClusters)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("no mappings on several clusters", nil, 3, ""),
+		Entry("same host port on tcp and udp", []string{"5353:53/tcp", "5353:53/udp"}, 1, ""),
+		Entry("duplicate host ports", []string{"8080:80", "8080:8080/tcp"}, 1, `port mappings "8080:80" and "8080:8080/tcp" use the same host port`),
+		Entry("several clusters", []string{"8080:80"}, 2, "extra port mappings can only be used with a single cluster"),
+		Entry("api server port", []string{"7443:6443"}, 1, "conflicts with the API server port 6443"),
+		Entry("invalid mapping", []string{"8080:80/http"}, 1, "invalid protocol"),
+	)
+})
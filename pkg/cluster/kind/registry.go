@@ -0,0 +1,241 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kind
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/docker"
+)
+
+// StartRegistry creates the shared kind registry and its mirrors, starting any that were stopped
+func (m *Manager) StartRegistry(regPort int, customMirrors, auth map[string]string, tls bool) error {
+	logger.Infof("-----> 📢 starting %s and registry mirrors <-----", config.KindRegistryName)
+
+	if regPort <= 0 {
+		port, err := getAvailableRegistryPort()
+		if err != nil {
+			return fmt.Errorf("failed to find available registry port: %w", err)
+		}
+		regPort = port
+	}
+
+	mirrors := mergeRegistryMirrors(customMirrors)
+	credentials, err := registryCredentials(auth, mirrors)
+	if err != nil {
+		return err
+	}
+	certDir, err := prepareRegistryCert(tls)
+	if err != nil {
+		return err
+	}
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+
+	// existing containers are skipped on creation, so start any that are stopped first
+	var stopped []string
+	for _, name := range registryContainerNames(mirrors) {
+		state, err := docker.InspectContainer(containerRuntime, name)
+		if err != nil {
+			logger.Debugf("failed to inspect container %s: %v", name, err)
+			continue
+		}
+		if state.Exists && !state.Running {
+			stopped = append(stopped, name)
+		}
+	}
+	if err := docker.StartContainers(containerRuntime, stopped); err != nil {
+		return err
+	}
+
+	if err := m.setupKindRegistryMirrors(regPort, config.KindRegistryName, config.KindNetworkName, mirrors, credentials, certDir); err != nil {
+		return err
+	}
+
+	logger.Infof("✓ %s is available on localhost:%d", config.KindRegistryName, regPort)
+	return nil
+}
+
+// StopRegistry removes the shared kind registry and its mirrors
+func (m *Manager) StopRegistry(customMirrors map[string]string) error {
+	logger.Infof("-----> 🚨 stopping %s and registry mirrors <-----", config.KindRegistryName)
+
+	if err := m.deleteKindRegistry(customMirrors); err != nil {
+		return fmt.Errorf("failed to delete registry containers: %w", err)
+	}
+
+	logger.Infof("✓ stopped %s and registry mirrors", config.KindRegistryName)
+	return nil
+}
+
+// RegistryStatus prints the state of the shared kind registry and each of its mirrors
+func (m *Manager) RegistryStatus(customMirrors map[string]string) error {
+	containerRuntime, err := m.runtime()
+	if err != nil {
+		return err
+	}
+	mirrors := mergeRegistryMirrors(customMirrors)
+
+	// map container names back to the upstream they proxy
+	remotes := make(map[string]string)
+	for host, upstream := range mirrors {
+		remotes[registryMirrorContainerName(host)] = upstream
+	}
+
+	w := tabwriter.NewWriter(logger.Output(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CONTAINER\tSTATUS\tREMOTE")
+	fmt.Fprintln(w, "---------\t------\t------")
+
+	for _, name := range registryContainerNames(mirrors) {
+		remote, ok := remotes[name]
+		if !ok {
+			remote = "local"
+		}
+
+		status := "Unknown"
+		state, err := docker.InspectContainer(containerRuntime, name)
+		if err != nil {
+			logger.Debugf("failed to inspect container %s: %v", name, err)
+		} else if !state.Exists {
+			status = "Not Found"
+		} else if state.Running {
+			status = "Running"
+		} else {
+			status = fmt.Sprintf("Stopped (%s)", state.Status)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, status, remote)
+	}
+
+	w.Flush()
+	return nil
+}
+
+// mergeRegistryMirrors returns the default registry mirrors (host -> upstream URL) overlaid with custom ones
+func mergeRegistryMirrors(customMirrors map[string]string) map[string]string {
+	mirrors := make(map[string]string)
+	for host, name := range config.KindRegistryHosts {
+		mirrors[host] = config.KindRegistries[name]
+	}
+	for host, upstream := range customMirrors {
+		mirrors[host] = upstream
+	}
+	return mirrors
+}
+
+// registryMirrors returns the registry mirrors started for new clusters, the defaults and custom mirrors
+// narrowed down to the requested hosts
+func registryMirrors(opts *CreateOptions) (map[string]string, error) {
+	return selectRegistryMirrors(mergeRegistryMirrors(opts.RegistryMirrors), opts.RegistryMirrorHosts, opts.NoRegistryMirrors)
+}
+
+// selectRegistryMirrors narrows the mirrors down to the allowed registry hosts, none when disabled and all of
+// them when no hosts are given
+func selectRegistryMirrors(mirrors map[string]string, allowedHosts []string, disabled bool) (map[string]string, error) {
+	if disabled {
+		return map[string]string{}, nil
+	}
+	if len(allowedHosts) == 0 {
+		return mirrors, nil
+	}
+
+	selected := make(map[string]string, len(allowedHosts))
+	for _, host := range allowedHosts {
+		upstream, ok := mirrors[host]
+		if !ok {
+			return nil, fmt.Errorf("unknown registry mirror %s. Valid options are: %s", host, strings.Join(sortedRegistryHosts(mirrors), ", "))
+		}
+		selected[host] = upstream
+	}
+	return selected, nil
+}
+
+// registryCredentials resolves the registry auth of the started mirrors, keyed by registry host
+func registryCredentials(auth, mirrors map[string]string) (map[string]*docker.RegistryCredentials, error) {
+	credentials := make(map[string]*docker.RegistryCredentials)
+	for host, value := range auth {
+		if _, ok := mirrors[host]; !ok {
+			logger.Debugf("skipping registry auth for %s, its mirror isn't started", host)
+			continue
+		}
+		username, password, err := config.ParseRegistryCredentials(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid registry auth for %s: %w", host, err)
+		}
+		credentials[host] = &docker.RegistryCredentials{Username: username, Password: password}
+	}
+	return credentials, nil
+}
+
+// registryUpstreamHost returns the host of a mirror's upstream URL, containerd keys its registry auth by it
+func registryUpstreamHost(host, upstream string) string {
+	if u, err := url.Parse(upstream); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return host
+}
+
+// sortedRegistryHosts returns the mirrored registry hosts in a stable order
+func sortedRegistryHosts(mirrors map[string]string) []string {
+	hosts := make([]string, 0, len(mirrors))
+	for host := range mirrors {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// registryMirrorContainerName returns the mirror container name for a registry host
+func registryMirrorContainerName(host string) string {
+	// keep the existing container names for the default mirrors
+	if name, ok := config.KindRegistryHosts[host]; ok {
+		return name
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(host)
+}
+
+// RegistryContainerNames returns the kind-registry container followed by the containers of the default and
+// custom mirrors
+func RegistryContainerNames(customMirrors map[string]string) []string {
+	return registryContainerNames(customMirrors)
+}
+
+// registryContainerNames returns the kind-registry container followed by its mirror containers
+func registryContainerNames(mirrors map[string]string) []string {
+	merged := mergeRegistryMirrors(mirrors)
+
+	names := make([]string, 0, len(merged))
+	for _, host := range sortedRegistryHosts(merged) {
+		names = append(names, registryMirrorContainerName(host))
+	}
+
+	return append([]string{config.KindRegistryName}, names...)
+}
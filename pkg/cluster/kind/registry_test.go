@@ -0,0 +1,102 @@
+package kind
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/util/docker"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry mirrors", func() {
+	It("should start every mirror by default", func() {
+		mirrors, err := registryMirrors(&CreateOptions{RegistryMirrors: map[string]string{"ghcr.io": "https://ghcr.io"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mirrors).To(HaveLen(len(config.KindRegistryHosts) + 1))
+	})
+
+	It("should only start the requested mirrors", func() {
+		mirrors, err := registryMirrors(&CreateOptions{RegistryMirrorHosts: []string{"docker.io", "quay.io"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mirrors).To(Equal(map[string]string{"docker.io": "https://registry-1.docker.io", "quay.io": "https://quay.io"}))
+
+		_, err = registryMirrors(&CreateOptions{RegistryMirrorHosts: []string{"ghcr.io"}})
+		Expect(err).To(MatchError(ContainSubstring("unknown registry mirror ghcr.io")))
+	})
+
+	It("should start no mirrors when disabled", func() {
+		mirrors, err := registryMirrors(&CreateOptions{NoRegistryMirrors: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mirrors).To(BeEmpty())
+	})
+
+	It("should only point containerd at the started mirrors", func() {
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", nil, 1, "7001", 5000, map[string]string{"quay.io": "https://quay.io"}, nil, "",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, "cilium", nil, nil)
+
+		Expect(rendered).To(ContainSubstring(`registry.mirrors."localhost:5000"]`))
+		Expect(rendered).To(ContainSubstring(`registry.mirrors."quay.io"]`))
+		Expect(rendered).NotTo(ContainSubstring(`registry.mirrors."docker.io"]`))
+		Expect(rendered).NotTo(ContainSubstring(".auth]"))
+	})
+
+	It("should point containerd at the registry over https and trust its certificate", func() {
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", []config.WorkerNodeConfig{{}}, 1, "7001", 5000, nil, nil, "/home/user/.lok8s/registry-certs",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, "cilium", nil, nil)
+
+		Expect(rendered).To(ContainSubstring(`endpoint = ["https://kind-registry:5000"]`))
+		Expect(rendered).To(ContainSubstring(`registry.configs."kind-registry:5000".tls]
+      ca_file = "/etc/containerd/certs.d/kind-registry/ca.crt"`))
+		// the control-plane and the worker both mount the certificate
+		Expect(strings.Count(rendered, `hostPath: "/home/user/.lok8s/registry-certs/tls.crt"`)).To(Equal(2))
+	})
+
+	It("should generate the registry certificate once", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "registry-certs")
+		Expect(ensureRegistryCert(dir)).To(Succeed())
+
+		certPEM, err := os.ReadFile(filepath.Join(dir, registryCertFile))
+		Expect(err).NotTo(HaveOccurred())
+		block, _ := pem.Decode(certPEM)
+		Expect(block).NotTo(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.DNSNames).To(ConsistOf("kind-registry", "localhost"))
+		Expect(cert.VerifyHostname("127.0.0.1")).To(Succeed())
+
+		info, err := os.Stat(filepath.Join(dir, registryKeyFile))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		Expect(ensureRegistryCert(dir)).To(Succeed())
+		Expect(os.ReadFile(filepath.Join(dir, registryCertFile))).To(Equal(certPEM))
+	})
+
+	It("should only resolve the registry auth of the started mirrors", func() {
+		GinkgoT().Setenv("LOK8S_TEST_TOKEN", "s3cret")
+		credentials, err := registryCredentials(map[string]string{"docker.io": "user:$LOK8S_TEST_TOKEN", "ghcr.io": "user:token"},
+			map[string]string{"docker.io": "https://registry-1.docker.io"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(HaveLen(1))
+		Expect(*credentials["docker.io"]).To(Equal(docker.RegistryCredentials{Username: "user", Password: "s3cret"}))
+
+		_, err = registryCredentials(map[string]string{"docker.io": "$LOK8S_TEST_UNSET"}, map[string]string{"docker.io": "https://registry-1.docker.io"})
+		Expect(err).To(MatchError(ContainSubstring("invalid registry auth for docker.io")))
+	})
+
+	It("should pass the registry auth to containerd keyed by the upstream host", func() {
+		rendered := generateKindConfig("kindest/node:v1.31.2", "myproject", nil, 1, "7001", 5000, map[string]string{"docker.io": "https://registry-1.docker.io"},
+			map[string]*docker.RegistryCredentials{"docker.io": {Username: "user", Password: "s3cret"}}, "",
+			config.KindPodSubnet, config.KindServiceSubnet, config.IPFamilyIPv4, "cilium", nil, nil)
+
+		Expect(rendered).To(ContainSubstring(`    [plugins."io.containerd.grpc.v1.cri".registry.configs."registry-1.docker.io".auth]
+      username = "user"
+      password = "s3cret"
+`))
+	})
+})
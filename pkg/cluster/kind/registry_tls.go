@@ -0,0 +1,145 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kind
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+const (
+	registryCertFile = "tls.crt"
+	registryKeyFile  = "tls.key"
+	// where the nodes find the registry certificate to trust it
+	registryNodeCAPath = "/etc/containerd/certs.d/" + config.KindRegistryName + "/ca.crt"
+)
+
+// registryCertDir returns the directory the self-signed kind registry certificate is kept in, it outlives the
+// clusters since the registry container is restarted with them
+func registryCertDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, "."+config.AppName, "registry-certs"), nil
+}
+
+// registryTLSCertDir returns the certificate directory of the kind registry when it's served over https, empty for
+// plain http
+func registryTLSCertDir(enabled bool) (string, error) {
+	if !enabled {
+		return "", nil
+	}
+	return registryCertDir()
+}
+
+// prepareRegistryCert returns the certificate directory of the kind registry when it's served over https, generating
+// the certificate first, and an empty directory for plain http
+func prepareRegistryCert(enabled bool) (string, error) {
+	dir, err := registryTLSCertDir(enabled)
+	if err != nil || dir == "" {
+		return dir, err
+	}
+	if err := ensureRegistryCert(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// ensureRegistryCert generates the self-signed kind registry certificate in the directory unless there is one already
+func ensureRegistryCert(dir string) error {
+	certPath := filepath.Join(dir, registryCertFile)
+	if _, err := os.Stat(certPath); err == nil {
+		return nil
+	}
+
+	certPEM, keyPEM, err := generateRegistryCert()
+	if err != nil {
+		return fmt.Errorf("failed to generate registry certificate: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create registry certificate directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, registryKeyFile), keyPEM, 0600); err != nil {
+		return fmt.Errorf("failed to write registry key: %w", err)
+	}
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return fmt.Errorf("failed to write registry certificate: %w", err)
+	}
+
+	logger.Infof("🔒 generated the %s certificate %s, trust it to push over https", config.KindRegistryName, certPath)
+	return nil
+}
+
+// generateRegistryCert creates a self-signed certificate for the kind registry, valid for its name on the kind
+// network and localhost on the host, returned PEM encoded
+func generateRegistryCert() ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	// the certificate is its own CA, so the nodes can trust it directly
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: config.KindRegistryName},
+		DNSNames:              []string{config.KindRegistryName, "localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(3, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
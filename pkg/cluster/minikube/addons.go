@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package minikube
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// AddonsOptions contains options for managing minikube addons across a project
+type AddonsOptions struct {
+	Project     string
+	NumClusters int
+	Action      string // enable, disable or list
+	Addon       string // not used by list
+}
+
+// ManageAddons runs 'minikube addons <action>' against every profile in the project
+func (m *Manager) ManageAddons(opts *AddonsOptions) error {
+	if opts.Action != "list" && opts.Addon == "" {
+		return fmt.Errorf("an addon name is required to %s addons", opts.Action)
+	}
+
+	// set environment variable to disable styling
+	os.Setenv("MINIKUBE_IN_STYLE", "false")
+
+	binaryPath, err := m.binaryManager.GetBinaryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get minikube binary path: %w", err)
+	}
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		var clusterName string
+		if opts.NumClusters == 1 {
+			// if only one cluster, don't add suffix
+			clusterName = opts.Project
+		} else {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+		}
+
+		switch opts.Action {
+		case "list":
+			fmt.Printf("# cluster %s (%d/%d)\n", clusterName, i, opts.NumClusters)
+			cmd := exec.Command(binaryPath, "addons", "list", "-p", clusterName)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("failed to list addons for cluster %s: %w", clusterName, err)
+			}
+			fmt.Println()
+		case "enable", "disable":
+			if err := m.setAddon(binaryPath, clusterName, opts.Action, opts.Addon); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid addons action '%s'. Must be 'enable', 'disable' or 'list'", opts.Action)
+		}
+	}
+
+	if opts.Action != "list" {
+		logger.Infof("✓ successfully %sd the %s addon on %d Minikube cluster(s)", opts.Action, opts.Addon, opts.NumClusters)
+	}
+	return nil
+}
+
+// setAddon enables or disables a single addon on a minikube profile
+func (m *Manager) setAddon(binaryPath, clusterName, action, addon string) error {
+	verb := "enabling"
+	if action == "disable" {
+		verb = "disabling"
+	}
+
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("%s %s addon for cluster %s", verb, addon, clusterName))
+
+	cmd := exec.Command(binaryPath, "addons", action, addon, "-p", clusterName)
+	cmd.Stdout = logger.GetLogger().Out
+	cmd.Stderr = logger.GetLogger().Out
+	if err := cmd.Run(); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to %s %s addon for cluster %s: %w", action, addon, clusterName, err)
+	}
+
+	status.End(true)
+	return nil
+}
@@ -23,20 +23,15 @@
 package minikube
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
 
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
 	"github.com/day0ops/lok8s/pkg/util/github"
 	"github.com/day0ops/lok8s/pkg/util/version"
 )
@@ -92,6 +87,21 @@ func (bm *BinaryManager) GetLatestVersion() (string, error) {
 	return bm.githubClient.GetLatestVersion("kubernetes", "minikube")
 }
 
+// InstalledVersion returns the version of the downloaded minikube binary without downloading it, empty when it is
+// not there yet
+func (bm *BinaryManager) InstalledVersion() (string, error) {
+	binaryPath := bm.getBinaryPath()
+	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	output, err := utilexec.Output(binaryPath, "version", "--short")
+	if err != nil {
+		return "", fmt.Errorf("failed to get minikube version: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "v")), nil
+}
+
 // isBinaryValid checks if the existing binary is valid
 func (bm *BinaryManager) isBinaryValid() bool {
 	if bm.binaryPath == "" {
@@ -104,12 +114,12 @@ func (bm *BinaryManager) isBinaryValid() bool {
 	}
 
 	// Check if binary is executable
-	if err := exec.Command(bm.binaryPath, "version", "--short").Run(); err != nil {
+	if err := utilexec.Run(bm.binaryPath, "version", "--short"); err != nil {
 		return false
 	}
 
 	// Check version
-	output, err := exec.Command(bm.binaryPath, "version", "--short").Output()
+	output, err := utilexec.Output(bm.binaryPath, "version", "--short")
 	if err != nil {
 		return false
 	}
@@ -154,48 +164,10 @@ func (bm *BinaryManager) downloadBinary() error {
 		return fmt.Errorf("failed to download binary: %w", err)
 	}
 
-	// Verify checksum
-	if err := bm.verifyChecksum(checksumURL, bm.binaryPath); err != nil {
-		logger.Warnf("failed to verify checksum: %v", err)
-		// Continue anyway as checksum verification is not critical
-	}
-
-	return nil
-}
-
-// verifyChecksum verifies the downloaded file's checksum
-func (bm *BinaryManager) verifyChecksum(checksumURL, filePath string) error {
-	// Download checksum
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Get(checksumURL)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	checksumData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	expectedChecksum := strings.Fields(string(checksumData))[0]
-
-	// Calculate file checksum
-	file, err := os.Open(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return err
-	}
-
-	actualChecksum := hex.EncodeToString(hash.Sum(nil))
-
-	if actualChecksum != expectedChecksum {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, actualChecksum)
+	// Verify checksum, removing the binary so a corrupt download is never reused
+	if err := bm.githubClient.VerifyChecksum(checksumURL, binaryName, bm.binaryPath); err != nil {
+		os.Remove(bm.binaryPath)
+		return fmt.Errorf("checksum verification failed: %w", err)
 	}
 
 	return nil
@@ -265,7 +237,7 @@ func (bm *BinaryManager) GetVersion() (string, error) {
 		return "", err
 	}
 
-	output, err := exec.Command(bm.binaryPath, "version", "--short").Output()
+	output, err := utilexec.Output(bm.binaryPath, "version", "--short")
 	if err != nil {
 		return "", fmt.Errorf("failed to get minikube version: %w", err)
 	}
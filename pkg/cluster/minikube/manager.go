@@ -25,20 +25,25 @@ package minikube
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
+	"github.com/day0ops/lok8s/pkg/cluster/report"
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
 	"github.com/day0ops/lok8s/pkg/network"
 	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util"
+	"github.com/day0ops/lok8s/pkg/util/docker"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
 	"github.com/day0ops/lok8s/pkg/util/helm"
 	"github.com/day0ops/lok8s/pkg/util/k8s"
 	"github.com/day0ops/lok8s/pkg/util/version"
@@ -56,45 +61,92 @@ type Manager struct {
 	binaryManager  *BinaryManager
 	helmManager    *helm.HelmManager
 	ciliumManager  *services.CiliumManager
+	flannelManager *services.FlannelManager
 	metallbManager *services.MetalLBManager
+	waitTimeout    time.Duration // how long to wait for nodes to become ready
 }
 
 // CreateOptions contains options for creating minikube clusters
 type CreateOptions struct {
-	Project          string
-	Bridge           string
-	CPU              string
-	Memory           string
-	Disk             string
-	SubnetCIDR       string
-	NumClusters      int
-	NodeCount        int
-	K8sVersion       string
-	InstallMetalLB   bool
-	Verbose          bool
-	CNI              string
-	ContainerRuntime string
+	Project             string
+	Bridge              string
+	CPU                 string
+	Memory              string
+	Disk                string
+	SubnetCIDR          string
+	NumClusters         int
+	NodeCount           int
+	HA                  bool   // start multi-control-plane clusters
+	APIServerVIP        string // VIP reserved in the network for the HA control planes, set once it's ensured
+	K8sVersion          string
+	InstallMetalLB      bool
+	MetalLBPoolSize     int
+	MetalLBIPRanges     []string                 // exact pool of each cluster, generated when empty
+	MetalLBBGPPeer      *services.MetalLBBGPPeer // advertise over BGP to this peer, nil for L2
+	Verbose             bool
+	CNI                 string
+	ContainerRuntime    string
+	EnableCSI           bool
+	EnableMetrics       bool
+	ReadinessTimeout    time.Duration // defaults to config.DefaultReadinessTimeout
+	DryRun              bool
+	Parallel            bool
+	HelmSet             []string // release.key=value overrides merged over the built-in Helm values
+	CiliumChartVersion  string   // pinned chart versions, empty for the latest
+	MetalLBChartVersion string
+	CiliumChart         string // local chart directories or archives, empty for the repo charts
+	MetalLBChart        string
+	CiliumValuesFile    string // Helm values files merged beneath HelmSet
+	MetalLBValuesFile   string
+	SubnetSearchLimit   int      // libvirt subnets to probe for a free one, defaults to config.DefaultSubnetSearchLimit
+	Mounts              []string // host:container directory mounted into the nodes, minikube supports a single one
+	CleanupOnFailure    bool     // delete the clusters this create added when it fails or times out
 }
 
 // DeleteOptions contains options for deleting minikube clusters
 type DeleteOptions struct {
-	Project     string
-	NumClusters int
-	Force       bool
-	Bridge      string
-	SubnetCIDR  string
+	Project      string
+	NumClusters  int
+	ClusterIndex int // only delete this cluster (1-NumClusters) and keep the project, 0 deletes every cluster
+	Force        bool
+	Bridge       string
+	SubnetCIDR   string
 }
 
 // StatusOptions contains options for checking minikube cluster status
 type StatusOptions struct {
+	Project      string
+	NumClusters  int
+	CNI          string
+	OutputFormat string // table (default) or json
+}
+
+// StartOptions contains options for starting stopped minikube clusters
+type StartOptions struct {
+	Project     string
+	NumClusters int
+}
+
+// StopOptions contains options for stopping minikube clusters
+type StopOptions struct {
 	Project     string
 	NumClusters int
 }
 
+// ScaleOptions contains options for changing the node count of minikube clusters
+type ScaleOptions struct {
+	Project         string
+	NumClusters     int
+	NodeCount       int                      // nodes each cluster should have, including the control plane
+	CNI             string                   // nodes aren't waited on when the project has no CNI
+	MetalLBIPRanges []string                 // pools given by the user aren't moved off a new node's IP
+	MetalLBBGPPeer  *services.MetalLBBGPPeer // kept when a MetalLB pool has to move off a new node's IP
+}
+
 // LoadImageOptions contains options for loading images into minikube clusters
 type LoadImageOptions struct {
 	Project     string
-	Image       string
+	Images      []string
 	NumClusters int
 }
 
@@ -108,13 +160,55 @@ func NewManager() *Manager {
 		binaryManager:  binaryManager,
 		helmManager:    helmManager,
 		ciliumManager:  services.NewCiliumManager(helmManager, binaryManager),
-		metallbManager: services.NewMetalLBManagerWithOptions(helmManager, config.MetalLBRangeMinLastOctet, config.MetalLBRangeMaxLastOctet),
+		flannelManager: services.NewFlannelManager(),
+		metallbManager: services.NewMetalLBManagerWithOptions(helmManager, config.MetalLBRangeMinLastOctet, config.MetalLBRangeMaxLastOctet, config.MetalLBDefaultIPsPerCluster),
+		waitTimeout:    config.DefaultReadinessTimeout,
 	}
 }
 
-// CreateClusters creates multiple minikube clusters
-func (m *Manager) CreateClusters(opts *CreateOptions) error {
+// CreateClusters creates multiple minikube clusters, provisioning stops once the context is done
+func (m *Manager) CreateClusters(ctx context.Context, opts *CreateOptions) error {
 	logger.Infof("-----> 📢 creating %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	started := time.Now()
+
+	if opts.ReadinessTimeout <= 0 {
+		opts.ReadinessTimeout = config.DefaultReadinessTimeout
+	}
+	m.waitTimeout = opts.ReadinessTimeout
+	m.metallbManager.SetReadinessTimeout(opts.ReadinessTimeout)
+	m.ciliumManager.SetChartVersion(opts.CiliumChartVersion)
+	m.metallbManager.SetChartVersion(opts.MetalLBChartVersion)
+	m.ciliumManager.SetChart(opts.CiliumChart)
+	m.metallbManager.SetChart(opts.MetalLBChart)
+
+	if len(opts.Mounts) > 1 {
+		return fmt.Errorf("minikube supports a single mount, got %d", len(opts.Mounts))
+	}
+	if err := config.CheckMountHostPaths(opts.Mounts); err != nil {
+		return err
+	}
+
+	// Helm waits for the MetalLB pods, which can't be scheduled until the user applies a CNI
+	if opts.CNI == "none" && opts.InstallMetalLB {
+		logger.Warnf("⚠️ skipping MetalLB, it can't start until a CNI is installed")
+		opts.InstallMetalLB = false
+	}
+
+	valueOverrides, err := helm.LoadValueOverrides(map[string]string{
+		"cilium":  opts.CiliumValuesFile,
+		"metallb": opts.MetalLBValuesFile,
+	}, opts.HelmSet)
+	if err != nil {
+		return err
+	}
+	for _, release := range addonReleases(valueOverrides) {
+		logger.Warnf("⚠️ minikube installs %s itself rather than with Helm, ignoring its --helm-set overrides", release)
+	}
+	m.helmManager.SetValueOverrides(valueOverrides)
+
+	if opts.DryRun {
+		return m.dryRunCreate(opts)
+	}
 
 	// check prerequisites
 	if err := m.checkPrerequisites(); err != nil {
@@ -128,7 +222,7 @@ func (m *Manager) CreateClusters(opts *CreateOptions) error {
 	}
 
 	// setup network and driver based on OS
-	networkManager, driver, err := m.setupNetworkAndDriver(opts.Project, opts.Bridge, opts.SubnetCIDR)
+	networkManager, driver, err := m.setupNetworkAndDriver(opts.Project, opts.Bridge, opts.SubnetCIDR, opts.SubnetSearchLimit)
 	if err != nil {
 		return fmt.Errorf("failed to setup network and driver: %w", err)
 	}
@@ -141,80 +235,221 @@ func (m *Manager) CreateClusters(opts *CreateOptions) error {
 	// Extract network name and subnet from the network manager
 	var networkName string
 	var actualSubnet string
+	var haVIP string
 	if net, ok := networkManager.(*network.Network); ok {
 		networkName = net.Name
 		actualSubnet = net.Subnet
+		haVIP = net.HAVIP
 	} else {
 		return fmt.Errorf("unexpected network manager type")
 	}
 
+	// the network reserves a single VIP, so only a single HA cluster can be given it
+	if opts.HA && haVIP != "" {
+		if opts.NumClusters == 1 {
+			opts.APIServerVIP = haVIP
+			logger.Infof("🌐 using %s reserved in network %s as the API server VIP", haVIP, networkName)
+		} else {
+			logger.Warnf("⚠️ network %s only reserves %s for a single HA cluster, minikube picks the API server VIP of each of the %d clusters", networkName, haVIP, opts.NumClusters)
+		}
+	}
+
 	// Update subnet in options if it was changed (e.g., free subnet was selected)
 	if actualSubnet != "" && actualSubnet != opts.SubnetCIDR {
-		opts.SubnetCIDR = actualSubnet
 		logger.Debugf("using subnet %s (updated from %s)", actualSubnet, opts.SubnetCIDR)
+		opts.SubnetCIDR = actualSubnet
 	}
 
-	// create clusters
-	for i := 1; i <= opts.NumClusters; i++ {
-		var clusterName string
-		if opts.NumClusters == 1 {
-			// if only one cluster, don't add suffix
-			clusterName = opts.Project
-		} else {
-			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+	// MetalLB tracking is shared by all clusters, so set it up once before creating any
+	if opts.InstallMetalLB {
+		m.metallbManager.SetIPsPerCluster(opts.MetalLBPoolSize)
+		m.metallbManager.SetIPRanges(opts.MetalLBIPRanges)
+		m.metallbManager.SetSubnet(opts.SubnetCIDR)
+		m.metallbManager.SetBGPPeer(opts.MetalLBBGPPeer)
+		if err := m.metallbManager.InitializeTracking(opts.Project); err != nil {
+			logger.Warnf("failed to initialize MetalLB tracking: %v", err)
 		}
+	}
 
-		if err := m.createCluster(clusterName, k8sVersion, driver, opts.CPU, opts.Memory, opts.Disk, networkName, opts.CNI, opts.ContainerRuntime, opts.NodeCount, i, opts.Verbose); err != nil {
-			return fmt.Errorf("failed to create cluster %s: %w", clusterName, err)
+	// remember the profiles that were there before, minikube starts them again rather than recreating so a
+	// failed create only cleans up the ones it added
+	var existingProfiles []string
+	if opts.CleanupOnFailure {
+		if existingProfiles, err = m.ProfileNames(); err != nil {
+			return err
 		}
+	}
 
-		if opts.InstallMetalLB {
-			// initialize tracking before first cluster configuration
-			if i == 1 {
-				if err := m.metallbManager.InitializeTracking(opts.Project); err != nil {
-					logger.Warnf("failed to initialize MetalLB tracking: %v", err)
-				}
+	// create clusters
+	m.helmManager.SetContext(ctx)
+	results := make([]report.CreateResult, opts.NumClusters)
+	var createErr error
+	if opts.Parallel && opts.NumClusters > 1 {
+		createErr = m.provisionClustersParallel(ctx, opts, k8sVersion, driver, networkName, results)
+	} else {
+		for i := 1; i <= opts.NumClusters; i++ {
+			if createErr = m.provisionCluster(ctx, i, opts, k8sVersion, driver, networkName, &results[i-1]); createErr != nil {
+				break
 			}
+		}
+	}
+	report.PrintCreateSummary(logger.Output(), results, time.Since(started))
+	if createErr != nil {
+		if opts.CleanupOnFailure {
+			m.cleanupFailedCreate(opts, results, existingProfiles)
+		}
+		return createErr
+	}
 
-			if err := m.metallbManager.InstallMetalLB(clusterName); err != nil {
-				logger.Errorf("failed to install MetalLB on %s: %v", clusterName, err)
-			}
+	if report.AddonsFailed(results) {
+		logger.Warnf("⚠️ created %d Minikube cluster(s), but some of their add-ons failed", opts.NumClusters)
+	} else {
+		logger.Infof("✓ successfully created %d Minikube cluster(s)", opts.NumClusters)
+	}
 
+	// show profile list
+	if err := m.showProfileList(); err != nil {
+		logger.Warnf("failed to show profile list: %v", err)
+	}
+
+	return nil
+}
+
+// provisionCluster creates a single minikube cluster and installs its add-ons. Add-on failures are recorded on
+// the result rather than returned so the cluster itself is still usable
+func (m *Manager) provisionCluster(ctx context.Context, clusterIndex int, opts *CreateOptions, k8sVersion, driver, networkName string, result *report.CreateResult) error {
+	var clusterName string
+	if opts.NumClusters == 1 {
+		// if only one cluster, don't add suffix
+		clusterName = opts.Project
+	} else {
+		clusterName = fmt.Sprintf("%s-%d", opts.Project, clusterIndex)
+	}
+
+	// time the cluster and each of its steps for the create summary, minikube installs the CNI itself
+	started := time.Now()
+	result.Cluster = clusterName
+	result.CNI = opts.CNI
+	if opts.InstallMetalLB {
+		result.LoadBalancer = "metallb"
+	}
+	defer func() { result.Duration = time.Since(started) }()
+
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return fmt.Errorf("aborted creating cluster %s: %w", clusterName, err)
+	}
+	result.Created = true
+	if err := m.createCluster(ctx, clusterName, k8sVersion, driver, opts.CPU, opts.Memory, opts.Disk, networkName, opts.CNI, opts.ContainerRuntime, mountString(opts.Mounts), opts.NodeCount, clusterIndex, opts.HA, opts.APIServerVIP, opts.Verbose); err != nil {
+		result.Err = err
+		return fmt.Errorf("failed to create cluster %s: %w", clusterName, err)
+	}
+	result.AddStep("cluster", started)
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return fmt.Errorf("aborted provisioning cluster %s: %w", clusterName, err)
+	}
+
+	// record the project on the nodes so the cluster can be told apart from the ones of other projects
+	if err := k8s.LabelProjectNodes(clusterName, opts.Project); err != nil {
+		logger.Warnf("failed to label the nodes of %s with project %s: %v", clusterName, opts.Project, err)
+	}
+
+	if opts.InstallMetalLB {
+		step := time.Now()
+		if err := m.metallbManager.InstallMetalLB(clusterName); err != nil {
+			logger.Errorf("failed to install MetalLB on %s: %v", clusterName, err)
+			result.AddAddonError("metallb", err)
+		} else if ipAddress, err := m.getMinikubeIP(clusterName); err != nil {
 			// configure MetalLB after installation
-			var ipAddress string
-			if ipAddress, err = m.getMinikubeIP(clusterName); err != nil {
-				logger.Errorf("failed to get Minikube IP for cluster %s: %v", clusterName, err)
-			} else {
-				if err := m.metallbManager.ConfigureMetalLB(clusterName, ipAddress, i, opts.NumClusters, opts.Project); err != nil {
-					logger.Errorf("failed to configure MetalLB on %s: %v", clusterName, err)
-				}
-			}
+			logger.Errorf("failed to get Minikube IP for cluster %s: %v", clusterName, err)
+			result.AddAddonError("metallb", err)
+		} else if err := m.metallbManager.ConfigureMetalLB(clusterName, ipAddress, clusterIndex, opts.NumClusters, opts.Project); err != nil {
+			logger.Errorf("failed to configure MetalLB on %s: %v", clusterName, err)
+			result.AddAddonError("metallb", err)
 		}
+		result.AddStep("metallb", step)
+	}
 
-		// enable CSI support
+	// enable CSI support
+	if opts.EnableCSI {
+		step := time.Now()
 		if err := m.enableCSI(clusterName); err != nil {
 			logger.Errorf("failed to enable CSI on %s: %v", clusterName, err)
+			result.AddAddonError("csi", err)
 		}
+		result.AddStep("csi", step)
+	}
 
-		// enable metrics-server addon
+	// enable metrics-server addon
+	if opts.EnableMetrics {
+		step := time.Now()
 		if err := m.enableMetricsServer(clusterName); err != nil {
 			logger.Errorf("failed to enable metrics-server on %s: %v", clusterName, err)
+			result.AddAddonError("metrics-server", err)
 		}
+		result.AddStep("metrics-server", step)
 	}
 
-	logger.Infof("✓ successfully created %d Minikube cluster(s)", opts.NumClusters)
+	return nil
+}
+
+// provisionClustersParallel provisions the clusters concurrently and, once all of them are done, reports every cluster that failed
+func (m *Manager) provisionClustersParallel(ctx context.Context, opts *CreateOptions, k8sVersion, driver, networkName string, results []report.CreateResult) error {
+	logger.Infof("creating %d Minikube clusters in parallel", opts.NumClusters)
 
-	// show profile list
-	if err := m.showProfileList(); err != nil {
-		logger.Warnf("failed to show profile list: %v", err)
+	errs := util.RunParallel(opts.NumClusters, config.MaxParallelClusters, func(clusterIndex int) error {
+		return m.provisionCluster(ctx, clusterIndex, opts, k8sVersion, driver, networkName, &results[clusterIndex-1])
+	})
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			logger.Errorf("❌ %v", err)
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d Minikube cluster(s) failed to create: %w", len(failed), opts.NumClusters, errors.Join(failed...))
 	}
 
 	return nil
 }
 
+// cleanupFailedCreate deletes the clusters a failed create added along with their contexts and MetalLB
+// allocations, the profiles that existed before are kept
+func (m *Manager) cleanupFailedCreate(opts *CreateOptions, results []report.CreateResult, existingProfiles []string) {
+	currentProfiles, err := m.ProfileNames()
+	if err != nil {
+		logger.Errorf("failed to clean up after the failed create: %v", err)
+		return
+	}
+
+	for i, result := range results {
+		clusterName := result.Cluster
+		if !result.Created || slices.Contains(existingProfiles, clusterName) || !slices.Contains(currentProfiles, clusterName) {
+			continue
+		}
+		logger.Warnf("⚠️ deleting the partially created cluster %s", clusterName)
+		if err := m.DeleteClusters(&DeleteOptions{
+			Project:      opts.Project,
+			NumClusters:  opts.NumClusters,
+			ClusterIndex: i + 1,
+			Bridge:       opts.Bridge,
+			SubnetCIDR:   opts.SubnetCIDR,
+		}); err != nil {
+			logger.Errorf("failed to delete cluster %s: %v", clusterName, err)
+		}
+	}
+}
+
 // DeleteClusters deletes multiple minikube clusters
 func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
-	logger.Infof("-----> 🚨 deleting %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	if opts.ClusterIndex > 0 {
+		logger.Infof("-----> 🚨 deleting Minikube cluster %d of project %s <-----", opts.ClusterIndex, opts.Project)
+	} else {
+		logger.Infof("-----> 🚨 deleting %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	}
 
 	// set environment variable to disable styling
 	os.Setenv("MINIKUBE_IN_STYLE", "false")
@@ -236,7 +471,7 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 	}
 
 	// setup network and driver based on OS
-	networkManager, _, err := m.setupNetworkAndDriver(opts.Project, bridge, subnetCIDR)
+	networkManager, _, err := m.setupNetworkAndDriver(opts.Project, bridge, subnetCIDR, 0)
 	if err != nil {
 		return fmt.Errorf("failed to setup network and driver: %w", err)
 	}
@@ -258,6 +493,9 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 	}
 
 	for i := 1; i <= opts.NumClusters; i++ {
+		if opts.ClusterIndex > 0 && i != opts.ClusterIndex {
+			continue
+		}
 		var clusterName string
 		if opts.NumClusters == 1 {
 			// if only one cluster, don't add suffix
@@ -292,6 +530,19 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 		status.End(true)
 	}
 
+	// the rest of the project stays, only free the deleted cluster's MetalLB range
+	if opts.ClusterIndex > 0 {
+		clusterName := opts.Project
+		if opts.NumClusters > 1 {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, opts.ClusterIndex)
+		}
+		if err := m.metallbManager.ReleaseAllocation(opts.Project, clusterName); err != nil {
+			logger.Warnf("failed to release MetalLB allocation for %s: %v", clusterName, err)
+		}
+		logger.Infof("✓ successfully deleted Minikube cluster %s", clusterName)
+		return nil
+	}
+
 	// clean up network if network manager is available
 	if networkManager != nil && opts.Force {
 		if net, ok := networkManager.(*network.Network); ok {
@@ -320,29 +571,55 @@ func (m *Manager) DeleteClusters(opts *DeleteOptions) error {
 
 // StatusClusters shows the status of minikube clusters
 func (m *Manager) StatusClusters(opts *StatusOptions) error {
-	logger.Infof("-----> 📊 checking status of %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	if opts.OutputFormat == "" {
+		opts.OutputFormat = report.FormatTable
+	}
+	if err := report.ValidateFormat(opts.OutputFormat); err != nil {
+		return err
+	}
+
+	// keep stdout machine readable for json output
+	if opts.OutputFormat == report.FormatTable {
+		logger.Infof("-----> 📊 checking status of %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+	}
+
+	statuses, err := m.collectStatuses(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.OutputFormat == report.FormatJSON {
+		return report.PrintJSON(os.Stdout, statuses)
+	}
+
+	// print table
+	out := logger.Output()
+	fmt.Fprintf(out, "\nProject: %s\n\n", opts.Project)
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tSTATUS\tHOST\tKUBELET\tAPI SERVER\tNODES\tVERSION\tIP")
+	fmt.Fprintln(w, "-------\t------\t----\t-------\t----------\t-----\t-------\t---")
+
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", s.Cluster, s.Status, s.Host, s.Kubelet, s.APIServer, s.Nodes, s.Version, s.IP)
+	}
 
+	w.Flush()
+	return nil
+}
+
+// collectStatuses gathers the status of each minikube cluster in the project
+func (m *Manager) collectStatuses(opts *StatusOptions) ([]report.ClusterStatus, error) {
 	// ensure minikube binary is available
 	if err := m.binaryManager.EnsureBinary(); err != nil {
-		return fmt.Errorf("minikube binary not available: %w", err)
+		return nil, fmt.Errorf("minikube binary not available: %w", err)
 	}
 
 	binaryPath, err := m.binaryManager.GetBinaryPath()
 	if err != nil {
-		return fmt.Errorf("failed to get minikube binary path: %w", err)
+		return nil, fmt.Errorf("failed to get minikube binary path: %w", err)
 	}
 
-	// prepare table data
-	type clusterStatus struct {
-		name         string
-		status       string
-		host         string
-		kubelet      string
-		apiServer    string
-		ip           string
-	}
-
-	var statuses []clusterStatus
+	var statuses []report.ClusterStatus
 
 	for i := 1; i <= opts.NumClusters; i++ {
 		var clusterName string
@@ -353,74 +630,307 @@ func (m *Manager) StatusClusters(opts *StatusOptions) error {
 			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
 		}
 
+		// minikube names the context after the profile
+		clusterStatus := report.ClusterStatus{
+			Cluster:   clusterName,
+			Context:   clusterName,
+			IP:        "N/A",
+			Nodes:     "N/A",
+			Version:   "N/A",
+			Host:      "N/A",
+			Kubelet:   "N/A",
+			APIServer: "N/A",
+		}
+
 		// check if cluster exists by trying to get its status
-		cmd := exec.Command(binaryPath, "status", "-p", clusterName, "--format", "{{.Host}},{{.Kubelet}},{{.APIServer}}")
-		output, err := cmd.Output()
-		if err != nil {
-			statuses = append(statuses, clusterStatus{
-				name:   clusterName,
-				status: "Not Found",
-				host:   "N/A",
-				kubelet: "N/A",
-				apiServer: "N/A",
-				ip:     "N/A",
-			})
+		// minikube status exits non-zero for stopped clusters, so only treat it as missing when there's no output
+		output, err := utilexec.Output(binaryPath, "status", "-p", clusterName, "--format", "{{.Host}},{{.Kubelet}},{{.APIServer}}")
+		statusStr := strings.TrimSpace(string(output))
+		if err != nil && statusStr == "" {
+			clusterStatus.Status = "Not Found"
+			statuses = append(statuses, clusterStatus)
 			continue
 		}
 
 		// parse status output (format: hostStatus,kubeletStatus,apiServerStatus)
-		statusStr := strings.TrimSpace(string(output))
 		parts := strings.Split(statusStr, ",")
 		if len(parts) != 3 {
-			statuses = append(statuses, clusterStatus{
-				name:   clusterName,
-				status: "Unknown",
-				host:   "N/A",
-				kubelet: "N/A",
-				apiServer: "N/A",
-				ip:     "N/A",
-			})
+			clusterStatus.Status = "Unknown"
+			statuses = append(statuses, clusterStatus)
 			continue
 		}
 
-		hostStatus := strings.TrimSpace(parts[0])
-		kubeletStatus := strings.TrimSpace(parts[1])
-		apiServerStatus := strings.TrimSpace(parts[2])
+		clusterStatus.Host = strings.TrimSpace(parts[0])
+		clusterStatus.Kubelet = strings.TrimSpace(parts[1])
+		clusterStatus.APIServer = strings.TrimSpace(parts[2])
+
+		// a stopped host has no IP to report
+		if clusterStatus.Host == "Stopped" {
+			clusterStatus.Status = "Stopped"
+			statuses = append(statuses, clusterStatus)
+			continue
+		}
 
 		// get cluster IP
-		ip := "N/A"
-		ipCmd := exec.Command(binaryPath, "ip", "-p", clusterName)
-		if ipOutput, err := ipCmd.Output(); err == nil {
-			ip = strings.TrimSpace(string(ipOutput))
+		if ipOutput, err := utilexec.Output(binaryPath, "ip", "-p", clusterName); err == nil {
+			clusterStatus.IP = strings.TrimSpace(string(ipOutput))
+		}
+
+		// query node counts and version from the api server when it's up
+		nodesReady := true
+		if clusterStatus.APIServer == "Running" {
+			if clientManager, err := k8s.NewClientManagerForContext(clusterName); err == nil {
+				if ready, total, err := clientManager.CountReadyNodes(); err == nil {
+					clusterStatus.Nodes = fmt.Sprintf("%d/%d", ready, total)
+					nodesReady = ready == total
+				}
+				if serverVersion, err := clientManager.GetServerVersion(); err == nil {
+					clusterStatus.Version = serverVersion
+				}
+			} else {
+				logger.Debugf("failed to create client for %s: %v", clusterName, err)
+			}
 		}
 
 		// determine overall status
-		overallStatus := "Running"
-		if hostStatus != "Running" || kubeletStatus != "Running" || apiServerStatus != "Running" {
-			overallStatus = "Not Ready"
+		clusterStatus.Status = "Running"
+		if clusterStatus.Host != "Running" || clusterStatus.Kubelet != "Running" || clusterStatus.APIServer != "Running" {
+			clusterStatus.Status = "Not Ready"
+		} else if !nodesReady && opts.CNI == "none" {
+			// nodes never become ready without a CNI, which is expected until the user applies one
+			clusterStatus.Status = "No CNI installed"
 		}
 
-		statuses = append(statuses, clusterStatus{
-			name:      clusterName,
-			status:    overallStatus,
-			host:      hostStatus,
-			kubelet:   kubeletStatus,
-			apiServer: apiServerStatus,
-			ip:        ip,
-		})
+		statuses = append(statuses, clusterStatus)
 	}
 
-	// print table
-	fmt.Printf("\nProject: %s\n\n", opts.Project)
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "CLUSTER\tSTATUS\tHOST\tKUBELET\tAPI SERVER\tIP")
-	fmt.Fprintln(w, "-------\t------\t----\t-------\t----------\t---")
+	return statuses, nil
+}
 
-	for _, s := range statuses {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.name, s.status, s.host, s.kubelet, s.apiServer, s.ip)
+// StartClusters starts previously stopped minikube clusters
+func (m *Manager) StartClusters(opts *StartOptions) error {
+	logger.Infof("-----> 📢 starting %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+
+	// set environment variable to disable styling
+	os.Setenv("MINIKUBE_IN_STYLE", "false")
+
+	binaryPath, err := m.binaryManager.GetBinaryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get minikube binary path: %w", err)
 	}
 
-	w.Flush()
+	for i := 1; i <= opts.NumClusters; i++ {
+		var clusterName string
+		if opts.NumClusters == 1 {
+			// if only one cluster, don't add suffix
+			clusterName = opts.Project
+		} else {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+		}
+
+		status := logger.NewStatus()
+		status.Start(fmt.Sprintf("starting Minikube cluster %s (%d/%d)", clusterName, i, opts.NumClusters))
+
+		if err := m.runProfileCommand(binaryPath, "start", clusterName); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to start cluster %s: %w", clusterName, err)
+		}
+		status.End(true)
+	}
+
+	logger.Infof("✓ successfully started %d Minikube cluster(s)", opts.NumClusters)
+	return nil
+}
+
+// StopClusters stops minikube clusters without deleting them
+func (m *Manager) StopClusters(opts *StopOptions) error {
+	logger.Infof("-----> 🚨 stopping %d Minikube cluster(s) for project %s <-----", opts.NumClusters, opts.Project)
+
+	// set environment variable to disable styling
+	os.Setenv("MINIKUBE_IN_STYLE", "false")
+
+	binaryPath, err := m.binaryManager.GetBinaryPath()
+	if err != nil {
+		return fmt.Errorf("failed to get minikube binary path: %w", err)
+	}
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		var clusterName string
+		if opts.NumClusters == 1 {
+			// if only one cluster, don't add suffix
+			clusterName = opts.Project
+		} else {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+		}
+
+		status := logger.NewStatus()
+		status.Start(fmt.Sprintf("stopping Minikube cluster %s (%d/%d)", clusterName, i, opts.NumClusters))
+
+		if err := m.runProfileCommand(binaryPath, "stop", clusterName); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to stop cluster %s: %w", clusterName, err)
+		}
+		status.End(true)
+	}
+
+	logger.Infof("✓ successfully stopped %d Minikube cluster(s)", opts.NumClusters)
+	return nil
+}
+
+// ScaleClusters adds or removes nodes until every cluster of the project has opts.NodeCount nodes. The newest
+// nodes are removed first and the control plane is never touched. It returns the node count reached, which is
+// that of the failing cluster on error, or 0 when no cluster was scaled
+func (m *Manager) ScaleClusters(opts *ScaleOptions) (int, error) {
+	if opts.NodeCount < 1 {
+		return 0, fmt.Errorf("clusters need at least 1 node, got %d", opts.NodeCount)
+	}
+
+	logger.Infof("-----> 📢 scaling %d Minikube cluster(s) for project %s to %d node(s) <-----", opts.NumClusters, opts.Project, opts.NodeCount)
+
+	// set environment variable to disable styling
+	os.Setenv("MINIKUBE_IN_STYLE", "false")
+
+	binaryPath, err := m.binaryManager.GetBinaryPath()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get minikube binary path: %w", err)
+	}
+
+	// new nodes can take an IP inside a MetalLB pool, so load the allocations to check them afterwards
+	m.metallbManager.SetIPRanges(opts.MetalLBIPRanges)
+	m.metallbManager.SetBGPPeer(opts.MetalLBBGPPeer)
+	if err := m.metallbManager.InitializeTracking(opts.Project); err != nil {
+		logger.Warnf("failed to initialize MetalLB tracking: %v", err)
+	}
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		var clusterName string
+		if opts.NumClusters == 1 {
+			// if only one cluster, don't add suffix
+			clusterName = opts.Project
+		} else {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+		}
+
+		if reached, err := m.scaleCluster(binaryPath, clusterName, i, opts); err != nil {
+			return reached, err
+		}
+	}
+
+	logger.Infof("✓ successfully scaled %d Minikube cluster(s) to %d node(s)", opts.NumClusters, opts.NodeCount)
+	return opts.NodeCount, nil
+}
+
+// scaleCluster brings a single cluster to opts.NodeCount nodes, then waits for them and refreshes the node labels
+// and the MetalLB allocation. It returns the node count the cluster was left with, or 0 when its nodes couldn't be
+// listed
+func (m *Manager) scaleCluster(binaryPath, clusterName string, clusterIndex int, opts *ScaleOptions) (int, error) {
+	output, err := utilexec.Output(binaryPath, "node", "list", "-p", clusterName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the nodes of cluster %s: %w", clusterName, err)
+	}
+	nodes := parseNodeNames(output)
+	if len(nodes) == 0 {
+		return 0, fmt.Errorf("no nodes found for cluster %s", clusterName)
+	}
+
+	if len(nodes) == opts.NodeCount {
+		logger.Infof("cluster %s already has %d node(s)", clusterName, opts.NodeCount)
+		return opts.NodeCount, nil
+	}
+
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("scaling Minikube cluster %s from %d to %d node(s)", clusterName, len(nodes), opts.NodeCount))
+
+	// count the nodes as they change so a partial scale can still be recorded
+	reached := len(nodes)
+	for reached < opts.NodeCount {
+		if err := m.runProfileCommand(binaryPath, "node", clusterName, "add"); err != nil {
+			status.End(false)
+			return reached, fmt.Errorf("failed to add a node to cluster %s: %w", clusterName, err)
+		}
+		reached++
+	}
+	for _, node := range nodesToRemove(nodes, opts.NodeCount) {
+		if err := m.runProfileCommand(binaryPath, "node", clusterName, "delete", node); err != nil {
+			status.End(false)
+			return reached, fmt.Errorf("failed to delete node %s from cluster %s, it has %d node(s) left: %w", node, clusterName, reached, err)
+		}
+		reached--
+	}
+	status.End(true)
+
+	// without a CNI the nodes stay NotReady until the user applies one
+	if opts.CNI != "none" {
+		if err := m.waitForNodesReady(clusterName); err != nil {
+			return reached, fmt.Errorf("nodes not ready: %w", err)
+		}
+	}
+
+	if err := k8s.LabelProjectNodes(clusterName, opts.Project); err != nil {
+		logger.Warnf("failed to label the nodes of %s with project %s: %v", clusterName, opts.Project, err)
+	}
+
+	if ipAddress, err := m.getMinikubeIP(clusterName); err != nil {
+		logger.Warnf("failed to get Minikube IP for cluster %s, MetalLB not rechecked: %v", clusterName, err)
+	} else if err := m.metallbManager.ReconcileNodeIPs(clusterName, ipAddress, clusterIndex, opts.NumClusters, opts.Project); err != nil {
+		logger.Warnf("failed to recheck the MetalLB range of %s against its node IPs: %v", clusterName, err)
+	}
+
+	return reached, nil
+}
+
+// parseNodeNames returns the node names in minikube node list output, where each line is the name and IP of a
+// node and the control plane comes first
+func parseNodeNames(output []byte) []string {
+	var names []string
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
+
+// nodesToRemove returns the nodes to delete to get down to nodeCount, newest first, keeping the control plane
+func nodesToRemove(nodes []string, nodeCount int) []string {
+	if nodeCount < 1 {
+		nodeCount = 1
+	}
+
+	var remove []string
+	for i := len(nodes) - 1; i >= nodeCount; i-- {
+		remove = append(remove, nodes[i])
+	}
+	return remove
+}
+
+// runProfileCommand runs a minikube subcommand against a single profile and captures error output. Extra arguments
+// follow the action, e.g. node add
+func (m *Manager) runProfileCommand(binaryPath, action, clusterName string, extraArgs ...string) error {
+	args := append([]string{action}, extraArgs...)
+	cmd := exec.Command(binaryPath, append(args, "-p", clusterName)...)
+	cmd.Env = k8s.KubeConfigEnv()
+
+	// capture stderr to show actual error messages
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// suppress stdout since spinner provides feedback
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err == nil {
+		cmd.Stdout = devNull
+		defer devNull.Close()
+	} else {
+		cmd.Stdout = logger.GetLogger().Out
+	}
+
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, stderr.String())
+		}
+		return err
+	}
 	return nil
 }
 
@@ -431,6 +941,7 @@ func (m *Manager) deleteCluster(binaryPath, clusterName string, force bool) erro
 		args = append(args, "--purge=true")
 	}
 	cmd := exec.Command(binaryPath, args...)
+	cmd.Env = k8s.KubeConfigEnv()
 
 	// capture stderr to show actual error messages
 	var stderr bytes.Buffer
@@ -471,8 +982,7 @@ func (m *Manager) checkPrerequisites() error {
 	}
 
 	// check minikube version
-	cmd := exec.Command(binaryPath, "version", "--short")
-	output, err := cmd.Output()
+	output, err := utilexec.Output(binaryPath, "version", "--short")
 	if err != nil {
 		return fmt.Errorf("failed to get minikube version: %w", err)
 	}
@@ -491,11 +1001,15 @@ func (m *Manager) checkPrerequisites() error {
 		return m.checkDarwinPrerequisites()
 	}
 
-	return fmt.Errorf("unsupported operating system: %s", config.GetOS())
+	return config.UnsupportedOSError()
 }
 
 // checkLinuxPrerequisites checks Linux-specific prerequisites
 func (m *Manager) checkLinuxPrerequisites() error {
+	if config.IsWSL() {
+		logger.Warnf("⚠️ running inside WSL2, Docker-based kind clusters work but minikube needs KVM and libvirt which WSL2 may not provide. Consider --environment kind")
+	}
+
 	// check KVM support
 	if err := m.checkKVMSupport(); err != nil {
 		return fmt.Errorf("KVM support check failed: %w", err)
@@ -523,8 +1037,7 @@ func (m *Manager) checkDarwinPrerequisites() error {
 // checkKVMSupport checks if KVM is available and loaded
 func (m *Manager) checkKVMSupport() error {
 	// check if KVM modules are loaded
-	cmd := exec.Command("lsmod")
-	output, err := cmd.Output()
+	output, err := utilexec.Output("lsmod")
 	if err != nil {
 		return fmt.Errorf("failed to check loaded modules: %w", err)
 	}
@@ -539,19 +1052,17 @@ func (m *Manager) checkKVMSupport() error {
 // checkLibvirt checks if libvirt is properly installed and running
 func (m *Manager) checkLibvirt() error {
 	// check if virsh is available
-	if err := exec.Command("virsh", "--version").Run(); err != nil {
+	if err := utilexec.Run("virsh", "--version"); err != nil {
 		return fmt.Errorf("virsh not found. Please install libvirt")
 	}
 
 	// check if libvirtd is running
-	cmd := exec.Command("systemctl", "is-active", "--quiet", "libvirtd")
-	if err := cmd.Run(); err != nil {
+	if err := utilexec.Run("systemctl", "is-active", "--quiet", "libvirtd"); err != nil {
 		return fmt.Errorf("libvirtd is not running. Please start it with: systemctl start libvirtd")
 	}
 
 	// check if user is in libvirt group
-	cmd = exec.Command("id", "-nG")
-	output, err := cmd.Output()
+	output, err := utilexec.Output("id", "-nG")
 	if err != nil {
 		return fmt.Errorf("failed to check user groups: %w", err)
 	}
@@ -564,14 +1075,41 @@ func (m *Manager) checkLibvirt() error {
 	return nil
 }
 
+// Doctor runs the minikube prerequisite checks of this OS without installing or provisioning anything
+func (m *Manager) Doctor() []report.Check {
+	var binaryErr error
+	if !m.binaryManager.isBinaryValid() {
+		binaryErr = fmt.Errorf("not downloaded yet, a supported version is downloaded on create")
+	}
+	checks := []report.Check{{Name: "minikube binary", Err: binaryErr, Optional: true}}
+
+	if config.IsLinux() {
+		if config.IsWSL() {
+			checks = append(checks, report.Check{
+				Name:     "native Linux",
+				Err:      fmt.Errorf("running inside WSL2, KVM and libvirt may not be available. Docker-based kind clusters work"),
+				Optional: true,
+			})
+		}
+		return append(checks,
+			report.Check{Name: "KVM support", Err: m.checkKVMSupport()},
+			report.Check{Name: "libvirt running and libvirt group membership", Err: m.checkLibvirt()},
+		)
+	} else if config.IsDarwin() {
+		return append(checks, report.Check{Name: "vfkit version", Err: m.checkVfkitVersion()})
+	}
+
+	return append(checks, report.Check{Name: "operating system", Err: config.UnsupportedOSError()})
+}
+
 // checkVfkitInstalled checks if vfkit is installed and meets minimum version requirements
 func (m *Manager) checkVfkitInstalled() error {
 	// check if vfkit is available
-	if err := exec.Command("vfkit", "--version").Run(); err != nil {
+	if err := utilexec.Run("vfkit", "--version"); err != nil {
 		logger.Infof("vfkit not found, attempting to install via Homebrew...")
 
 		// check if brew is available
-		if err := exec.Command("brew", "--version").Run(); err != nil {
+		if err := utilexec.Run("brew", "--version"); err != nil {
 			return fmt.Errorf("vfkit not found and Homebrew is not available. Please install Homebrew first, then run: 'brew install vfkit'")
 		}
 
@@ -585,9 +1123,13 @@ func (m *Manager) checkVfkitInstalled() error {
 		logger.Infof("✓ vfkit installed successfully via Homebrew")
 	}
 
+	return m.checkVfkitVersion()
+}
+
+// checkVfkitVersion checks the installed vfkit meets the minimum version requirements
+func (m *Manager) checkVfkitVersion() error {
 	// get vfkit version
-	cmd := exec.Command("vfkit", "--version")
-	output, err := cmd.Output()
+	output, err := utilexec.Output("vfkit", "--version")
 	if err != nil {
 		return fmt.Errorf("failed to get vfkit version: %w", err)
 	}
@@ -612,9 +1154,11 @@ func (m *Manager) checkVfkitInstalled() error {
 func (m *Manager) getMinikubeK8sVersion(k8sVersion string) (string, error) {
 	if k8sVersion == "stable" {
 		// get the latest version
-		for _, version := range config.MinikubeK8sVersions {
-			return fmt.Sprintf("v%s", version), nil
+		_, latestVersion, err := config.LatestK8sVersion(config.MinikubeK8sVersions)
+		if err != nil {
+			return "", err
 		}
+		return fmt.Sprintf("v%s", latestVersion), nil
 	}
 
 	// extract minor version (e.g., "1.31" from "1.31.2")
@@ -633,20 +1177,21 @@ func (m *Manager) getMinikubeK8sVersion(k8sVersion string) (string, error) {
 		return fmt.Sprintf("v%s", k8sVersion), nil
 	}
 
-	return "", fmt.Errorf("unsupported Kubernetes version: %s", k8sVersion)
+	return "", fmt.Errorf("unsupported Kubernetes version: %s (supported: stable, %s, or a full MAJOR.MINOR.PATCH version)", k8sVersion, strings.Join(config.SupportedK8sVersions(config.MinikubeK8sVersions), ", "))
 }
 
 // setupNetworkAndDriver sets up networking and determines the appropriate driver
 // Returns: NetworkManager, driver, error
-func (m *Manager) setupNetworkAndDriver(project, bridge, subnetCIDR string) (NetworkManager, string, error) {
+func (m *Manager) setupNetworkAndDriver(project, bridge, subnetCIDR string, subnetSearchLimit int) (NetworkManager, string, error) {
 	if config.IsLinux() {
 		// create libvirt network
 		networkName := fmt.Sprintf("%s-net", project)
 		libvirtNet := &network.Network{
-			Name:          networkName,
-			Bridge:        bridge,
-			Subnet:        subnetCIDR,
-			ConnectionURI: config.MinikubeQemuSystem,
+			Name:              networkName,
+			Bridge:            bridge,
+			Subnet:            subnetCIDR,
+			ConnectionURI:     config.MinikubeQemuSystem,
+			SubnetSearchLimit: subnetSearchLimit,
 		}
 
 		var networkManager NetworkManager = libvirtNet
@@ -662,11 +1207,11 @@ func (m *Manager) setupNetworkAndDriver(project, bridge, subnetCIDR string) (Net
 		return vmnetManager, "vfkit", nil
 	}
 
-	return nil, "", fmt.Errorf("unsupported operating system: %s", config.GetOS())
+	return nil, "", config.UnsupportedOSError()
 }
 
 // createCluster creates a single minikube cluster
-func (m *Manager) createCluster(clusterName, k8sVersion, driver, cpu, memory, disk, networkName, cni, containerRuntime string, nodeCount, clusterIndex int, verbose bool) error {
+func (m *Manager) createCluster(ctx context.Context, clusterName, k8sVersion, driver, cpu, memory, disk, networkName, cni, containerRuntime, mount string, nodeCount, clusterIndex int, ha bool, apiServerVIP string, verbose bool) error {
 	// set environment variable to disable styling
 	os.Setenv("MINIKUBE_IN_STYLE", "false")
 
@@ -676,28 +1221,69 @@ func (m *Manager) createCluster(clusterName, k8sVersion, driver, cpu, memory, di
 		return fmt.Errorf("failed to get minikube binary path: %w", err)
 	}
 
-	region := getRegion(clusterIndex - 1)
-	zone := getZone(clusterIndex - 1)
-
 	// determine the actual CNI to use for minikube
 	minikubeCNI := cni
 	if cni == "cilium" {
 		// generate Cilium manifest file from helm chart
-		manifestPath, err := m.ciliumManager.GenerateCiliumManifest(clusterName)
+		manifestPath, err := m.ciliumManager.GenerateCiliumManifest(clusterName, clusterIndex)
 		if err != nil {
 			return fmt.Errorf("failed to generate Cilium manifest: %w", err)
 		}
 		// use the manifest file path for --cni flag
 		minikubeCNI = manifestPath
+	} else if cni == "flannel" {
+		// the upstream manifest already matches the default minikube pod network
+		manifestPath, err := m.flannelManager.GenerateFlannelManifest(clusterName, "")
+		if err != nil {
+			return fmt.Errorf("failed to generate Flannel manifest: %w", err)
+		}
+		minikubeCNI = manifestPath
+	} else if cni == "none" {
+		// the user brings their own CNI once the cluster is up
+		minikubeCNI = "false"
 	}
 
+	args := buildStartArgs(clusterName, k8sVersion, driver, cpu, memory, disk, networkName, minikubeCNI, containerRuntime, mount, nodeCount, clusterIndex, ha, apiServerVIP, verbose)
+
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("creating Minikube cluster %s", clusterName))
+
+	// a cancelled create kills minikube, the partial profile is left for the cleanup
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
+	cmd.Env = k8s.KubeConfigEnv()
+	// Redirect minikube output through the logger so it properly clears the spinner line
+	cmd.Stdout = logger.GetLogger().Out
+	cmd.Stderr = logger.GetLogger().Out
+
+	if err := cmd.Run(); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to start minikube cluster: %w", err)
+	}
+
+	// wait for all nodes to be ready, without a CNI they stay NotReady until the user applies one
+	if cni == "none" {
+		logger.Infof("no CNI installed on %s, its nodes stay NotReady until one is applied", clusterName)
+	} else if err := m.waitForNodesReady(clusterName); err != nil {
+		status.End(false)
+		return fmt.Errorf("nodes not ready: %w", err)
+	}
+	status.End(true)
+
+	return nil
+}
+
+// buildStartArgs assembles the minikube start arguments for a single cluster
+func buildStartArgs(clusterName, k8sVersion, driver, cpu, memory, disk, networkName, cni, containerRuntime, mount string, nodeCount, clusterIndex int, ha bool, apiServerVIP string, verbose bool) []string {
+	region := getRegion(clusterIndex - 1)
+	zone := getZone(clusterIndex - 1)
+
 	args := []string{
 		"start",
 		"-p", clusterName,
 		"--kubernetes-version=" + k8sVersion,
 		"--driver=" + driver,
 		"--container-runtime=" + containerRuntime,
-		"--cni=" + minikubeCNI,
+		"--cni=" + cni,
 		"--cpus=" + cpu,
 		"--memory=" + memory,
 		"--disk-size=" + disk,
@@ -707,32 +1293,76 @@ func (m *Manager) createCluster(clusterName, k8sVersion, driver, cpu, memory, di
 		"--extra-config=kubelet.node-labels=topology.kubernetes.io/region=" + region + ",topology.kubernetes.io/zone=" + zone,
 	}
 
+	if mount != "" {
+		args = append(args, "--mount", "--mount-string="+mount)
+	}
+
+	// the VIP reserved in the network is added to the API server certificate of the control planes
+	if ha {
+		args = append(args, "--ha")
+		if apiServerVIP != "" {
+			args = append(args, "--apiserver-ips="+apiServerVIP)
+		}
+	}
+
 	// add verbose flag if requested
 	if verbose {
 		args = append(args, "--alsologtostderr")
 		args = append(args, "--v=7")
 	}
 
-	status := logger.NewStatus()
-	status.Start(fmt.Sprintf("creating Minikube cluster %s", clusterName))
+	return args
+}
 
-	cmd := exec.Command(binaryPath, args...)
-	// Redirect minikube output through the logger so it properly clears the spinner line
-	cmd.Stdout = logger.GetLogger().Out
-	cmd.Stderr = logger.GetLogger().Out
+// mountString converts the mount to the host:guest form of minikube's --mount-string, with an absolute host path
+func mountString(mounts []string) string {
+	if len(mounts) == 0 {
+		return ""
+	}
+	// mounts are validated before any cluster is created
+	hostPath, containerPath, _ := config.ParseMount(mounts[0])
+	return hostPath + ":" + containerPath
+}
 
-	if err := cmd.Run(); err != nil {
-		status.End(false)
-		return fmt.Errorf("failed to start minikube cluster: %w", err)
+// dryRunCreate prints the minikube start arguments for each cluster without provisioning anything
+func (m *Manager) dryRunCreate(opts *CreateOptions) error {
+	k8sVersion, err := m.getMinikubeK8sVersion(opts.K8sVersion)
+	if err != nil {
+		return fmt.Errorf("failed to get Kubernetes version: %w", err)
 	}
 
-	// wait for all nodes to be ready
-	if err := m.waitForNodesReady(clusterName); err != nil {
-		status.End(false)
-		return fmt.Errorf("nodes not ready: %w", err)
+	// only derive the network name and driver, the network itself is not created
+	networkManager, driver, err := m.setupNetworkAndDriver(opts.Project, opts.Bridge, opts.SubnetCIDR, opts.SubnetSearchLimit)
+	if err != nil {
+		return fmt.Errorf("failed to setup network and driver: %w", err)
 	}
-	status.End(true)
+	var networkName string
+	if net, ok := networkManager.(*network.Network); ok {
+		networkName = net.Name
+	}
+
+	for i := 1; i <= opts.NumClusters; i++ {
+		var clusterName string
+		if opts.NumClusters == 1 {
+			// if only one cluster, don't add suffix
+			clusterName = opts.Project
+		} else {
+			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
+		}
+
+		// cilium and flannel manifests are generated at creation time, calico is minikube's built-in CNI
+		minikubeCNI := opts.CNI
+		if opts.CNI == "cilium" || opts.CNI == "flannel" {
+			minikubeCNI = fmt.Sprintf("<%s-%s-manifest.yaml>", opts.CNI, clusterName)
+		} else if opts.CNI == "none" {
+			minikubeCNI = "false"
+		}
 
+		args := buildStartArgs(clusterName, k8sVersion, driver, opts.CPU, opts.Memory, opts.Disk, networkName, minikubeCNI, opts.ContainerRuntime, mountString(opts.Mounts), opts.NodeCount, i, opts.HA, opts.APIServerVIP, opts.Verbose)
+		fmt.Printf("# cluster %s (%d/%d)\nminikube %s\n\n", clusterName, i, opts.NumClusters, strings.Join(args, " "))
+	}
+
+	logger.Infof("dry run complete, no clusters were created")
 	return nil
 }
 
@@ -751,9 +1381,7 @@ func (m *Manager) waitForNodesReady(clusterName string) error {
 		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
 	}
 
-	// wait for nodes to be ready with 5 minute timeout
-	timeout := 5 * time.Minute
-	if err := clientManager.WaitForNodesReady(timeout); err != nil {
+	if err := clientManager.WaitForNodesReady(m.waitTimeout); err != nil {
 		status.End(false)
 		return err
 	}
@@ -790,14 +1418,65 @@ func (m *Manager) showProfileList() error {
 	return nil
 }
 
+// ProfileNames returns the names of the existing minikube profiles. The minikube binary isn't downloaded for this,
+// so it fails when no supported binary is available yet
+func (m *Manager) ProfileNames() ([]string, error) {
+	if !m.binaryManager.isBinaryValid() {
+		return nil, fmt.Errorf("minikube binary not available")
+	}
+
+	output, err := utilexec.Output(m.binaryManager.binaryPath, "profile", "list", "-o", "json")
+	if err != nil {
+		// exit code 14 (MK_USAGE_NO_PROFILE) means there are no profiles
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && exitError.ExitCode() == 14 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list minikube profiles: %w", err)
+	}
+
+	return parseProfileNames(output)
+}
+
+// parseProfileNames returns the names of the valid and invalid profiles in minikube profile list -o json output
+func parseProfileNames(output []byte) ([]string, error) {
+	var profiles struct {
+		Valid   []struct{ Name string } `json:"valid"`
+		Invalid []struct{ Name string } `json:"invalid"`
+	}
+	if err := json.Unmarshal(output, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse minikube profiles: %w", err)
+	}
+
+	var names []string
+	for _, profile := range append(profiles.Valid, profiles.Invalid...) {
+		names = append(names, profile.Name)
+	}
+	return names, nil
+}
+
+// DeleteProjectNetwork deletes the libvirt network created for a project. It does nothing on macOS, where
+// the vmnet network is shared by every project
+func (m *Manager) DeleteProjectNetwork(project string) error {
+	if !config.IsLinux() {
+		return nil
+	}
+
+	libvirtNet := &network.Network{
+		Name:          fmt.Sprintf("%s-net", project),
+		ConnectionURI: config.MinikubeQemuSystem,
+	}
+	return libvirtNet.DeleteNetwork(false)
+}
+
 // ListProfiles lists all minikube profiles
 func (m *Manager) ListProfiles() error {
 	return m.showProfileList()
 }
 
-// LoadImage loads a Docker image into minikube clusters
+// LoadImage loads Docker images into minikube clusters
 func (m *Manager) LoadImage(opts *LoadImageOptions) error {
-	logger.Infof("-----> 📦 loading image %s into %d Minikube cluster(s) for project %s <-----", opts.Image, opts.NumClusters, opts.Project)
+	logger.Infof("-----> 📦 loading %d image(s) into %d Minikube cluster(s) for project %s <-----", len(opts.Images), opts.NumClusters, opts.Project)
 
 	// ensure minikube binary is available
 	if err := m.binaryManager.EnsureBinary(); err != nil {
@@ -809,6 +1488,16 @@ func (m *Manager) LoadImage(opts *LoadImageOptions) error {
 		return fmt.Errorf("failed to get minikube binary path: %w", err)
 	}
 
+	// validate archives before iterating clusters, minikube loads both archives and image references the same way
+	for _, image := range opts.Images {
+		if strings.HasSuffix(image, ".tar") && !docker.IsImageArchive(image) {
+			return fmt.Errorf("image archive %s does not exist", image)
+		}
+	}
+
+	// track clusters each image failed to load into for the summary
+	failures := make(map[string][]string)
+
 	for i := 1; i <= opts.NumClusters; i++ {
 		var clusterName string
 		if opts.NumClusters == 1 {
@@ -818,30 +1507,46 @@ func (m *Manager) LoadImage(opts *LoadImageOptions) error {
 			clusterName = fmt.Sprintf("%s-%d", opts.Project, i)
 		}
 
-		status := logger.NewStatus()
-		status.Start(fmt.Sprintf("loading image %s into cluster %s (%d/%d)", opts.Image, clusterName, i, opts.NumClusters))
+		for _, image := range opts.Images {
+			status := logger.NewStatus()
+			status.Start(fmt.Sprintf("loading image %s into cluster %s (%d/%d)", image, clusterName, i, opts.NumClusters))
 
-		cmd := exec.Command(binaryPath, "image", "load", opts.Image, "-p", clusterName)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+			cmd := exec.Command(binaryPath, "image", "load", image, "-p", clusterName)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
 
-		if err := cmd.Run(); err != nil {
-			status.End(false)
-			return fmt.Errorf("failed to load image %s into cluster %s: %w", opts.Image, clusterName, err)
+			if err := cmd.Run(); err != nil {
+				status.End(false)
+				logger.Errorf("failed to load image %s into cluster %s: %v", image, clusterName, err)
+				failures[image] = append(failures[image], clusterName)
+				continue
+			}
+
+			status.End(true)
+			logger.Infof("✓ successfully loaded image %s into cluster %s", image, clusterName)
 		}
+	}
 
-		status.End(true)
-		logger.Infof("✓ successfully loaded image %s into cluster %s", opts.Image, clusterName)
+	logger.Infof("📦 image load summary:")
+	for _, image := range opts.Images {
+		if failed, ok := failures[image]; ok {
+			logger.Errorf("  ✗ %s failed on %d/%d cluster(s): %s", image, len(failed), opts.NumClusters, strings.Join(failed, ", "))
+		} else {
+			logger.Infof("  ✓ %s loaded into %d Minikube cluster(s)", image, opts.NumClusters)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to load %d of %d image(s)", len(failures), len(opts.Images))
 	}
 
-	logger.Infof("🎉 successfully loaded image %s into %d Minikube cluster(s)", opts.Image, opts.NumClusters)
+	logger.Infof("🎉 successfully loaded %d image(s) into %d Minikube cluster(s)", len(opts.Images), opts.NumClusters)
 	return nil
 }
 
 // getMinikubeIP gets the IP address of a minikube cluster
 func (m *Manager) getMinikubeIP(clusterName string) (string, error) {
-	cmd := exec.Command("minikube", "ip", "-p", clusterName)
-	output, err := cmd.Output()
+	output, err := utilexec.Output("minikube", "ip", "-p", clusterName)
 	if err != nil {
 		return "", fmt.Errorf("failed to get minikube IP for cluster %s: %w", clusterName, err)
 	}
@@ -914,29 +1619,28 @@ func (m *Manager) enableCSI(clusterName string) error {
 		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
 	}
 
-	// get storageclass
-	storageClass, err := clientManager.GetClientset().StorageV1().StorageClasses().Get(context.Background(), "csi-hostpath-sc", metav1.GetOptions{})
-	if err != nil {
+	// make the csi storageclass the default
+	if err := clientManager.SetDefaultStorageClass(config.MinikubeCSIStorageClass); err != nil {
 		status.End(false)
-		return fmt.Errorf("failed to get storageclass csi-hostpath-sc: %w", err)
-	}
-
-	// patch storageclass annotations
-	if storageClass.Annotations == nil {
-		storageClass.Annotations = make(map[string]string)
-	}
-	storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] = "true"
-
-	_, err = clientManager.GetClientset().StorageV1().StorageClasses().Update(context.Background(), storageClass, metav1.UpdateOptions{})
-	if err != nil {
-		status.End(false)
-		return fmt.Errorf("failed to patch storageclass csi-hostpath-sc: %w", err)
+		return err
 	}
 
 	logger.Debugf("✓ successfully enabled CSI support for cluster %s", clusterName)
 	return nil
 }
 
+// addonReleases returns the releases with value overrides that minikube installs itself, metrics-server as an addon
+// and calico as its built-in CNI, so the overrides never reach them
+func addonReleases(overrides map[string]map[string]interface{}) []string {
+	var releases []string
+	for _, release := range []string{"calico", "metrics-server"} {
+		if _, ok := overrides[release]; ok {
+			releases = append(releases, release)
+		}
+	}
+	return releases
+}
+
 // enableMetricsServer enables the metrics-server addon for a minikube cluster
 func (m *Manager) enableMetricsServer(clusterName string) error {
 	logger.Debugf("enabling metrics-server addon for cluster %s", clusterName)
@@ -0,0 +1,47 @@
+package minikube
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scaling nodes", func() {
+	Describe("parseNodeNames", func() {
+		It("should return the node names in order with the control plane first", func() {
+			output := []byte("myproject-1\t192.168.49.2\nmyproject-1-m02\t192.168.49.3\nmyproject-1-m03\t192.168.49.4\n")
+			Expect(parseNodeNames(output)).To(Equal([]string{"myproject-1", "myproject-1-m02", "myproject-1-m03"}))
+		})
+
+		It("should skip blank lines and surrounding whitespace", func() {
+			output := []byte("\n  myproject-1   192.168.49.2  \n\nmyproject-1-m02 192.168.49.3\n")
+			Expect(parseNodeNames(output)).To(Equal([]string{"myproject-1", "myproject-1-m02"}))
+		})
+
+		It("should return nothing for empty output", func() {
+			Expect(parseNodeNames(nil)).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("nodesToRemove",
+		func(nodeCount int, expected []string) {
+			nodes := []string{"myproject-1", "myproject-1-m02", "myproject-1-m03"}
+			Expect(nodesToRemove(nodes, nodeCount)).To(Equal(expected))
+		},
+		Entry("keeps every node at the current count", 3, nil),
+		Entry("keeps every node when growing", 5, nil),
+		Entry("removes the newest worker first", 2, []string{"myproject-1-m03"}),
+		Entry("removes every worker down to the control plane", 1, []string{"myproject-1-m03", "myproject-1-m02"}),
+		Entry("never removes the control plane", 0, []string{"myproject-1-m03", "myproject-1-m02"}),
+	)
+})
+
+var _ = Describe("Helm value overrides", func() {
+	It("should find the overrides of the add-ons minikube installs itself", func() {
+		Expect(addonReleases(map[string]map[string]interface{}{
+			"metallb":        {"speaker": map[string]interface{}{"frr": map[string]interface{}{"enabled": true}}},
+			"metrics-server": {"replicas": 2},
+			"calico":         {"installation": map[string]interface{}{}},
+		})).To(Equal([]string{"calico", "metrics-server"}))
+		Expect(addonReleases(map[string]map[string]interface{}{"cilium": {"hubble": true}})).To(BeEmpty())
+	})
+})
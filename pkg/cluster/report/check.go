@@ -0,0 +1,52 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// Check is the result of a single prerequisite check run by the doctor command
+type Check struct {
+	Name     string
+	Err      error // nil when the check passed
+	Optional bool  // a failed optional check is only a warning
+}
+
+// PrintChecks writes a pass/fail line per check to w and returns how many hard prerequisites failed
+func PrintChecks(w io.Writer, checks []Check) int {
+	failed := 0
+	for _, check := range checks {
+		switch {
+		case check.Err == nil:
+			fmt.Fprintf(w, "  ✅ %s\n", check.Name)
+		case check.Optional:
+			fmt.Fprintf(w, "  ⚠️ %s: %v\n", check.Name, check.Err)
+		default:
+			fmt.Fprintf(w, "  ❌ %s: %v\n", check.Name, check.Err)
+			failed++
+		}
+	}
+	return failed
+}
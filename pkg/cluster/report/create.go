@@ -0,0 +1,123 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// StepTiming is how long a single provisioning step of a cluster took
+type StepTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// AddonError is an add-on that failed on a cluster that was otherwise created
+type AddonError struct {
+	Name string
+	Err  error
+}
+
+// CreateResult is the outcome of creating a single cluster, shown in the create summary
+type CreateResult struct {
+	Cluster      string
+	CNI          string
+	LoadBalancer string // empty when no load balancer was installed
+	Duration     time.Duration
+	Steps        []StepTiming
+	Err          error
+	Created      bool         // the cluster was (re)created by this run, so a failed create may have left it behind
+	AddonErrs    []AddonError // add-ons that failed, the cluster itself is still usable
+}
+
+// AddStep records a provisioning step that started at the given time and just finished
+func (r *CreateResult) AddStep(name string, start time.Time) {
+	r.Steps = append(r.Steps, StepTiming{Name: name, Duration: time.Since(start)})
+}
+
+// AddAddonError records an add-on that failed to install or configure
+func (r *CreateResult) AddAddonError(name string, err error) {
+	r.AddonErrs = append(r.AddonErrs, AddonError{Name: name, Err: err})
+}
+
+// AddonsFailed reports whether an add-on failed on any of the clusters
+func AddonsFailed(results []CreateResult) bool {
+	for _, result := range results {
+		if len(result.AddonErrs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintCreateSummary writes a table of the created clusters, how long each one and its steps took, to w, followed by
+// the add-ons that failed. Clusters that were never attempted are left out
+func PrintCreateSummary(w io.Writer, results []CreateResult, total time.Duration) {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CLUSTER\tCNI\tLB\tDURATION\tRESULT\tSTEPS")
+	fmt.Fprintln(tw, "-------\t---\t--\t--------\t------\t-----")
+
+	for _, result := range results {
+		if result.Cluster == "" {
+			continue
+		}
+
+		lb := result.LoadBalancer
+		if lb == "" {
+			lb = "none"
+		}
+		outcome := "✅ created"
+		if result.Err != nil {
+			outcome = "❌ failed"
+		} else if len(result.AddonErrs) > 0 {
+			names := make([]string, 0, len(result.AddonErrs))
+			for _, addonErr := range result.AddonErrs {
+				names = append(names, addonErr.Name)
+			}
+			outcome = fmt.Sprintf("⚠️ created, %s failed", strings.Join(names, ", "))
+		}
+		steps := make([]string, 0, len(result.Steps))
+		for _, step := range result.Steps {
+			steps = append(steps, fmt.Sprintf("%s %s", step.Name, formatDuration(step.Duration)))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", result.Cluster, result.CNI, lb, formatDuration(result.Duration), outcome, strings.Join(steps, ", "))
+	}
+	tw.Flush()
+
+	for _, result := range results {
+		for _, addonErr := range result.AddonErrs {
+			fmt.Fprintf(w, "%s: %s failed: %v\n", result.Cluster, addonErr.Name, addonErr.Err)
+		}
+	}
+	fmt.Fprintf(w, "total: %s\n", formatDuration(total))
+}
+
+// formatDuration rounds a duration to the second, anything shorter shows as 0s
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
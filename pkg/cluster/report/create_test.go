@@ -0,0 +1,53 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrintCreateSummary", func() {
+	It("should show every attempted cluster with its steps", func() {
+		results := []CreateResult{
+			{
+				Cluster:      "kind1",
+				CNI:          "cilium",
+				LoadBalancer: "metallb",
+				Duration:     95 * time.Second,
+				Steps:        []StepTiming{{Name: "cluster", Duration: 61 * time.Second}, {Name: "cilium", Duration: 34 * time.Second}},
+			},
+			{Cluster: "kind2", CNI: "cilium", Duration: 1500 * time.Millisecond, Err: errors.New("boom")},
+			{},
+		}
+
+		var out bytes.Buffer
+		PrintCreateSummary(&out, results, 97*time.Second)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		Expect(lines).To(HaveLen(5))
+		Expect(string(lines[2])).To(MatchRegexp(`^kind1\s+cilium\s+metallb\s+1m35s\s+✅ created\s+cluster 1m1s, cilium 34s$`))
+		Expect(string(lines[3])).To(MatchRegexp(`^kind2\s+cilium\s+none\s+2s\s+❌ failed`))
+		Expect(string(lines[4])).To(Equal("total: 1m37s"))
+	})
+
+	It("should flag clusters whose add-ons failed", func() {
+		result := CreateResult{Cluster: "kind1", CNI: "cilium", LoadBalancer: "metallb", Duration: 90 * time.Second}
+		result.AddAddonError("cilium", errors.New("timeout waiting for Cilium to be ready"))
+		result.AddAddonError("metrics-server", errors.New("chart not found"))
+
+		var out bytes.Buffer
+		PrintCreateSummary(&out, []CreateResult{result}, 90*time.Second)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		Expect(lines).To(HaveLen(6))
+		Expect(string(lines[2])).To(MatchRegexp(`^kind1\s+cilium\s+metallb\s+1m30s\s+⚠️ created, cilium, metrics-server failed`))
+		Expect(string(lines[3])).To(Equal("kind1: cilium failed: timeout waiting for Cilium to be ready"))
+		Expect(string(lines[4])).To(Equal("kind1: metrics-server failed: chart not found"))
+		Expect(string(lines[5])).To(Equal("total: 1m30s"))
+		Expect(AddonsFailed([]CreateResult{result})).To(BeTrue())
+		Expect(AddonsFailed([]CreateResult{{Cluster: "kind2"}})).To(BeFalse())
+	})
+})
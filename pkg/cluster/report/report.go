@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// FormatTable renders statuses as a human readable table
+	FormatTable = "table"
+	// FormatJSON renders statuses as a JSON array
+	FormatJSON = "json"
+)
+
+// ClusterStatus is the status of a single cluster, shared by the table and JSON renderers
+type ClusterStatus struct {
+	Cluster   string `json:"cluster"`
+	Context   string `json:"context"`
+	Status    string `json:"status"`
+	IP        string `json:"ip"`
+	Nodes     string `json:"nodes"` // ready/total
+	Version   string `json:"version"`
+	Host      string `json:"host"`
+	Kubelet   string `json:"kubelet"`
+	APIServer string `json:"apiServer"`
+}
+
+// ValidateFormat returns an error if the output format is not supported
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatTable, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid output format: %s. Valid options are: %s, %s", format, FormatTable, FormatJSON)
+}
+
+// PrintJSON writes the statuses to w as an indented JSON array
+func PrintJSON(w io.Writer, statuses []ClusterStatus) error {
+	// always emit an array, even when there are no clusters
+	if statuses == nil {
+		statuses = []ClusterStatus{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(statuses); err != nil {
+		return fmt.Errorf("failed to encode status as JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// addonsCmd manages minikube addons for every cluster in a project
+func addonsCmd() *cobra.Command {
+	var project string
+
+	cmd := &cobra.Command{
+		Use:   "addons",
+		Short: "Manage Minikube addons for a project",
+		Long: `Enable, disable or list Minikube addons (e.g. ingress, registry, dashboard) on every
+cluster in a project. Only supported for the Minikube environment.`,
+	}
+
+	// runAddons loads the project and runs the addons action against each of its clusters
+	runAddons := func(action, addon string) error {
+		// check if running as sudo/root
+		if syscall.Geteuid() == 0 {
+			return fmt.Errorf("addons command must not be run as sudo/root")
+		}
+
+		if project == "" {
+			return fmt.Errorf("project name is required")
+		}
+
+		savedConfig, err := configManager.LoadConfig(project)
+		if err != nil {
+			return fmt.Errorf("failed to load project config: %w", err)
+		}
+		if savedConfig == nil {
+			return fmt.Errorf("project %s not found", project)
+		}
+		if savedConfig.Environment != "minikube" {
+			return fmt.Errorf("project %s is not configured for minikube environment", project)
+		}
+
+		manager := minikube.NewManager()
+		return manager.ManageAddons(&minikube.AddonsOptions{
+			Project:     project,
+			NumClusters: savedConfig.NumClusters,
+			Action:      action,
+			Addon:       addon,
+		})
+	}
+
+	// enable command
+	enableCmd := &cobra.Command{
+		Use:   "enable [addon]",
+		Short: "Enable an addon on every cluster in the project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAddons("enable", args[0])
+		},
+	}
+
+	// disable command
+	disableCmd := &cobra.Command{
+		Use:   "disable [addon]",
+		Short: "Disable an addon on every cluster in the project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAddons("disable", args[0])
+		},
+	}
+
+	// list command
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the addons and their status for every cluster in the project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAddons("list", "")
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	if err := cmd.MarkPersistentFlagRequired("project"); err != nil {
+		logger.Warnf("failed to mark project flag as required: %v", err)
+	}
+
+	cmd.AddCommand(enableCmd)
+	cmd.AddCommand(disableCmd)
+	cmd.AddCommand(listCmd)
+
+	return cmd
+}
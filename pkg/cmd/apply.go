@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// bootstrapManifest is a manifest given with --apply, read before any cluster is created
+type bootstrapManifest struct {
+	source   string
+	manifest string
+}
+
+// readBootstrapManifests reads every --apply source up front so a bad path or URL fails before provisioning
+func readBootstrapManifests(sources []string) ([]bootstrapManifest, error) {
+	manifests := make([]bootstrapManifest, 0, len(sources))
+	for _, source := range sources {
+		manifest, err := k8s.ReadManifests(source)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, bootstrapManifest{source: source, manifest: manifest})
+	}
+	return manifests, nil
+}
+
+// applyBootstrapManifests applies the manifests, in the order given, to each cluster and reports the applied
+// resources. A failing cluster doesn't stop the manifests being applied to the others
+func applyBootstrapManifests(contexts []string, manifests []bootstrapManifest) error {
+	if len(manifests) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, contextName := range contexts {
+		if err := applyBootstrapManifestsToCluster(contextName, manifests); err != nil {
+			logger.Errorf("❌ failed to apply manifests to cluster %s: %v", contextName, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyBootstrapManifestsToCluster applies the manifests to a single cluster
+func applyBootstrapManifestsToCluster(contextName string, manifests []bootstrapManifest) error {
+	clientManager, err := k8s.NewClientManagerForContext(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+	}
+
+	logger.Infof("📦 applying manifests to cluster %s", contextName)
+	for _, m := range manifests {
+		applied, err := clientManager.ApplyManifestResources(m.manifest)
+		for _, resource := range applied {
+			logger.Infof("  ✓ %s", resource)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply %s: %w", m.source, err)
+		}
+	}
+
+	return nil
+}
@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 
 	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/network"
+	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util/docker"
 )
 
 var _ = Describe("Cmd", func() {
@@ -29,8 +38,18 @@ var _ = Describe("Cmd", func() {
 
 				Expect(commandNames).To(ContainElement("create"))
 				Expect(commandNames).To(ContainElement("delete"))
+				Expect(commandNames).To(ContainElement("start"))
+				Expect(commandNames).To(ContainElement("stop"))
+				Expect(commandNames).To(ContainElement("scale"))
 				Expect(commandNames).To(ContainElement("config"))
 				Expect(commandNames).To(ContainElement("version"))
+				Expect(commandNames).To(ContainElement("kubeconfig"))
+				Expect(commandNames).To(ContainElement("kubectl"))
+				Expect(commandNames).To(ContainElement("versions"))
+				Expect(commandNames).To(ContainElement("reset"))
+				Expect(commandNames).To(ContainElement("doctor"))
+				Expect(commandNames).To(ContainElement("prune"))
+				Expect(commandNames).To(ContainElement("network"))
 			})
 
 			It("should have correct persistent flags", func() {
@@ -47,6 +66,33 @@ var _ = Describe("Cmd", func() {
 				environmentFlag := flags.Lookup("environment")
 				Expect(environmentFlag).NotTo(BeNil())
 				Expect(environmentFlag.Usage).To(ContainSubstring("environment to use"))
+
+				// only create can preview its changes
+				Expect(flags.Lookup("dry-run")).To(BeNil())
+
+				kubeconfigFlag := flags.Lookup("kubeconfig")
+				Expect(kubeconfigFlag).NotTo(BeNil())
+				Expect(kubeconfigFlag.DefValue).To(Equal(""))
+
+				logFormatFlag := flags.Lookup("log-format")
+				Expect(logFormatFlag).NotTo(BeNil())
+				Expect(logFormatFlag.DefValue).To(Equal("text"))
+
+				quietFlag := flags.Lookup("quiet")
+				Expect(quietFlag).NotTo(BeNil())
+				Expect(quietFlag.Shorthand).To(Equal("q"))
+			})
+
+			It("should reject an unknown log format and verbose with quiet", func() {
+				DeferCleanup(func() {
+					logFormat, verbose, quiet = "text", false, false
+				})
+
+				logFormat = "xml"
+				Expect(initializeConfig()).To(MatchError(ContainSubstring("invalid log format: xml")))
+
+				logFormat, verbose, quiet = "text", true, true
+				Expect(initializeConfig()).To(MatchError("--verbose can't be combined with --quiet"))
 			})
 		})
 
@@ -91,6 +137,7 @@ var _ = Describe("Cmd", func() {
 				// These are set during init()
 				Expect(cfgFile).To(Equal(""))
 				Expect(verbose).To(BeFalse())
+				Expect(dryRun).To(BeFalse())
 				Expect(environment).To(Equal("minikube"))
 			})
 		})
@@ -110,6 +157,29 @@ var _ = Describe("Cmd", func() {
 				Expect(createCommand.Long).To(ContainSubstring("networking and MetalLB support"))
 			})
 
+			It("should reject creating no clusters", func() {
+				Expect(createCommand.ParseFlags([]string{"-p", "myproject", "-n", "0"})).To(Succeed())
+				numClusters, err := createCommand.Flags().GetInt("num")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(validateClusterCount(numClusters)).To(MatchError(fmt.Sprintf("number of clusters must be between 1 and %d, got 0", config.MaxClusterNum)))
+
+				Expect(validateClusterCount(config.MaxClusterNum + 1)).To(HaveOccurred())
+				Expect(validateClusterCount(1)).To(Succeed())
+				Expect(validateClusterCount(config.MaxClusterNum)).To(Succeed())
+			})
+
+			It("should save the host side of mounts as absolute paths", func() {
+				cwd, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+
+				mounts, err := absoluteMounts([]string{"data:/data", "/srv/cache:/cache"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mounts).To(Equal([]string{filepath.Join(cwd, "data") + ":/data", "/srv/cache:/cache"}))
+
+				_, err = absoluteMounts([]string{"data:relative"})
+				Expect(err).To(HaveOccurred())
+			})
+
 			It("should have all required flags", func() {
 				flags := createCommand.Flags()
 
@@ -119,6 +189,10 @@ var _ = Describe("Cmd", func() {
 				Expect(projectFlag.Usage).To(ContainSubstring("Project name"))
 
 				// Optional flags
+				dryRunFlag := flags.Lookup("dry-run")
+				Expect(dryRunFlag).NotTo(BeNil())
+				Expect(dryRunFlag.Usage).To(ContainSubstring("without provisioning"))
+
 				bridgeFlag := flags.Lookup("bridge")
 				Expect(bridgeFlag).NotTo(BeNil())
 				Expect(bridgeFlag.Usage).To(ContainSubstring("Bridge name"))
@@ -130,10 +204,13 @@ var _ = Describe("Cmd", func() {
 				cpuFlag := flags.Lookup("cpu")
 				Expect(cpuFlag).NotTo(BeNil())
 				Expect(cpuFlag.Usage).To(ContainSubstring("Number of CPUs"))
+				// left empty so kind nodes are only limited when a cpu is given, minikube falls back to its default
+				Expect(cpuFlag.DefValue).To(BeEmpty())
 
 				memoryFlag := flags.Lookup("memory")
 				Expect(memoryFlag).NotTo(BeNil())
 				Expect(memoryFlag.Usage).To(ContainSubstring("Amount of memory"))
+				Expect(memoryFlag.DefValue).To(BeEmpty())
 
 				diskFlag := flags.Lookup("disk")
 				Expect(diskFlag).NotTo(BeNil())
@@ -151,10 +228,54 @@ var _ = Describe("Cmd", func() {
 				Expect(nodesFlag).NotTo(BeNil())
 				Expect(nodesFlag.Usage).To(ContainSubstring("Number of worker nodes"))
 
+				haFlag := flags.Lookup("ha")
+				Expect(haFlag).NotTo(BeNil())
+				Expect(haFlag.DefValue).To(Equal("false"))
+
 				k8sVersionFlag := flags.Lookup("kubernetes-version")
 				Expect(k8sVersionFlag).NotTo(BeNil())
 				Expect(k8sVersionFlag.Usage).To(ContainSubstring("Kubernetes version"))
 
+				nodeImageFlag := flags.Lookup("node-image")
+				Expect(nodeImageFlag).NotTo(BeNil())
+				Expect(nodeImageFlag.DefValue).To(Equal(""))
+
+				enableCSIFlag := flags.Lookup("enable-csi")
+				Expect(enableCSIFlag).NotTo(BeNil())
+				Expect(enableCSIFlag.DefValue).To(Equal("true"))
+
+				enableMetricsFlag := flags.Lookup("enable-metrics-server")
+				Expect(enableMetricsFlag).NotTo(BeNil())
+				Expect(enableMetricsFlag.DefValue).To(Equal("true"))
+
+				waitTimeoutFlag := flags.Lookup("wait-timeout")
+				Expect(waitTimeoutFlag).NotTo(BeNil())
+				Expect(waitTimeoutFlag.DefValue).To(Equal("5m0s"))
+
+				artifactsDirFlag := flags.Lookup("artifacts-dir")
+				Expect(artifactsDirFlag).NotTo(BeNil())
+				Expect(artifactsDirFlag.DefValue).To(Equal(""))
+
+				timeoutFlag := flags.Lookup("timeout")
+				Expect(timeoutFlag).NotTo(BeNil())
+				Expect(timeoutFlag.DefValue).To(Equal("0s"))
+
+				cleanupFlag := flags.Lookup("cleanup-on-failure")
+				Expect(cleanupFlag).NotTo(BeNil())
+				Expect(cleanupFlag.DefValue).To(Equal("false"))
+
+				prefetchImageFlag := flags.Lookup("prefetch-image")
+				Expect(prefetchImageFlag).NotTo(BeNil())
+				Expect(prefetchImageFlag.Value.Type()).To(Equal("stringArray"))
+
+				storageClassFlag := flags.Lookup("storage-class")
+				Expect(storageClassFlag).NotTo(BeNil())
+				Expect(storageClassFlag.DefValue).To(Equal(""))
+
+				gpuFlag := flags.Lookup("gpu")
+				Expect(gpuFlag).NotTo(BeNil())
+				Expect(gpuFlag.DefValue).To(Equal("0"))
+
 				skipMetalLBFlag := flags.Lookup("skip-metallb-install")
 				Expect(skipMetalLBFlag).NotTo(BeNil())
 				Expect(skipMetalLBFlag.Usage).To(ContainSubstring("Skip MetalLB"))
@@ -163,6 +284,10 @@ var _ = Describe("Cmd", func() {
 				Expect(cloudProviderFlag).NotTo(BeNil())
 				Expect(cloudProviderFlag.Usage).To(ContainSubstring("cloud-provider-kind"))
 
+				cloudProviderVersionFlag := flags.Lookup("cloud-provider-version")
+				Expect(cloudProviderVersionFlag).NotTo(BeNil())
+				Expect(cloudProviderVersionFlag.DefValue).To(Equal(""))
+
 				cniFlag := flags.Lookup("cni")
 				Expect(cniFlag).NotTo(BeNil())
 				Expect(cniFlag.Usage).To(ContainSubstring("CNI plugin"))
@@ -185,6 +310,28 @@ var _ = Describe("Cmd", func() {
 				Expect(createCommand.MarkFlagRequired("project")).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("Bridge reuse", func() {
+			var manager *config.ConfigManager
+
+			BeforeEach(func() {
+				manager = config.NewConfigManagerWithDir(GinkgoT().TempDir())
+				Expect(manager.SaveConfig("first", &config.ProjectConfig{Project: "first", Environment: "minikube", Bridge: "virbr50"})).To(Succeed())
+				Expect(manager.SaveConfig("kindproject", &config.ProjectConfig{Project: "kindproject", Environment: "kind", Bridge: "virbr51"})).To(Succeed())
+			})
+
+			It("should reject a bridge used by another minikube project", func() {
+				err := checkBridgeAvailable(manager, "second", "virbr50")
+				Expect(err).To(MatchError(ContainSubstring("bridge virbr50 is already used by project first")))
+
+				Expect(checkBridgeAvailable(manager, "second", "")).To(MatchError(ContainSubstring("project first")))
+			})
+
+			It("should allow the project's own bridge and bridges no minikube project uses", func() {
+				Expect(checkBridgeAvailable(manager, "first", "virbr50")).To(Succeed())
+				Expect(checkBridgeAvailable(manager, "second", "virbr51")).To(Succeed())
+			})
+		})
 	})
 
 	Describe("Delete Command", func() {
@@ -212,6 +359,10 @@ var _ = Describe("Cmd", func() {
 				Expect(numFlag).NotTo(BeNil())
 				Expect(numFlag.Usage).To(ContainSubstring("Number of clusters"))
 
+				clusterFlag := flags.Lookup("cluster")
+				Expect(clusterFlag).NotTo(BeNil())
+				Expect(clusterFlag.DefValue).To(Equal("0"))
+
 				forceFlag := flags.Lookup("force")
 				Expect(forceFlag).NotTo(BeNil())
 				Expect(forceFlag.Usage).To(ContainSubstring("Force cleanup"))
@@ -223,6 +374,135 @@ var _ = Describe("Cmd", func() {
 		})
 	})
 
+	Describe("Status Command", func() {
+		var statusCommand *cobra.Command
+
+		BeforeEach(func() {
+			statusCommand = statusCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should default to table output", func() {
+				outputFlag := statusCommand.Flags().Lookup("output")
+				Expect(outputFlag).NotTo(BeNil())
+				Expect(outputFlag.Shorthand).To(Equal("o"))
+				Expect(outputFlag.DefValue).To(Equal("table"))
+			})
+
+			It("should reject unknown output formats", func() {
+				statusCommand.SetArgs([]string{"-p", "demo", "-o", "yaml"})
+				statusCommand.SilenceErrors = true
+				statusCommand.SilenceUsage = true
+				err := statusCommand.Execute()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid output format"))
+			})
+		})
+	})
+
+	Describe("Start Command", func() {
+		var startCommand *cobra.Command
+
+		BeforeEach(func() {
+			startCommand = startCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(startCommand.Use).To(Equal("start"))
+				Expect(startCommand.Short).To(ContainSubstring("Start stopped Kubernetes clusters"))
+				Expect(startCommand.Long).To(ContainSubstring("without recreating them"))
+			})
+
+			It("should have project flag", func() {
+				projectFlag := startCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Usage).To(ContainSubstring("Project name"))
+			})
+		})
+	})
+
+	Describe("Stop Command", func() {
+		var stopCommand *cobra.Command
+
+		BeforeEach(func() {
+			stopCommand = stopCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(stopCommand.Use).To(Equal("stop"))
+				Expect(stopCommand.Short).To(ContainSubstring("Stop Kubernetes clusters"))
+				Expect(stopCommand.Long).To(ContainSubstring("started again later"))
+			})
+
+			It("should have project flag", func() {
+				projectFlag := stopCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Usage).To(ContainSubstring("Project name"))
+			})
+		})
+	})
+
+	Describe("Scale Command", func() {
+		var scaleCommand *cobra.Command
+
+		BeforeEach(func() {
+			scaleCommand = scaleCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(scaleCommand.Use).To(Equal("scale"))
+				Expect(scaleCommand.Long).To(ContainSubstring("can't be resized in place"))
+			})
+
+			It("should require the project and node count", func() {
+				projectFlag := scaleCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Shorthand).To(Equal("p"))
+				Expect(projectFlag.Annotations).To(HaveKey(cobra.BashCompOneRequiredFlag))
+
+				nodesFlag := scaleCommand.Flags().Lookup("nodes")
+				Expect(nodesFlag).NotTo(BeNil())
+				Expect(nodesFlag.Shorthand).To(Equal("z"))
+				Expect(nodesFlag.Annotations).To(HaveKey(cobra.BashCompOneRequiredFlag))
+			})
+		})
+	})
+
+	Describe("Image Load Command", func() {
+		var imageLoadCommand *cobra.Command
+
+		BeforeEach(func() {
+			imageLoadCommand = imageLoadCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(imageLoadCommand.Use).To(Equal("image-load"))
+				Expect(imageLoadCommand.Short).To(ContainSubstring("Load Docker images"))
+			})
+
+			It("should accept repeated image flags", func() {
+				imageFlag := imageLoadCommand.Flags().Lookup("image")
+				Expect(imageFlag).NotTo(BeNil())
+				Expect(imageFlag.Value.Type()).To(Equal("stringArray"))
+
+				Expect(imageLoadCommand.Flags().Parse([]string{"-i", "app:v1", "--image", "worker:v1"})).To(Succeed())
+				images, err := imageLoadCommand.Flags().GetStringArray("image")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(images).To(Equal([]string{"app:v1", "worker:v1"}))
+			})
+
+			It("should have archive flag", func() {
+				archiveFlag := imageLoadCommand.Flags().Lookup("archive")
+				Expect(archiveFlag).NotTo(BeNil())
+				Expect(archiveFlag.Usage).To(ContainSubstring("image archive"))
+			})
+		})
+	})
+
 	Describe("Config Command", func() {
 		var configCommand *cobra.Command
 
@@ -246,10 +526,117 @@ var _ = Describe("Cmd", func() {
 
 				Expect(commandNames).To(ContainElement("list"))
 				Expect(commandNames).To(ContainElement("show"))
+				Expect(commandNames).To(ContainElement("set"))
+				Expect(commandNames).To(ContainElement("edit"))
 				Expect(commandNames).To(ContainElement("delete"))
 			})
 		})
 
+		Context("Updating a saved config", func() {
+			var originalManager *config.ConfigManager
+
+			BeforeEach(func() {
+				originalManager = configManager
+				configManager = config.NewConfigManagerWithDir(GinkgoT().TempDir())
+				Expect(configManager.SaveConfig("myproject", &config.ProjectConfig{
+					Project:     "myproject",
+					Environment: "kind",
+					NodeCount:   2,
+					CNI:         "cilium",
+				})).To(Succeed())
+			})
+
+			AfterEach(func() {
+				configManager = originalManager
+			})
+
+			It("should set a known key", func() {
+				configCommand.SetArgs([]string{"set", "myproject", "node_count", "3"})
+				Expect(configCommand.Execute()).To(Succeed())
+
+				projectConfig, err := configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.NodeCount).To(Equal(3))
+				Expect(projectConfig.CNI).To(Equal("cilium"))
+			})
+
+			It("should reject unknown keys and invalid values", func() {
+				configCommand.SetArgs([]string{"set", "myproject", "nodes", "3"})
+				Expect(configCommand.Execute()).To(MatchError(ContainSubstring("unknown config key")))
+
+				configCommand.SetArgs([]string{"set", "myproject", "node_count", "three"})
+				Expect(configCommand.Execute()).To(MatchError(ContainSubstring("expected a number")))
+			})
+
+			It("should fail for a project without a config", func() {
+				configCommand.SetArgs([]string{"set", "unknown", "node_count", "3"})
+				Expect(configCommand.Execute()).To(MatchError(ContainSubstring("no configuration found")))
+			})
+
+			It("should save the config changed in the editor", func() {
+				GinkgoT().Setenv("EDITOR", "sed -i /node_count/s/2/4/")
+				Expect(editProjectConfig("myproject")).To(Succeed())
+
+				projectConfig, err := configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.NodeCount).To(Equal(4))
+			})
+
+			It("should not save an edited config that doesn't parse", func() {
+				GinkgoT().Setenv("EDITOR", "sed -i s/node_count:/node_counts:/")
+				Expect(editProjectConfig("myproject")).To(MatchError(ContainSubstring("no changes were saved")))
+
+				projectConfig, err := configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.NodeCount).To(Equal(2))
+			})
+
+			It("should mark a create in progress until it is finalized", func() {
+				finalConfig := &config.ProjectConfig{Project: "myproject", Environment: "kind", NumClusters: 3}
+				saveCreateInProgress(configManager, finalConfig)
+
+				projectConfig, err := configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.CreateInProgress).To(BeTrue())
+				Expect(projectConfig.NumClusters).To(Equal(3))
+
+				// the allocations saved while the clusters are created survive finalizing
+				projectConfig.MetalLBAllocations = []config.MetalLBAllocation{{ClusterName: "kind1", IPPrefix: "172.18.0", StartOctet: 200, EndOctet: 210}}
+				Expect(configManager.SaveConfig("myproject", projectConfig)).To(Succeed())
+				finalizeCreateConfig(configManager, finalConfig)
+
+				projectConfig, err = configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.CreateInProgress).To(BeFalse())
+				Expect(projectConfig.MetalLBAllocations).To(HaveLen(1))
+			})
+
+			It("should keep the saved config and the larger cluster count while a create is in progress", func() {
+				projectConfig, err := configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				projectConfig.NumClusters = 3
+				Expect(configManager.SaveConfig("myproject", projectConfig)).To(Succeed())
+
+				saveCreateInProgress(configManager, &config.ProjectConfig{Project: "myproject", Environment: "kind", NumClusters: 1, NodeCount: 1, CNI: "calico"})
+
+				projectConfig, err = configManager.LoadConfig("myproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.CreateInProgress).To(BeTrue())
+				Expect(projectConfig.NumClusters).To(Equal(3))
+				Expect(projectConfig.NodeCount).To(Equal(2))
+				Expect(projectConfig.CNI).To(Equal("cilium"))
+			})
+
+			It("should save a new project with the requested settings while its create is in progress", func() {
+				saveCreateInProgress(configManager, &config.ProjectConfig{Project: "newproject", Environment: "kind", NumClusters: 2})
+
+				projectConfig, err := configManager.LoadConfig("newproject")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.CreateInProgress).To(BeTrue())
+				Expect(projectConfig.NumClusters).To(Equal(2))
+			})
+		})
+
 		Context("Config subcommands", func() {
 			It("should have list subcommand", func() {
 				subcommands := configCommand.Commands()
@@ -292,6 +679,476 @@ var _ = Describe("Cmd", func() {
 		})
 	})
 
+	Describe("Registry Command", func() {
+		var registryCommand *cobra.Command
+
+		BeforeEach(func() {
+			registryCommand = registryCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(registryCommand.Use).To(Equal("registry"))
+				Expect(registryCommand.Short).To(ContainSubstring("shared Kind registry"))
+			})
+
+			It("should have subcommands", func() {
+				subcommands := registryCommand.Commands()
+				commandNames := make([]string, len(subcommands))
+				for i, cmd := range subcommands {
+					commandNames[i] = cmd.Name()
+				}
+
+				Expect(commandNames).To(ContainElement("start"))
+				Expect(commandNames).To(ContainElement("stop"))
+				Expect(commandNames).To(ContainElement("status"))
+			})
+		})
+	})
+
+	Describe("Network Command", func() {
+		It("should have a list subcommand available as status too", func() {
+			networkCommand := networkCmd()
+			Expect(networkCommand.Use).To(Equal("network"))
+
+			listCommand, _, err := networkCommand.Find([]string{"status"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listCommand.Name()).To(Equal("list"))
+			Expect(listCommand.Flags().Lookup("project").Shorthand).To(Equal("p"))
+		})
+
+		Context("Managed networks", func() {
+			projects := []*config.ProjectConfig{
+				{Project: "vms"},
+				{Project: "team", Environment: "kind"},
+			}
+			hostNetworks := []network.NetworkInfo{
+				{Name: "vms-net", Subnet: "192.168.39.0/24", Gateway: "192.168.39.1", Active: true},
+				{Name: "gone-net", Subnet: "192.168.40.0/24", Gateway: "192.168.40.1", Active: true},
+				{Name: "default", Subnet: "192.168.122.0/24", Gateway: "192.168.122.1", Active: true},
+			}
+			containerNetworks := []docker.Network{
+				{Name: "bridge", Subnet: "172.17.0.0/16", Gateway: "172.17.0.1"},
+				{Name: config.KindNetworkName, Subnet: "10.89.0.0/16", Gateway: "10.89.0.1", Labels: map[string]string{config.ManagedLabel: "true"}},
+			}
+
+			It("should only keep the networks of saved projects and the shared networks", func() {
+				Expect(managedNetworkRows(hostNetworks, containerNetworks, projects, false)).To(Equal([]networkRow{
+					{name: config.KindNetworkName, kind: "container", projects: []string{"team"}, subnet: "10.89.0.0/16", gateway: "10.89.0.1", active: true},
+					{name: "vms-net", kind: "libvirt", projects: []string{"vms"}, subnet: "192.168.39.0/24", gateway: "192.168.39.1", active: true},
+				}))
+			})
+
+			It("should leave out shared networks the projects don't use", func() {
+				rows := managedNetworkRows(hostNetworks, containerNetworks, projects[:1], true)
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].name).To(Equal("vms-net"))
+
+				vmnet := []network.NetworkInfo{{Name: config.MinikubeVmnetNetworkName, Active: true}}
+				Expect(managedNetworkRows(vmnet, nil, projects[1:], true)).To(BeEmpty())
+				Expect(managedNetworkRows(vmnet, nil, projects, true)).To(Equal([]networkRow{
+					{name: config.MinikubeVmnetNetworkName, kind: "vmnet", projects: []string{"vms"}, active: true},
+				}))
+			})
+		})
+	})
+
+	Describe("Addons Command", func() {
+		var addonsCommand *cobra.Command
+
+		BeforeEach(func() {
+			addonsCommand = addonsCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct basic properties", func() {
+				Expect(addonsCommand.Use).To(Equal("addons"))
+				Expect(addonsCommand.Short).To(ContainSubstring("Minikube addons"))
+
+				projectFlag := addonsCommand.PersistentFlags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Shorthand).To(Equal("p"))
+			})
+
+			It("should have subcommands", func() {
+				subcommands := addonsCommand.Commands()
+				commandNames := make([]string, len(subcommands))
+				for i, cmd := range subcommands {
+					commandNames[i] = cmd.Name()
+				}
+
+				Expect(commandNames).To(ContainElement("enable"))
+				Expect(commandNames).To(ContainElement("disable"))
+				Expect(commandNames).To(ContainElement("list"))
+			})
+		})
+	})
+
+	Describe("Reset Command", func() {
+		var resetCommand *cobra.Command
+
+		BeforeEach(func() {
+			resetCommand = resetCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct flags", func() {
+				Expect(resetCommand.Use).To(Equal("reset"))
+
+				projectFlag := resetCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Shorthand).To(Equal("p"))
+
+				cniFlag := resetCommand.Flags().Lookup("cni")
+				Expect(cniFlag).NotTo(BeNil())
+				Expect(cniFlag.DefValue).To(Equal("false"))
+				Expect(cniFlag.Usage).To(ContainSubstring("Cilium and Calico"))
+				Expect(resetCommand.Long).To(ContainSubstring("metrics-server"))
+			})
+		})
+	})
+
+	Describe("Doctor Command", func() {
+		Context("Command structure", func() {
+			It("should be available as check too", func() {
+				doctorCommand := doctorCmd()
+				Expect(doctorCommand.Use).To(Equal("doctor"))
+				Expect(doctorCommand.Aliases).To(ContainElement("check"))
+				Expect(doctorCommand.Flags().Lookup("container-engine")).NotTo(BeNil())
+			})
+		})
+
+		Context("Checks", func() {
+			It("should fail for root", func() {
+				Expect(rootCheck(0)).To(MatchError(ContainSubstring("must not be run as sudo/root")))
+				Expect(rootCheck(1000)).To(Succeed())
+			})
+
+			It("should only warn about missing optional binaries", func() {
+				check := binaryCheck("lok8s-missing-binary", "not needed")
+				Expect(check.Optional).To(BeTrue())
+				Expect(check.Err).To(MatchError("not found, not needed"))
+			})
+		})
+	})
+
+	Describe("Prune Command", func() {
+		It("should only report unless forced", func() {
+			forceFlag := pruneCmd().Flags().Lookup("force")
+			Expect(forceFlag).NotTo(BeNil())
+			Expect(forceFlag.DefValue).To(Equal("false"))
+		})
+
+		Context("Planning", func() {
+			projects := []*config.ProjectConfig{
+				{Project: "gone", Environment: "kind", NumClusters: 2},
+				{Project: "partial", Environment: "kind", ClusterPrefix: "team", NumClusters: 2},
+				{Project: "vms", NumClusters: 1},
+			}
+
+			It("should find projects without live clusters and the contexts of missing clusters", func() {
+				live := map[string][]string{"kind": {"team-1"}, "minikube": {"vms"}}
+				contexts := []string{"gone-1", "partial-1", "partial-2", "vms"}
+
+				staleProjects, orphanedContexts := planPrune(projects, live, nil, contexts)
+				Expect(staleProjects).To(HaveLen(1))
+				Expect(staleProjects[0].Project).To(Equal("gone"))
+				Expect(orphanedContexts).To(Equal([]string{"gone-1", "partial-2"}))
+			})
+
+			It("should leave the projects of an environment that couldn't be listed alone", func() {
+				staleProjects, orphanedContexts := planPrune(projects, map[string][]string{"kind": {"kind1", "kind2", "team-1", "team-2"}}, nil, []string{"vms"})
+				Expect(staleProjects).To(BeEmpty())
+				Expect(orphanedContexts).To(BeEmpty())
+			})
+
+			It("should not count clusters labelled with another project", func() {
+				live := map[string][]string{"kind": {"kind1", "kind2", "team-1", "team-2"}, "minikube": {"vms"}}
+				owners := map[string]string{"kind1": "other", "kind2": "other", "team-1": "partial"}
+
+				staleProjects, orphanedContexts := planPrune(projects, live, owners, []string{"gone-1", "gone-2"})
+				Expect(staleProjects).To(HaveLen(1))
+				Expect(staleProjects[0].Project).To(Equal("gone"))
+				Expect(orphanedContexts).To(Equal([]string{"gone-1", "gone-2"}))
+			})
+		})
+	})
+
+	Describe("Kubeconfig Command", func() {
+		var kubeconfigCommand *cobra.Command
+
+		BeforeEach(func() {
+			kubeconfigCommand = kubeconfigCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct flags", func() {
+				projectFlag := kubeconfigCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Shorthand).To(Equal("p"))
+
+				outputFlag := kubeconfigCommand.Flags().Lookup("output")
+				Expect(outputFlag).NotTo(BeNil())
+				Expect(outputFlag.Shorthand).To(Equal("o"))
+				Expect(outputFlag.DefValue).To(Equal(""))
+			})
+		})
+
+		Context("Context names", func() {
+			It("should not suffix a single cluster", func() {
+				Expect(projectContextNames("demo", 1)).To(Equal([]string{"demo"}))
+			})
+
+			It("should suffix each cluster when there are several", func() {
+				Expect(projectContextNames("demo", 3)).To(Equal([]string{"demo-1", "demo-2", "demo-3"}))
+			})
+		})
+	})
+
+	Describe("Kubectl Command", func() {
+		var kubectlCommand *cobra.Command
+
+		BeforeEach(func() {
+			kubectlCommand = kubectlCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct flags", func() {
+				projectFlag := kubectlCommand.Flags().Lookup("project")
+				Expect(projectFlag).NotTo(BeNil())
+				Expect(projectFlag.Shorthand).To(Equal("p"))
+
+				clusterFlag := kubectlCommand.Flags().Lookup("cluster")
+				Expect(clusterFlag).NotTo(BeNil())
+				Expect(clusterFlag.DefValue).To(Equal("0"))
+			})
+		})
+
+		Context("Context name", func() {
+			It("should use the bare project name for a single cluster", func() {
+				contextName, err := projectContextName("demo", 1, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contextName).To(Equal("demo"))
+
+				contextName, err = projectContextName("demo", 1, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contextName).To(Equal("demo"))
+			})
+
+			It("should use the suffixed name of the chosen cluster", func() {
+				contextName, err := projectContextName("demo", 3, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contextName).To(Equal("demo-2"))
+			})
+
+			It("should require a cluster when there are several", func() {
+				_, err := projectContextName("demo", 2, 0)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("--cluster"))
+			})
+
+			It("should reject a cluster outside the project", func() {
+				_, err := projectContextName("demo", 2, 3)
+				Expect(err).To(HaveOccurred())
+
+				_, err = projectContextName("demo", 1, 2)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Kind Tunnel Command", func() {
+		var kindTunnelCommand *cobra.Command
+
+		BeforeEach(func() {
+			kindTunnelCommand = kindTunnelCmd()
+		})
+
+		Context("Command structure", func() {
+			It("should have correct flags", func() {
+				listFlag := kindTunnelCommand.Flags().Lookup("list")
+				Expect(listFlag).NotTo(BeNil())
+				Expect(listFlag.DefValue).To(Equal("false"))
+
+				logsFlag := kindTunnelCommand.Flags().Lookup("logs")
+				Expect(logsFlag).NotTo(BeNil())
+				Expect(logsFlag.Shorthand).To(Equal("l"))
+
+				for _, name := range []string{"arg", "env", "env-file"} {
+					Expect(kindTunnelCommand.Flags().Lookup(name)).NotTo(BeNil())
+				}
+
+				watchFlag := kindTunnelCommand.Flags().Lookup("watch")
+				Expect(watchFlag).NotTo(BeNil())
+				Expect(watchFlag.Shorthand).To(Equal("w"))
+				Expect(watchFlag.DefValue).To(Equal("false"))
+
+				intervalFlag := kindTunnelCommand.Flags().Lookup("interval")
+				Expect(intervalFlag).NotTo(BeNil())
+				Expect(intervalFlag.DefValue).To(Equal("5s"))
+			})
+
+			It("should reject a non-positive watch interval", func() {
+				err := watchLoadBalancerPorts(context.Background(), "test", "test", 1, 0)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("--interval must be positive"))
+			})
+		})
+
+		Context("Environment parsing", func() {
+			It("should merge the --env values over the env file", func() {
+				envFile := filepath.Join(GinkgoT().TempDir(), "tunnel.env")
+				Expect(os.WriteFile(envFile, []byte("# proxy settings\nHTTPS_PROXY=http://proxy:3128\n\nLB_MODE=tunnel\n"), 0644)).To(Succeed())
+
+				env, err := parseTunnelEnv(envFile, []string{"LB_MODE=port-mapping", "NO_PROXY="})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env).To(Equal(map[string]string{"HTTPS_PROXY": "http://proxy:3128", "LB_MODE": "port-mapping", "NO_PROXY": ""}))
+			})
+
+			It("should return nothing without an env file or values", func() {
+				env, err := parseTunnelEnv("", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env).To(BeNil())
+			})
+
+			It("should reject values without a key", func() {
+				_, err := parseTunnelEnv("", []string{"LB_MODE"})
+				Expect(err).To(MatchError(ContainSubstring("expected KEY=VALUE")))
+
+				_, err = parseTunnelEnv("", []string{"=tunnel"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("Container parsing", func() {
+			It("should parse docker ps output", func() {
+				output := []byte(`{"ID":"abc","Image":"envoyproxy/envoy","Labels":"io.x-k8s.cloud-provider-kind.cluster=kind1","Ports":"0.0.0.0:49778->80/tcp","State":"running"}` + "\n")
+
+				containers := parseDockerContainers(output)
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Ports).To(Equal("0.0.0.0:49778->80/tcp"))
+			})
+
+			It("should convert podman ps output to the docker layout", func() {
+				output := []byte(`[{"Id":"abc","Image":"docker.io/envoyproxy/envoy","State":"running",` +
+					`"Labels":{"io.x-k8s.cloud-provider-kind.cluster":"kind1","io.x-k8s.cloud-provider-kind.loadbalancer.name":"kind1/default/lb-test"},` +
+					`"Ports":[{"host_ip":"","container_port":80,"host_port":49778,"range":1,"protocol":"tcp"}]}]`)
+
+				containers, err := parsePodmanContainers(output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Labels).To(ContainSubstring("io.x-k8s.cloud-provider-kind.cluster=kind1"))
+				Expect(extractLoadBalancerName(containers[0].Labels)).To(Equal("lb-test"))
+
+				ports := parsePortMappings(containers[0].Ports)
+				Expect(ports).To(HaveLen(1))
+				Expect(ports[0].HostPort).To(Equal("49778"))
+				Expect(ports[0].ServicePort).To(Equal("80"))
+				Expect(ports[0].Protocol).To(Equal("tcp"))
+			})
+
+			It("should parse IPv4 and IPv6 host addresses in port mappings", func() {
+				ports := parsePortMappings("0.0.0.0:49778->80/tcp, [::]:49778->80/tcp, [fd00::1]:49779->443/tcp")
+				Expect(ports).To(Equal([]PortMapping{
+					{HostPort: "49778", ServicePort: "80", Protocol: "tcp", IPVersion: "IPv4"},
+					{HostPort: "49778", ServicePort: "80", Protocol: "tcp", IPVersion: "IPv6"},
+					{HostPort: "49779", ServicePort: "443", Protocol: "tcp", IPVersion: "IPv6"},
+				}))
+			})
+
+			It("should bracket IPv6 host addresses from podman ps output", func() {
+				output := []byte(`[{"Id":"abc","Image":"docker.io/envoyproxy/envoy","State":"running","Labels":{},` +
+					`"Ports":[{"host_ip":"fd00::1","container_port":80,"host_port":49778,"range":1,"protocol":"tcp"}]}]`)
+
+				containers, err := parsePodmanContainers(output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Ports).To(Equal("[fd00::1]:49778->80/tcp"))
+				Expect(parsePortMappings(containers[0].Ports)).To(Equal([]PortMapping{
+					{HostPort: "49778", ServicePort: "80", Protocol: "tcp", IPVersion: "IPv6"},
+				}))
+			})
+
+			It("should handle empty podman ps output", func() {
+				containers, err := parsePodmanContainers([]byte("[]"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(BeEmpty())
+			})
+		})
+
+		Context("Process uptime", func() {
+			It("should format the uptime of a process", func() {
+				now := time.Now()
+				process := services.CloudProviderProcess{StartTime: now.Add(-2*time.Hour - 5*time.Minute).Format(time.RFC3339)}
+				Expect(processUptime(process, now)).To(Equal("2h5m0s"))
+			})
+
+			It("should report unknown for entries without a timestamp", func() {
+				process := services.CloudProviderProcess{StartTime: "4242"}
+				Expect(processUptime(process, time.Now())).To(Equal("unknown"))
+			})
+		})
+
+		Context("Load balancer port tracking", func() {
+			saved := []config.LoadBalancerPort{
+				{Cluster: "kind1", LoadBalancer: "kind1/default/web", ServicePort: "80", Protocol: "TCP", HostPort: "50001"},
+				{Cluster: "kind2", LoadBalancer: "kind2/default/web", ServicePort: "80", Protocol: "TCP", HostPort: "50002"},
+			}
+
+			It("should report a service port that moved to another host port", func() {
+				current := []LoadBalancerPortInfo{
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", ServicePort: "80", Protocol: "TCP", HostPort: "50011"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/api", ServicePort: "8080", Protocol: "TCP", HostPort: "50012"},
+				}
+
+				portInfos, updated := trackLoadBalancerPorts(saved, current)
+				Expect(portInfos[0].PreviousHostPort).To(Equal("50001"))
+				Expect(portInfos[1].PreviousHostPort).To(BeEmpty())
+
+				// the cluster without load balancers this time keeps its saved port
+				Expect(updated).To(Equal([]config.LoadBalancerPort{
+					{Cluster: "kind1", LoadBalancer: "kind1/default/api", ServicePort: "8080", Protocol: "TCP", HostPort: "50012"},
+					{Cluster: "kind1", LoadBalancer: "kind1/default/web", ServicePort: "80", Protocol: "TCP", HostPort: "50011"},
+					{Cluster: "kind2", LoadBalancer: "kind2/default/web", ServicePort: "80", Protocol: "TCP", HostPort: "50002"},
+				}))
+			})
+
+			It("should keep the saved ports when no load balancer was found", func() {
+				portInfos, updated := trackLoadBalancerPorts(saved, nil)
+				Expect(portInfos).To(BeEmpty())
+				Expect(updated).To(Equal(saved))
+			})
+		})
+
+		Context("Port ordering", func() {
+			It("should sort by cluster, load balancer and numeric host port", func() {
+				portInfos := []LoadBalancerPortInfo{
+					{ClusterName: "kind2", LoadBalancerName: "kind2/default/web", HostPort: "50001"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "9000"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "10000"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/api", HostPort: "50002"},
+				}
+
+				sortPorts(portInfos)
+				Expect(portInfos).To(Equal([]LoadBalancerPortInfo{
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/api", HostPort: "50002"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "9000"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "10000"},
+					{ClusterName: "kind2", LoadBalancerName: "kind2/default/web", HostPort: "50001"},
+				}))
+			})
+
+			It("should keep the IP family duplicates out of the sorted result", func() {
+				portInfos := deduplicatePorts([]LoadBalancerPortInfo{
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "50001", ServicePort: "80", Protocol: "TCP", IPVersion: "IPv6"},
+					{ClusterName: "kind1", LoadBalancerName: "kind1/default/web", HostPort: "50001", ServicePort: "80", Protocol: "TCP", IPVersion: "IPv4"},
+				})
+				sortPorts(portInfos)
+				Expect(portInfos).To(HaveLen(1))
+			})
+		})
+	})
+
 	Describe("Version Command", func() {
 		var versionCommand *cobra.Command
 
@@ -303,6 +1160,38 @@ var _ = Describe("Cmd", func() {
 			It("should have correct basic properties", func() {
 				Expect(versionCommand.Use).To(Equal("version"))
 				Expect(versionCommand.Short).To(ContainSubstring("Print the version information"))
+
+				checkFlag := versionCommand.Flags().Lookup("check")
+				Expect(checkFlag).NotTo(BeNil())
+				Expect(checkFlag.DefValue).To(Equal("false"))
+			})
+		})
+
+		Context("Checking for upgrades", func() {
+			It("should compare the installed and latest versions", func() {
+				Expect(toolVersion{installed: "0.5.0", latest: "0.6.0"}.status()).To(Equal("upgrade available"))
+				Expect(toolVersion{installed: "0.6.0", latest: "0.6.0"}.status()).To(Equal("up to date"))
+				Expect(toolVersion{installed: "0.6.0"}.status()).To(Equal("unknown"))
+				Expect(toolVersion{latest: "0.6.0"}.status()).To(Equal("not installed"))
+				Expect(toolVersion{installed: "dev", latest: "0.6.0"}.status()).To(Equal("development build"))
+			})
+
+			It("should parse the version printed by a tool", func() {
+				Expect(parseToolVersion("vfkit version: v0.6.1\n")).To(Equal("0.6.1"))
+				Expect(parseToolVersion("vmnet-helper 0.7.0")).To(Equal("0.7.0"))
+				Expect(parseToolVersion("unknown")).To(BeEmpty())
+			})
+
+			It("should print a table and report available upgrades", func() {
+				var out bytes.Buffer
+				upgradable := printVersionCheck(&out, []toolVersion{
+					{name: "lok8s", installed: "1.0.0", latest: "1.1.0"},
+					{name: "minikube", latest: "1.36.0"},
+				})
+
+				Expect(upgradable).To(BeTrue())
+				Expect(out.String()).To(ContainSubstring("TOOL"))
+				Expect(out.String()).To(MatchRegexp(`minikube\s+-\s+1.36.0\s+not installed`))
 			})
 		})
 	})
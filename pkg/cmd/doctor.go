@@ -0,0 +1,99 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/cluster/kind"
+	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/cluster/report"
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// doctorCmd runs the prerequisite checks of create for an environment without provisioning anything
+func doctorCmd() *cobra.Command {
+	var (
+		containerEngine  string
+		enginePreference []string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "doctor",
+		Aliases: []string{"check"},
+		Short:   "Check the prerequisites of an environment",
+		Long: `Run the prerequisite checks done by create for the selected environment (KVM, libvirt and vfkit for
+minikube, the container runtime for Kind) and print a pass/fail report. Exits non-zero when a hard
+prerequisite fails`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checks := []report.Check{
+				{Name: "not running as sudo/root", Err: rootCheck(syscall.Geteuid())},
+			}
+			switch environment {
+			case "minikube":
+				checks = append(checks, minikube.NewManager().Doctor()...)
+			case "kind":
+				checks = append(checks, kind.NewManager().Doctor(containerEngine, enginePreference)...)
+				checks = append(checks, binaryCheck("kind", "used by image load"))
+			default:
+				return fmt.Errorf("unsupported environment: %s", environment)
+			}
+			checks = append(checks, binaryCheck("helm", "handy to inspect the installed releases"), binaryCheck("kubectl", "handy to use the clusters"))
+
+			out := logger.Output()
+			fmt.Fprintf(out, "🩺 checking the %s prerequisites\n", environment)
+			if failed := report.PrintChecks(out, checks); failed > 0 {
+				return fmt.Errorf("%d prerequisite check(s) failed", failed)
+			}
+			fmt.Fprintln(out, "✅ all prerequisites are met")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&containerEngine, "container-engine", "", "Container engine to check (Kind only, Options: docker or podman). If not specified, auto-detects available engine")
+	cmd.Flags().StringSliceVar(&enginePreference, "container-engine-preference", nil, "Order to auto-detect container engines in when --container-engine is not set (Kind only), e.g. podman,docker")
+
+	return cmd
+}
+
+// rootCheck fails for root, lok8s refuses to create clusters as sudo/root
+func rootCheck(euid int) error {
+	if euid == 0 {
+		return fmt.Errorf("lok8s must not be run as sudo/root")
+	}
+	return nil
+}
+
+// binaryCheck is an optional check that a binary lok8s doesn't need itself is on the PATH
+func binaryCheck(name, purpose string) report.Check {
+	check := report.Check{Name: fmt.Sprintf("%s on PATH", name), Optional: true}
+	if _, err := exec.LookPath(name); err != nil {
+		check.Err = fmt.Errorf("not found, %s", purpose)
+	}
+	return check
+}
@@ -23,19 +23,27 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
-	"os/exec"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/day0ops/lok8s/pkg/cluster/kind"
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
 	"github.com/day0ops/lok8s/pkg/services"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
 )
 
 // kindTunnelCmd manages cloud-provider-kind processes for darwin
@@ -44,7 +52,15 @@ func kindTunnelCmd() *cobra.Command {
 		project   string
 		terminate bool
 		showPorts bool
+		showLogs  bool
+		list      bool
+		watch     bool
+		interval  time.Duration
+		tail      int
 		format    string
+		extraArgs []string
+		extraEnv  []string
+		envFile   string
 	)
 
 	cmd := &cobra.Command{
@@ -57,10 +73,12 @@ On Linux, sudo is not required.
 Use this command to:
 - Start cloud-provider-kind processes for existing Kind clusters
 - Kill existing cloud-provider-kind processes
-- Display ephemeral ports created by Docker/Podman for Envoy load balancers`,
+- Display ephemeral ports created by Docker/Podman for Envoy load balancers
+- Print cloud-provider-kind logs to diagnose load balancers that never get an external IP
+- List tracked cloud-provider-kind processes and how long they have been running`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Only require sudo on macOS/Darwin
-			if config.IsDarwin() && syscall.Geteuid() != 0 {
+			// Only require sudo on macOS/Darwin (reading logs or the process list doesn't need it)
+			if config.IsDarwin() && syscall.Geteuid() != 0 && !showLogs && !list {
 				return fmt.Errorf("this command must be run as sudo on macOS")
 			}
 
@@ -68,6 +86,13 @@ Use this command to:
 				return fmt.Errorf("project name is required")
 			}
 
+			if watch && !showPorts {
+				return fmt.Errorf("--watch can only be used with --ports")
+			}
+			if watch && format != "table" {
+				return fmt.Errorf("--watch only supports the table format")
+			}
+
 			// load saved config to get environment and other settings
 			savedConfig, err := configManager.LoadConfig(project)
 			if err != nil {
@@ -79,12 +104,22 @@ Use this command to:
 				return fmt.Errorf("project %s is not configured for kind environment", project)
 			}
 
-			if showPorts {
-				return showLoadBalancerPorts(project, savedConfig.NumClusters, format)
+			if list {
+				return listCloudProviderProcesses()
+			} else if showLogs {
+				return showCloudProviderLogs(project, savedConfig.NumClusters, tail)
+			} else if showPorts && watch {
+				return watchLoadBalancerPorts(cmd.Context(), project, savedConfig.ClusterPrefix, savedConfig.NumClusters, interval)
+			} else if showPorts {
+				return showLoadBalancerPorts(project, savedConfig.ClusterPrefix, savedConfig.NumClusters, format)
 			} else if terminate {
 				return terminateCloudProviderProcesses(project)
 			} else {
-				return startCloudProviderProcesses(project)
+				env, err := parseTunnelEnv(envFile, extraEnv)
+				if err != nil {
+					return err
+				}
+				return startCloudProviderProcesses(project, extraArgs, env)
 			}
 		},
 	}
@@ -93,6 +128,14 @@ Use this command to:
 	cmd.Flags().BoolVarP(&terminate, "terminate", "t", false, "Terminate existing cloud-provider-kind processes under the given project")
 	cmd.Flags().BoolVarP(&showPorts, "ports", "s", false, "Show ephemeral ports created by Docker/Podman for the provisioned load balancers")
 	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format for port display (table, json)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep refreshing the --ports table until Ctrl-C")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "How often --watch refreshes the ports")
+	cmd.Flags().BoolVarP(&showLogs, "logs", "l", false, "Print the cloud-provider-kind logs for each cluster under the given project")
+	cmd.Flags().BoolVar(&list, "list", false, "List all tracked cloud-provider-kind processes with their uptime")
+	cmd.Flags().IntVar(&tail, "tail", 100, "Number of lines to print from the end of each log file when using --logs (0 prints everything)")
+	cmd.Flags().StringArrayVar(&extraArgs, "arg", nil, "Extra flag passed to cloud-provider-kind, can be repeated (e.g. --arg=-gateway-channel=disabled)")
+	cmd.Flags().StringArrayVar(&extraEnv, "env", nil, "Environment variable (KEY=VALUE) set for cloud-provider-kind, can be repeated (e.g. --env HTTPS_PROXY=http://proxy:3128)")
+	cmd.Flags().StringVar(&envFile, "env-file", "", "File of KEY=VALUE lines set as environment variables for cloud-provider-kind, --env values take precedence")
 
 	if err := cmd.MarkFlagRequired("project"); err != nil {
 		logger.Warnf("failed to mark project flag as required: %v", err)
@@ -111,7 +154,7 @@ func checkExistingCloudProviderProcesses(manager *services.CloudProviderKindMana
 	if hasExisting {
 		logger.Warnf("⚠️  existing cloud-provider-kind process(es) detected:")
 		for _, process := range processes {
-			logger.Warnf("   - context: %s, PID: %d", process.ContextName, process.PID)
+			logger.Warnf("   - context: %s, PID: %d, uptime: %s", process.ContextName, process.PID, processUptime(process, time.Now()))
 		}
 		logger.Warnf("⚠️  please terminate existing processes using 'lok8s kind-tunnel -p <project> --terminate' before starting new ones")
 		return fmt.Errorf("existing cloud-provider-kind processes are running")
@@ -120,11 +163,82 @@ func checkExistingCloudProviderProcesses(manager *services.CloudProviderKindMana
 	return nil
 }
 
-// startCloudProviderProcesses starts cloud-provider-kind processes for the specified project
-func startCloudProviderProcesses(project string) error {
-	logger.Infof("starting cloud-provider-kind processes for project %s", project)
+// listCloudProviderProcesses prints every tracked cloud-provider-kind process with its uptime
+func listCloudProviderProcesses() error {
+	manager := services.NewCloudProviderKindManager()
+
+	hasExisting, processes, err := manager.HasExistingProcesses()
+	if err != nil {
+		return fmt.Errorf("failed to check for existing processes: %w", err)
+	}
+
+	out := logger.Output()
+	if !hasExisting {
+		fmt.Fprintln(out, "No cloud-provider-kind processes are being tracked.")
+		return nil
+	}
 
-	clusterIndex := 1
+	now := time.Now()
+	fmt.Fprintf(out, "%-30s %-10s %-15s\n", "CONTEXT", "PID", "UPTIME")
+	fmt.Fprintln(out, strings.Repeat("-", 57))
+	for _, process := range processes {
+		fmt.Fprintf(out, "%-30s %-10d %-15s\n", process.ContextName, process.PID, processUptime(process, now))
+	}
+
+	return nil
+}
+
+// processUptime formats how long a process has been running, or "unknown" for entries
+// recorded without a valid start time
+func processUptime(process services.CloudProviderProcess, now time.Time) string {
+	uptime, err := process.Uptime(now)
+	if err != nil {
+		logger.Debugf("%v", err)
+		return "unknown"
+	}
+	if uptime < 0 {
+		uptime = 0
+	}
+	return uptime.Truncate(time.Second).String()
+}
+
+// parseTunnelEnv reads the KEY=VALUE pairs of the env file, skipping blank lines and # comments, and merges the
+// --env values over them
+func parseTunnelEnv(envFile string, values []string) (map[string]string, error) {
+	var lines []string
+	if envFile != "" {
+		data, err := os.ReadFile(envFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %s: %w", envFile, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	env := make(map[string]string)
+	for _, value := range append(lines, values...) {
+		key, val, found := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", value)
+		}
+		env[key] = val
+	}
+	if len(env) == 0 {
+		return nil, nil
+	}
+	return env, nil
+}
+
+// startCloudProviderProcesses starts cloud-provider-kind processes for the specified project, a context that is
+// restarted without extra flags or environment reuses the ones its previous process was started with
+func startCloudProviderProcesses(project string, extraArgs []string, extraEnv map[string]string) error {
+	logger.Infof("starting cloud-provider-kind processes for project %s", project)
 
 	// load saved config to get number of clusters
 	savedConfig, err := configManager.LoadConfig(project)
@@ -137,6 +251,18 @@ func startCloudProviderProcesses(project string) error {
 	}
 
 	cloudProviderManager := services.NewCloudProviderKindManager()
+	cloudProviderManager.SetVersion(savedConfig.CloudProviderVersion)
+
+	// drop entries left behind by processes that died (e.g. after a reboot)
+	pruned, err := cloudProviderManager.PruneStaleProcesses()
+	if err != nil {
+		logger.Warnf("failed to prune stale cloud-provider-kind processes: %v", err)
+	}
+	previous := make(map[string]services.CloudProviderProcess)
+	for _, process := range pruned {
+		logger.Infof("🧹 removed stale cloud-provider-kind entry for context %s (PID: %d)", process.ContextName, process.PID)
+		previous[process.ContextName] = process
+	}
 
 	// check if there are any existing cloud-provider-kind processes running
 	if err := checkExistingCloudProviderProcesses(cloudProviderManager); err != nil {
@@ -144,26 +270,30 @@ func startCloudProviderProcesses(project string) error {
 	}
 
 	// start cloud-provider-kind for each cluster
-	var contextName string
-	if savedConfig.NumClusters == 1 {
-		// if only one cluster, don't add suffix
-		contextName = project
-	} else {
-		contextName = fmt.Sprintf("%s-%d", project, clusterIndex)
-	}
+	for _, contextName := range projectContextNames(project, savedConfig.NumClusters) {
+		logger.Infof("installing cloud-provider-kind for context %s", contextName)
 
-	logger.Infof("installing cloud-provider-kind for context %s", contextName)
+		// ensure the correct context is set before starting cloud-provider-kind
+		if err := setKubeContext(contextName); err != nil {
+			logger.Errorf("failed to set kube context %s: %v", contextName, err)
+		}
 
-	// ensure the correct context is set before starting cloud-provider-kind
-	if err := setKubeContext(contextName); err != nil {
-		logger.Errorf("failed to set kube context %s: %v", contextName, err)
-	}
+		args, env := extraArgs, extraEnv
+		if process, found := previous[contextName]; found && len(args) == 0 && len(env) == 0 {
+			args, env = process.ExtraArgs, process.ExtraEnv
+			if len(args) > 0 || len(env) > 0 {
+				logger.Infof("reusing the extra flags and environment of the previous process for context %s", contextName)
+			}
+		}
+		cloudProviderManager.SetExtraArgs(args)
+		cloudProviderManager.SetExtraEnv(env)
 
-	if err := cloudProviderManager.Install(contextName, true); err != nil {
-		logger.Errorf("failed to install cloud-provider-kind for context %s: %v", contextName, err)
-		// continue with other clusters even if one fails
-	} else {
-		logger.Infof("✓ successfully started cloud-provider-kind for context %s", contextName)
+		if err := cloudProviderManager.Install(contextName, true); err != nil {
+			logger.Errorf("failed to install cloud-provider-kind for context %s: %v", contextName, err)
+			// continue with other clusters even if one fails
+		} else {
+			logger.Infof("✓ successfully started cloud-provider-kind for context %s", contextName)
+		}
 	}
 
 	logger.Infof("🎉 cloud-provider-kind processes started for project %s", project)
@@ -186,26 +316,71 @@ func terminateCloudProviderProcesses(project string) error {
 	}
 
 	cloudProviderManager := services.NewCloudProviderKindManager()
-	clusterIndex := 1
 
-	var contextName string
-	if savedConfig.NumClusters == 1 {
-		// if only one cluster, don't add suffix
-		contextName = project
-	} else {
-		contextName = fmt.Sprintf("%s-%d", project, clusterIndex)
+	for _, contextName := range projectContextNames(project, savedConfig.NumClusters) {
+		logger.Infof("terminating cloud-provider-kind for context %s", contextName)
+
+		if err := cloudProviderManager.Terminate(contextName, true); err != nil {
+			logger.Warnf("failed to terminate cloud-provider-kind for context %s: %v", contextName, err)
+			// continue with other clusters even if one fails
+		} else {
+			logger.Infof("✓ successfully terminated cloud-provider-kind for context %s", contextName)
+		}
 	}
 
-	logger.Infof("terminating cloud-provider-kind for context %s", contextName)
+	logger.Infof("🎉 cloud-provider-kind processes terminated for project %s", project)
+	return nil
+}
 
-	if err := cloudProviderManager.Terminate(contextName, true); err != nil {
-		logger.Warnf("failed to terminate cloud-provider-kind for context %s: %v", contextName, err)
-		// continue with other clusters even if one fails
-	} else {
-		logger.Infof("✓ successfully terminated cloud-provider-kind for context %s", contextName)
+// showCloudProviderLogs prints the cloud-provider-kind log files for each cluster in the project
+func showCloudProviderLogs(project string, numClusters, tail int) error {
+	cloudProviderManager := services.NewCloudProviderKindManager()
+	found := false
+
+	for _, contextName := range projectContextNames(project, numClusters) {
+		files, err := cloudProviderManager.LogFiles(contextName)
+		if err != nil {
+			logger.Warnf("⚠️ %v", err)
+			continue
+		}
+		found = true
+
+		if len(files) == 0 {
+			logger.Infof("no log files written yet for context %s", contextName)
+			continue
+		}
+
+		for _, file := range files {
+			fmt.Printf("==> %s (%s) <==\n", file, contextName)
+			if err := printLogTail(file, tail); err != nil {
+				logger.Warnf("failed to read %s: %v", file, err)
+			}
+			fmt.Println()
+		}
 	}
 
-	logger.Infof("🎉 cloud-provider-kind processes terminated for project %s", project)
+	if !found {
+		logger.Warnf("⚠️ no cloud-provider-kind logs found, start the processes with '%s kind-tunnel -p %s'", config.AppName, project)
+	}
+
+	return nil
+}
+
+// printLogTail prints the last n lines of a file, or the whole file if n <= 0
+func printLogTail(path string, n int) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
 	return nil
 }
 
@@ -213,11 +388,9 @@ func terminateCloudProviderProcesses(project string) error {
 func setKubeContext(contextName string) error {
 	logger.Debugf("setting kube context to %s", contextName)
 
-	// use kubectl to set the context
-	cmd := exec.Command("kubectl", "config", "use-context", contextName)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to set kube context %s: %w, output: %s", contextName, err, string(output))
+	// use kubectl to set the context, in the kubeconfig given with --kubeconfig when set
+	if _, err := utilexec.OutputWithEnv(k8s.KubeConfigEnv(), "kubectl", "config", "use-context", contextName); err != nil {
+		return fmt.Errorf("failed to set kube context %s: %w", contextName, err)
 	}
 
 	logger.Debugf("successfully set kube context to %s", contextName)
@@ -233,10 +406,14 @@ type LoadBalancerPortInfo struct {
 	Protocol         string
 	IPVersion        string
 	URL              string
+	PreviousHostPort string `json:",omitempty"` // host port saved for the service port when it has since changed
 }
 
+// loadBalancerDiscoveryTimeout is how long a one-shot --ports waits for the load balancer containers of a cluster
+const loadBalancerDiscoveryTimeout = 60 * time.Second
+
 // showLoadBalancerPorts displays ephemeral ports created by Docker/Podman for load balancers
-func showLoadBalancerPorts(project string, numClusters int, format string) error {
+func showLoadBalancerPorts(project, clusterPrefix string, numClusters int, format string) error {
 	logger.Infof("showing load balancer ports for project %s (%d clusters)", project, numClusters)
 
 	// validate format
@@ -256,13 +433,68 @@ func showLoadBalancerPorts(project string, numClusters int, format string) error
 		hostIP = "localhost"
 	}
 
+	portInfos, savedPorts := discoverLoadBalancerPorts(project, clusterPrefix, numClusters, hostIP, loadBalancerDiscoveryTimeout)
+	displayLoadBalancerPorts(portInfos, savedPorts, hostIP, format)
+	return nil
+}
+
+// watchLoadBalancerPorts redraws the load balancer ports table every interval until interrupted, so the URLs of
+// new services show up as they are created
+func watchLoadBalancerPorts(ctx context.Context, project, clusterPrefix string, numClusters int, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+
+	hostIP, err := getHostIP()
+	if err != nil {
+		logger.Warnf("failed to get host IP: %v", err)
+		hostIP = "localhost"
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	out := logger.Output()
+	redraw := logger.Interactive() && logger.IsSmartTerminal(out)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// every refresh is a single look, the interval takes the place of the discovery retries
+		portInfos, savedPorts := discoverLoadBalancerPorts(project, clusterPrefix, numClusters, hostIP, 0)
+		if redraw {
+			fmt.Fprint(out, "\x1b[H\x1b[2J")
+		}
+		fmt.Fprintf(out, "Load balancer ports for project %s at %s (every %s, Ctrl-C to stop)\n", project, time.Now().Format("15:04:05"), interval)
+		displayLoadBalancerPorts(portInfos, savedPorts, hostIP, "table")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverLoadBalancerPorts returns the ports of the project's load balancers, waiting up to timeout for the
+// containers of each cluster, along with the ports saved before this run. The ports found are saved to the config
+func discoverLoadBalancerPorts(project, clusterPrefix string, numClusters int, hostIP string, timeout time.Duration) ([]LoadBalancerPortInfo, []config.LoadBalancerPort) {
 	portInfos := []LoadBalancerPortInfo{}
 
-	for i := 1; i <= numClusters; i++ {
-		clusterName := fmt.Sprintf("kind%d", i)
+	// without a container runtime only the saved ports are reported
+	containerRuntime, runtimeErr := projectContainerRuntime(project)
+	if runtimeErr != nil {
+		logger.Warnf("failed to get load balancer containers: %v", runtimeErr)
+	}
+
+	for i := 1; runtimeErr == nil && i <= numClusters; i++ {
+		clusterName := kind.ClusterName(clusterPrefix, i, numClusters)
 
 		// get load balancer containers for this cluster
-		containers, err := getLoadBalancerContainers(clusterName)
+		containers, err := getLoadBalancerContainers(containerRuntime, clusterName, timeout)
 		if err != nil {
 			logger.Warnf("failed to get load balancer containers for cluster %s: %v", clusterName, err)
 			continue
@@ -284,23 +516,95 @@ func showLoadBalancerPorts(project string, numClusters int, format string) error
 		}
 	}
 
-	// deduplicate port entries (ignore IP family)
+	// deduplicate port entries (ignore IP family) and order them so repeated runs print the same rows
 	portInfos = deduplicatePorts(portInfos)
+	sortPorts(portInfos)
 
-	// display ports based on format
+	// compare with the ports saved last time, so a restart that moved a service to another host port is reported
+	var savedPorts []config.LoadBalancerPort
+	savedConfig, err := configManager.LoadConfig(project)
+	if err != nil {
+		logger.Warnf("failed to load project config: %v", err)
+	} else if savedConfig != nil {
+		savedPorts = savedConfig.LoadBalancerPorts
+		var updatedPorts []config.LoadBalancerPort
+		portInfos, updatedPorts = trackLoadBalancerPorts(savedPorts, portInfos)
+		if !reflect.DeepEqual(updatedPorts, savedConfig.LoadBalancerPorts) {
+			savedConfig.LoadBalancerPorts = updatedPorts
+			if err := configManager.SaveConfig(project, savedConfig); err != nil {
+				logger.Warnf("failed to save load balancer ports: %v", err)
+			}
+		}
+	}
+
+	return portInfos, savedPorts
+}
+
+// displayLoadBalancerPorts prints the ports in the given format, falling back to the saved ports when no load
+// balancer was found
+func displayLoadBalancerPorts(portInfos []LoadBalancerPortInfo, savedPorts []config.LoadBalancerPort, hostIP, format string) {
 	switch format {
 	case "table":
 		if len(portInfos) > 0 {
 			displayPortsTable(portInfos, hostIP)
+			for _, info := range portInfos {
+				if info.PreviousHostPort != "" {
+					logger.Warnf("⚠️ %s port %s/%s moved from host port %s to %s, it is now at %s", info.LoadBalancerName, info.ServicePort, info.Protocol, info.PreviousHostPort, info.HostPort, info.URL)
+				}
+			}
 		} else {
-			fmt.Printf("\n🌐 Host IP: %s\n", hostIP)
-			fmt.Println("No load balancers found. Make sure cloud-provider-kind is running.")
+			fmt.Fprintf(logger.Output(), "\n🌐 Host IP: %s\n", hostIP)
+			fmt.Fprintln(logger.Output(), "No load balancers found. Make sure cloud-provider-kind is running.")
+			if len(savedPorts) > 0 {
+				fmt.Fprintln(logger.Output(), "Last known load balancer ports:")
+				for _, port := range savedPorts {
+					fmt.Fprintf(logger.Output(), "  %s %s/%s -> %s\n", port.LoadBalancer, port.ServicePort, port.Protocol, generateURL(hostIP, port.HostPort))
+				}
+			}
 		}
 	case "json":
 		displayPortsJSON(portInfos, hostIP)
 	}
+}
 
-	return nil
+// trackLoadBalancerPorts marks the ports whose host port differs from the saved one and returns the ports to save.
+// Saved ports of clusters without any load balancer this time are kept, cloud-provider-kind may just not be running
+func trackLoadBalancerPorts(saved []config.LoadBalancerPort, portInfos []LoadBalancerPortInfo) ([]LoadBalancerPortInfo, []config.LoadBalancerPort) {
+	key := func(cluster, loadBalancer, servicePort, protocol string) string {
+		return strings.Join([]string{cluster, loadBalancer, servicePort, protocol}, "|")
+	}
+
+	previous := make(map[string]string, len(saved))
+	for _, port := range saved {
+		previous[key(port.Cluster, port.LoadBalancer, port.ServicePort, port.Protocol)] = port.HostPort
+	}
+
+	found := make(map[string]bool)
+	var updated []config.LoadBalancerPort
+	for i, info := range portInfos {
+		found[info.ClusterName] = true
+		if hostPort, ok := previous[key(info.ClusterName, info.LoadBalancerName, info.ServicePort, info.Protocol)]; ok && hostPort != info.HostPort {
+			portInfos[i].PreviousHostPort = hostPort
+		}
+		updated = append(updated, config.LoadBalancerPort{
+			Cluster:      info.ClusterName,
+			LoadBalancer: info.LoadBalancerName,
+			ServicePort:  info.ServicePort,
+			Protocol:     info.Protocol,
+			HostPort:     info.HostPort,
+		})
+	}
+	for _, port := range saved {
+		if !found[port.Cluster] {
+			updated = append(updated, port)
+		}
+	}
+
+	sort.Slice(updated, func(i, j int) bool {
+		return key(updated[i].Cluster, updated[i].LoadBalancer, updated[i].ServicePort, updated[i].Protocol) <
+			key(updated[j].Cluster, updated[j].LoadBalancer, updated[j].ServicePort, updated[j].Protocol)
+	})
+	return portInfos, updated
 }
 
 // deduplicatePorts removes duplicate port entries, keeping only one entry per unique combination
@@ -327,6 +631,31 @@ func deduplicatePorts(portInfos []LoadBalancerPortInfo) []LoadBalancerPortInfo {
 	return deduplicated
 }
 
+// sortPorts orders port entries by cluster, load balancer and then host port, as docker ps and the IP families
+// don't come back in a stable order
+func sortPorts(portInfos []LoadBalancerPortInfo) {
+	sort.SliceStable(portInfos, func(i, j int) bool {
+		a, b := portInfos[i], portInfos[j]
+		if a.ClusterName != b.ClusterName {
+			return a.ClusterName < b.ClusterName
+		}
+		if a.LoadBalancerName != b.LoadBalancerName {
+			return a.LoadBalancerName < b.LoadBalancerName
+		}
+		return comparePorts(a.HostPort, b.HostPort) < 0
+	})
+}
+
+// comparePorts compares two ports numerically, falling back to a string comparison when either isn't a number
+func comparePorts(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return x - y
+}
+
 // generateURL creates a full URL from host IP, port, and protocol
 func generateURL(hostIP, port string) string {
 	// determine protocol based on port
@@ -342,13 +671,14 @@ func generateURL(hostIP, port string) string {
 
 // displayPortsTable displays port information in table format
 func displayPortsTable(portInfos []LoadBalancerPortInfo, hostIP string) {
-	fmt.Printf("\n🌐 Host IP: %s\n", hostIP)
-	fmt.Println("┌─────────────────┬─────────────────────┬────────────┬───────────────┬──────────┬─────────────────────────────┐")
-	fmt.Println("│ Cluster         │ Load Balancer       │ Host Port  │ Service Port  │ Protocol │ URL                         │")
-	fmt.Println("├─────────────────┼─────────────────────┼────────────┼───────────────┼──────────┼─────────────────────────────┤")
+	out := logger.Output()
+	fmt.Fprintf(out, "\n🌐 Host IP: %s\n", hostIP)
+	fmt.Fprintln(out, "┌─────────────────┬─────────────────────┬────────────┬───────────────┬──────────┬─────────────────────────────┐")
+	fmt.Fprintln(out, "│ Cluster         │ Load Balancer       │ Host Port  │ Service Port  │ Protocol │ URL                         │")
+	fmt.Fprintln(out, "├─────────────────┼─────────────────────┼────────────┼───────────────┼──────────┼─────────────────────────────┤")
 
 	for _, info := range portInfos {
-		fmt.Printf("│ %-15s │ %-19s │ %-10s │ %-13s │ %-8s │ %-27s │\n",
+		fmt.Fprintf(out, "│ %-15s │ %-19s │ %-10s │ %-13s │ %-8s │ %-27s │\n",
 			info.ClusterName,
 			info.LoadBalancerName,
 			info.HostPort,
@@ -358,7 +688,7 @@ func displayPortsTable(portInfos []LoadBalancerPortInfo, hostIP string) {
 		)
 	}
 
-	fmt.Println("└─────────────────┴─────────────────────┴────────────┴───────────────┴──────────┴─────────────────────────────┘")
+	fmt.Fprintln(out, "└─────────────────┴─────────────────────┴────────────┴───────────────┴──────────┴─────────────────────────────┘")
 }
 
 // displayPortsJSON displays port information in JSON format
@@ -382,7 +712,7 @@ func displayPortsJSON(portInfos []LoadBalancerPortInfo, hostIP string) {
 	fmt.Println(string(jsonData))
 }
 
-// DockerContainer represents a Docker container from docker ps output
+// DockerContainer represents a container from docker ps output
 type DockerContainer struct {
 	ID               string `json:"ID"`
 	Image            string `json:"Image"`
@@ -446,8 +776,11 @@ func retryWithTimeout(operation func() (interface{}, error), timeout time.Durati
 		}
 
 		// check if we've exceeded the timeout
-		if time.Since(startTime) > timeout {
-			logger.Warnf("timeout waiting for %s after %v", operationName, timeout)
+		if time.Since(startTime) >= timeout {
+			// a zero timeout is a single look, nothing was waited for
+			if timeout > 0 {
+				logger.Warnf("timeout waiting for %s after %v", operationName, timeout)
+			}
 			return nil, nil // return nil instead of error
 		}
 
@@ -457,32 +790,28 @@ func retryWithTimeout(operation func() (interface{}, error), timeout time.Durati
 	}
 }
 
-// getLoadBalancerContainers gets load balancer containers for a specific cluster
-func getLoadBalancerContainers(clusterName string) ([]DockerContainer, error) {
-	timeout := 60 * time.Second
+// getLoadBalancerContainers gets load balancer containers for a specific cluster, retrying for up to timeout
+// while there are none. A zero timeout looks once
+func getLoadBalancerContainers(runtime, clusterName string, timeout time.Duration) ([]DockerContainer, error) {
 	retryInterval := 2 * time.Second
 
 	operation := func() (interface{}, error) {
-		cmd := exec.Command("docker", "ps", "--filter", "label=io.x-k8s.cloud-provider-kind.cluster", "--format", "json")
-		output, err := cmd.Output()
+		output, err := utilexec.Output(runtime, "ps", "--filter", "label=io.x-k8s.cloud-provider-kind.cluster", "--format", "json")
 		if err != nil {
-			return nil, fmt.Errorf("failed to run docker ps: %w", err)
+			return nil, fmt.Errorf("failed to run %s ps: %w", runtime, err)
 		}
 
-		var containers []DockerContainer
-		lines := strings.Split(string(output), "\n")
-
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" {
-				continue
-			}
-
-			var container DockerContainer
-			if err := json.Unmarshal([]byte(line), &container); err != nil {
-				continue
+		var listed []DockerContainer
+		if runtime == "podman" {
+			if listed, err = parsePodmanContainers(output); err != nil {
+				return nil, err
 			}
+		} else {
+			listed = parseDockerContainers(output)
+		}
 
+		var containers []DockerContainer
+		for _, container := range listed {
 			// check if this is a load balancer container for our cluster
 			if strings.Contains(container.Labels, fmt.Sprintf("io.x-k8s.cloud-provider-kind.cluster=%s", clusterName)) &&
 				strings.Contains(container.Image, "envoy") &&
@@ -514,6 +843,92 @@ func getLoadBalancerContainers(clusterName string) ([]DockerContainer, error) {
 	return result.([]DockerContainer), nil
 }
 
+// parseDockerContainers parses the line-delimited JSON printed by 'docker ps --format json'
+func parseDockerContainers(output []byte) []DockerContainer {
+	var containers []DockerContainer
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var container DockerContainer
+		if err := json.Unmarshal([]byte(line), &container); err != nil {
+			continue
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// PodmanContainer represents a container from podman ps output
+type PodmanContainer struct {
+	ID     string            `json:"Id"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
+	Names  []string          `json:"Names"`
+	Ports  []PodmanPort      `json:"Ports"`
+	State  string            `json:"State"`
+}
+
+// PodmanPort represents a published port range from podman ps output
+type PodmanPort struct {
+	HostIP        string `json:"host_ip"`
+	ContainerPort int    `json:"container_port"`
+	HostPort      int    `json:"host_port"`
+	Range         int    `json:"range"`
+	Protocol      string `json:"protocol"`
+}
+
+// parsePodmanContainers parses the JSON array printed by 'podman ps --format json' and converts
+// each entry to the docker layout so the label and port parsing can be shared
+func parsePodmanContainers(output []byte) ([]DockerContainer, error) {
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return nil, nil
+	}
+
+	var podmanContainers []PodmanContainer
+	if err := json.Unmarshal(output, &podmanContainers); err != nil {
+		return nil, fmt.Errorf("failed to parse podman ps output: %w", err)
+	}
+
+	containers := make([]DockerContainer, 0, len(podmanContainers))
+	for _, pc := range podmanContainers {
+		labels := make([]string, 0, len(pc.Labels))
+		for key, value := range pc.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(labels)
+
+		var ports []string
+		for _, port := range pc.Ports {
+			hostIP := port.HostIP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+
+			portRange := port.Range
+			if portRange < 1 {
+				portRange = 1
+			}
+			for i := 0; i < portRange; i++ {
+				ports = append(ports, fmt.Sprintf("%s->%d/%s", net.JoinHostPort(hostIP, strconv.Itoa(port.HostPort+i)), port.ContainerPort+i, port.Protocol))
+			}
+		}
+
+		containers = append(containers, DockerContainer{
+			ID:     pc.ID,
+			Image:  pc.Image,
+			Labels: strings.Join(labels, ","),
+			Names:  strings.Join(pc.Names, ","),
+			Ports:  strings.Join(ports, ", "),
+			State:  pc.State,
+		})
+	}
+
+	return containers, nil
+}
+
 // extractLoadBalancerName extracts the load balancer name from Docker labels
 func extractLoadBalancerName(labels string) string {
 	// look for pattern: io.x-k8s.cloud-provider-kind.loadbalancer.name=kind1/default/lb-test
@@ -553,20 +968,15 @@ func parsePortMappings(portsStr string) []PortMapping {
 		hostPart := strings.TrimSpace(parts[0])
 		containerPart := strings.TrimSpace(parts[1])
 
-		// parse host part (e.g., "0.0.0.0:49778" or "[::]:49778")
-		var hostPort, ipVersion string
-		if strings.HasPrefix(hostPart, "[::]:") {
-			ipVersion = "IPv6"
-			hostPort = strings.TrimPrefix(hostPart, "[::]:")
-		} else if strings.Contains(hostPart, ":") {
-			ipVersion = "IPv4"
-			hostParts := strings.Split(hostPart, ":")
-			if len(hostParts) > 1 {
-				hostPort = hostParts[1]
-			}
-		} else {
+		// parse host part (e.g., "0.0.0.0:49778", "[::]:49778" or "[fd00::1]:49778")
+		hostIP, hostPort, err := net.SplitHostPort(hostPart)
+		if err != nil {
 			continue
 		}
+		ipVersion := "IPv4"
+		if strings.Contains(hostIP, ":") {
+			ipVersion = "IPv6"
+		}
 
 		// parse container part (e.g., "80/tcp")
 		containerParts := strings.Split(containerPart, "/")
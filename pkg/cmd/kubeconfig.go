@@ -0,0 +1,105 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// kubeconfigCmd prints the kube contexts for a project and optionally exports them to a standalone file
+func kubeconfigCmd() *cobra.Command {
+	var (
+		project string
+		output  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Show or export the kube contexts for a project",
+		Long: `List the kube context names for each cluster in a project. With --output, a standalone
+kubeconfig containing only those contexts is written to the given path`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+
+			// load saved config to get the number of clusters
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+
+			clusters := 1
+			if savedConfig != nil && savedConfig.NumClusters > 0 {
+				clusters = savedConfig.NumClusters
+			}
+
+			if clusters < 1 || clusters > 3 {
+				return fmt.Errorf("number of clusters must be between 1 and 3")
+			}
+
+			contexts := projectContextNames(project, clusters)
+
+			fmt.Printf("Contexts for project %s:\n", project)
+			for _, contextName := range contexts {
+				fmt.Printf("  %s\t(kubectl --context %s)\n", contextName, contextName)
+			}
+
+			if output == "" {
+				return nil
+			}
+
+			if err := k8s.ExportContexts(contexts, output); err != nil {
+				return fmt.Errorf("failed to export kubeconfig: %w", err)
+			}
+
+			logger.Infof("✅ wrote kubeconfig for %d context(s) to %s", len(contexts), output)
+			fmt.Printf("\nexport KUBECONFIG=%s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write a standalone kubeconfig containing only the project's contexts to this path")
+
+	return cmd
+}
+
+// projectContextNames returns the kube context names for a project's clusters
+func projectContextNames(project string, numClusters int) []string {
+	if numClusters == 1 {
+		// if only one cluster, don't add suffix
+		return []string{project}
+	}
+
+	contexts := make([]string, 0, numClusters)
+	for i := 1; i <= numClusters; i++ {
+		contexts = append(contexts, fmt.Sprintf("%s-%d", project, i))
+	}
+	return contexts
+}
@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// kubectlCmd runs kubectl against one of a project's clusters without having to know its context name
+func kubectlCmd() *cobra.Command {
+	var (
+		project      string
+		clusterIndex int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "kubectl -p <project> [--cluster N] -- <kubectl args>",
+		Short: "Run kubectl against a project's cluster",
+		Long: `Run kubectl with --context set to a project's cluster. A single cluster project uses the bare project
+name as its context, a multi-cluster project uses <project>-N and needs --cluster to pick one. Everything
+after -- is passed to kubectl unchanged`,
+		Example: `  lok8s kubectl -p myproject -- get pods -A
+  lok8s kubectl -p myproject --cluster 2 -- get nodes -o wide`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+
+			// load saved config to get the number of clusters
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+			if savedConfig == nil {
+				return fmt.Errorf("no configuration found for project: %s", project)
+			}
+
+			contextName, err := projectContextName(project, savedConfig.NumClusters, clusterIndex)
+			if err != nil {
+				return err
+			}
+
+			kubectlPath, err := exec.LookPath("kubectl")
+			if err != nil {
+				return fmt.Errorf("kubectl not found in PATH: %w", err)
+			}
+
+			kubectl := exec.Command(kubectlPath, append([]string{"--context", contextName}, args...)...)
+			kubectl.Env = k8s.KubeConfigEnv()
+			kubectl.Stdin = os.Stdin
+			kubectl.Stdout = os.Stdout
+			kubectl.Stderr = os.Stderr
+			if err := kubectl.Run(); err != nil {
+				// kubectl has already reported the failure, so only pass its exit code on
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					os.Exit(exitErr.ExitCode())
+				}
+				return fmt.Errorf("failed to run kubectl: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	cmd.Flags().IntVar(&clusterIndex, "cluster", 0, "Cluster (1-N) to run against, required when the project has more than one cluster")
+
+	return cmd
+}
+
+// projectContextName returns the kube context name of one cluster of a project. A cluster index of 0 is only
+// accepted for a single cluster project
+func projectContextName(project string, numClusters, clusterIndex int) (string, error) {
+	if numClusters < 1 {
+		numClusters = 1
+	}
+
+	if clusterIndex == 0 {
+		if numClusters > 1 {
+			return "", fmt.Errorf("project %s has %d clusters, use --cluster to pick one (1-%d)", project, numClusters, numClusters)
+		}
+		clusterIndex = 1
+	}
+	if clusterIndex < 1 || clusterIndex > numClusters {
+		return "", fmt.Errorf("cluster must be between 1 and %d", numClusters)
+	}
+
+	return projectContextNames(project, numClusters)[clusterIndex-1], nil
+}
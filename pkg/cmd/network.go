@@ -0,0 +1,214 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/network"
+	"github.com/day0ops/lok8s/pkg/util/docker"
+)
+
+// networkRow is a network managed by lok8s as shown by network list
+type networkRow struct {
+	name     string
+	kind     string
+	projects []string
+	subnet   string
+	gateway  string
+	active   bool
+}
+
+// networkCmd inspects the libvirt, vmnet and container networks the clusters run on
+func networkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Inspect the networks managed by lok8s",
+		Long: `Inspect the networks the clusters run on: the libvirt <project>-net networks of minikube projects
+on Linux, the shared vmnet network on macOS and the ` + config.KindNetworkName + ` container network of Kind projects`,
+	}
+
+	var project string
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "status"},
+		Short:   "List the networks with their subnet, gateway and state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("network command must not be run as sudo/root")
+			}
+
+			projects, err := networkProjects(project)
+			if err != nil {
+				return err
+			}
+
+			var hostNetworks []network.NetworkInfo
+			if config.IsLinux() || config.IsDarwin() {
+				if hostNetworks, err = network.ListNetworks(config.MinikubeQemuSystem); err != nil {
+					logger.Warnf("⚠️ skipping minikube networks: %v", err)
+				}
+			}
+			var containerNetworks []docker.Network
+			containerRuntime, err := docker.ResolveContainerRuntime("", nil)
+			if err == nil {
+				containerNetworks, err = docker.ListNetworks(containerRuntime)
+			}
+			if err != nil {
+				logger.Warnf("⚠️ skipping container networks: %v", err)
+			}
+
+			rows := managedNetworkRows(hostNetworks, containerNetworks, projects, project != "")
+			if len(rows) == 0 {
+				logger.Info("no networks managed by lok8s found")
+				return nil
+			}
+			printNetworkRows(rows)
+			return nil
+		},
+	}
+	listCmd.Flags().StringVarP(&project, "project", "p", "", "Only list the networks of the project")
+
+	cmd.AddCommand(listCmd)
+
+	return cmd
+}
+
+// networkProjects returns the saved project configs, only the given project when one is set
+func networkProjects(project string) ([]*config.ProjectConfig, error) {
+	if project != "" {
+		savedConfig, err := configManager.LoadConfig(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project config: %w", err)
+		}
+		if savedConfig == nil {
+			return nil, fmt.Errorf("project %s not found", project)
+		}
+		return []*config.ProjectConfig{savedConfig}, nil
+	}
+
+	projectNames, err := configManager.ListConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project configs: %w", err)
+	}
+	var projects []*config.ProjectConfig
+	for _, name := range projectNames {
+		savedConfig, err := configManager.LoadConfig(name)
+		if err != nil {
+			logger.Warnf("⚠️ skipping project %s: %v", name, err)
+			continue
+		}
+		if savedConfig != nil {
+			projects = append(projects, savedConfig)
+		}
+	}
+	return projects, nil
+}
+
+// managedNetworkRows picks the networks lok8s created out of the host and container networks: the libvirt
+// <project>-net network of each minikube project, the shared vmnet network and the kind network or any container
+// network labelled as managed. With onlyProjects the shared networks are left out unless one of the projects
+// uses them
+func managedNetworkRows(hostNetworks []network.NetworkInfo, containerNetworks []docker.Network, projects []*config.ProjectConfig, onlyProjects bool) []networkRow {
+	var minikubeProjects, kindProjects []string
+	for _, project := range projects {
+		if projectEnvironment(project) == "kind" {
+			kindProjects = append(kindProjects, project.Project)
+		} else {
+			minikubeProjects = append(minikubeProjects, project.Project)
+		}
+	}
+
+	var rows []networkRow
+	for _, hostNetwork := range hostNetworks {
+		row := networkRow{
+			name:    hostNetwork.Name,
+			kind:    "libvirt",
+			subnet:  hostNetwork.Subnet,
+			gateway: hostNetwork.Gateway,
+			active:  hostNetwork.Active,
+		}
+		if hostNetwork.Name == config.MinikubeVmnetNetworkName {
+			if onlyProjects && len(minikubeProjects) == 0 {
+				continue
+			}
+			row.kind = "vmnet"
+			row.projects = minikubeProjects
+		} else {
+			project, ok := strings.CutSuffix(hostNetwork.Name, "-net")
+			if !ok || !slices.Contains(minikubeProjects, project) {
+				continue
+			}
+			row.projects = []string{project}
+		}
+		rows = append(rows, row)
+	}
+
+	for _, containerNetwork := range containerNetworks {
+		if containerNetwork.Name != config.KindNetworkName && containerNetwork.Labels[config.ManagedLabel] != "true" {
+			continue
+		}
+		if onlyProjects && len(kindProjects) == 0 {
+			continue
+		}
+		// container networks have no state, they exist as long as they are usable
+		rows = append(rows, networkRow{
+			name:     containerNetwork.Name,
+			kind:     "container",
+			projects: kindProjects,
+			subnet:   containerNetwork.Subnet,
+			gateway:  containerNetwork.Gateway,
+			active:   true,
+		})
+	}
+
+	slices.SortFunc(rows, func(a, b networkRow) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return rows
+}
+
+// printNetworkRows prints the networks as a table
+func printNetworkRows(rows []networkRow) {
+	w := tabwriter.NewWriter(logger.Output(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tPROJECTS\tSUBNET\tGATEWAY\tACTIVE")
+	fmt.Fprintln(w, "----\t----\t--------\t------\t-------\t------")
+	for _, row := range rows {
+		active := "no"
+		if row.active {
+			active = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.name, row.kind, orDash(strings.Join(row.projects, ",")), orDash(row.subnet), orDash(row.gateway), active)
+	}
+	w.Flush()
+}
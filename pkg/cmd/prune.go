@@ -0,0 +1,320 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/cluster/kind"
+	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util/docker"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// pruneCandidate is a resource left behind that isn't tied to a live cluster
+type pruneCandidate struct {
+	description string
+	remove      func() error
+}
+
+// pruneCmd finds the resources left behind by interrupted or partial runs and removes them with --force
+func pruneCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Clean up resources not tied to a live cluster",
+		Long: `Cross-reference the existing Kind clusters and minikube profiles against the saved project configs
+and report what an interrupted create or delete left behind: project configs whose clusters are all gone,
+kubeconfig contexts of missing clusters, libvirt networks of those projects, the Kind registry containers
+and network once no Kind cluster is left, and stale cloud-provider-kind entries. Nothing is removed
+unless --force is given`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("prune command must not be run as sudo/root")
+			}
+
+			candidates, err := findPruneCandidates()
+			if err != nil {
+				return err
+			}
+			if len(candidates) == 0 {
+				logger.Info("✓ nothing to prune")
+				return nil
+			}
+
+			logger.Infof("🧹 found %d resource(s) not tied to a live cluster:", len(candidates))
+			for _, candidate := range candidates {
+				logger.Infof("  - %s", candidate.description)
+			}
+			if !force {
+				logger.Info("run again with --force to remove them")
+				return nil
+			}
+
+			var errs []error
+			for _, candidate := range candidates {
+				if err := candidate.remove(); err != nil {
+					logger.Errorf("❌ failed to remove %s: %v", candidate.description, err)
+					errs = append(errs, err)
+					continue
+				}
+				logger.Infof("✓ removed %s", candidate.description)
+			}
+			if len(errs) > 0 {
+				return fmt.Errorf("failed to remove %d resource(s): %w", len(errs), errors.Join(errs...))
+			}
+			logger.Infof("✅ pruned %d resource(s)", len(candidates))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Remove the resources found instead of only reporting them")
+
+	return cmd
+}
+
+// kindClusterOwners returns the project of each kind cluster whose nodes carry one. kind cluster names aren't unique
+// to a project, the project label on the nodes is. The API servers are asked concurrently so an unreachable
+// cluster only holds prune up for a single timeout
+func kindClusterOwners(kindManager *kind.Manager, clusters []string) map[string]string {
+	owners := make(map[string]string, len(clusters))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, clusterName := range clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			owner, err := kindManager.ClusterProject(clusterName)
+			if err != nil {
+				logger.Debugf("failed to get the project of cluster %s: %v", clusterName, err)
+				return
+			}
+			if owner != "" {
+				mu.Lock()
+				owners[clusterName] = owner
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return owners
+}
+
+// findPruneCandidates collects the live clusters, saved projects and kubeconfig contexts and returns what
+// can be removed. An environment whose clusters can't be listed is left alone
+func findPruneCandidates() ([]pruneCandidate, error) {
+	projectNames, err := configManager.ListConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project configs: %w", err)
+	}
+	var projects []*config.ProjectConfig
+	for _, name := range projectNames {
+		savedConfig, err := configManager.LoadConfig(name)
+		if err != nil {
+			logger.Warnf("⚠️ skipping project %s: %v", name, err)
+			continue
+		}
+		if savedConfig != nil {
+			projects = append(projects, savedConfig)
+		}
+	}
+
+	liveClusters := map[string][]string{}
+	var owners map[string]string
+	kindManager := kind.NewManager()
+	if clusters, err := kindManager.ClusterNames(); err != nil {
+		logger.Warnf("⚠️ skipping Kind resources: %v", err)
+	} else {
+		liveClusters["kind"] = clusters
+		owners = kindClusterOwners(kindManager, clusters)
+	}
+	minikubeManager := minikube.NewManager()
+	if profiles, err := minikubeManager.ProfileNames(); err != nil {
+		logger.Warnf("⚠️ skipping minikube resources: %v", err)
+	} else {
+		liveClusters["minikube"] = profiles
+	}
+
+	contexts, err := k8s.ListContexts()
+	if err != nil {
+		logger.Warnf("⚠️ skipping kubeconfig contexts: %v", err)
+	}
+
+	staleProjects, orphanedContexts := planPrune(projects, liveClusters, owners, contexts)
+
+	var candidates []pruneCandidate
+	for _, project := range staleProjects {
+		description := fmt.Sprintf("project config %s (%s)", project.Project, projectEnvironment(project))
+		if projectEnvironment(project) == "minikube" && config.IsLinux() {
+			description = fmt.Sprintf("project config and libvirt network %s-net of %s (minikube)", project.Project, project.Project)
+		}
+		candidates = append(candidates, pruneCandidate{
+			description: description,
+			remove: func() error {
+				if projectEnvironment(project) == "minikube" {
+					if err := minikubeManager.DeleteProjectNetwork(project.Project); err != nil {
+						return err
+					}
+				}
+				return configManager.DeleteConfig(project.Project)
+			},
+		})
+	}
+	for _, contextName := range orphanedContexts {
+		candidates = append(candidates, pruneCandidate{
+			description: fmt.Sprintf("kubeconfig context %s", contextName),
+			remove: func() error {
+				return k8s.DeleteContext(contextName)
+			},
+		})
+	}
+
+	// the registry containers and the kind network are shared, only prune them once no Kind cluster is left
+	if clusters, ok := liveClusters["kind"]; ok && len(clusters) == 0 {
+		candidates = append(candidates, kindSharedPruneCandidates(projects)...)
+	}
+
+	cloudProviderManager := services.NewCloudProviderKindManager()
+	staleProcesses, err := cloudProviderManager.StaleProcesses()
+	if err != nil {
+		logger.Warnf("⚠️ skipping cloud-provider-kind processes: %v", err)
+	} else if len(staleProcesses) > 0 {
+		candidates = append(candidates, pruneCandidate{
+			description: fmt.Sprintf("%d stale cloud-provider-kind process entry(ies)", len(staleProcesses)),
+			remove: func() error {
+				_, err := cloudProviderManager.PruneStaleProcesses()
+				return err
+			},
+		})
+	}
+
+	return candidates, nil
+}
+
+// kindSharedPruneCandidates returns the registry containers and the kind network that still exist
+func kindSharedPruneCandidates(projects []*config.ProjectConfig) []pruneCandidate {
+	customMirrors := map[string]string{}
+	for _, project := range projects {
+		for host, endpoint := range project.RegistryMirrors {
+			customMirrors[host] = endpoint
+		}
+	}
+
+	containerRuntime, err := docker.ResolveContainerRuntime("", nil)
+	if err != nil {
+		logger.Debugf("skipping registry containers and network %s: %v", config.KindNetworkName, err)
+		return nil
+	}
+
+	var candidates []pruneCandidate
+	for _, containerName := range kind.RegistryContainerNames(customMirrors) {
+		state, err := docker.InspectContainer(containerRuntime, containerName)
+		if err != nil {
+			logger.Debugf("failed to inspect container %s: %v", containerName, err)
+			continue
+		}
+		if !state.Exists {
+			continue
+		}
+		candidates = append(candidates, pruneCandidate{
+			description: fmt.Sprintf("registry container %s", containerName),
+			remove: func() error {
+				return docker.DeleteRegistryContainers(containerRuntime, []string{containerName})
+			},
+		})
+	}
+
+	exists, err := docker.NetworkExists(containerRuntime, config.KindNetworkName)
+	if err != nil {
+		logger.Debugf("failed to check network %s: %v", config.KindNetworkName, err)
+	} else if exists {
+		candidates = append(candidates, pruneCandidate{
+			description: fmt.Sprintf("container network %s", config.KindNetworkName),
+			remove: func() error {
+				return docker.DeleteNetwork(containerRuntime, config.KindNetworkName)
+			},
+		})
+	}
+
+	return candidates
+}
+
+// planPrune returns the projects none of whose clusters exist and the kubeconfig contexts of every missing
+// cluster. liveClusters maps an environment to its existing cluster names, projects of an environment
+// missing from it are skipped. owners maps a cluster name to the project labelled on its nodes, a cluster
+// labelled with another project doesn't count as a cluster of the project
+func planPrune(projects []*config.ProjectConfig, liveClusters map[string][]string, owners map[string]string, contexts []string) ([]*config.ProjectConfig, []string) {
+	var (
+		staleProjects    []*config.ProjectConfig
+		orphanedContexts []string
+	)
+	for _, project := range projects {
+		env := projectEnvironment(project)
+		live, ok := liveClusters[env]
+		if !ok {
+			continue
+		}
+
+		numClusters := max(project.NumClusters, 1)
+		contextNames := projectContextNames(project.Project, numClusters)
+		missing := 0
+		for i := 1; i <= numClusters; i++ {
+			clusterName := contextNames[i-1]
+			if env == "kind" {
+				clusterName = kind.ClusterName(project.ClusterPrefix, i, numClusters)
+			}
+			if owner := owners[clusterName]; slices.Contains(live, clusterName) && (owner == "" || owner == project.Project) {
+				continue
+			}
+			missing++
+			if slices.Contains(contexts, contextNames[i-1]) {
+				orphanedContexts = append(orphanedContexts, contextNames[i-1])
+			}
+		}
+		if missing == numClusters {
+			staleProjects = append(staleProjects, project)
+		}
+	}
+
+	return staleProjects, orphanedContexts
+}
+
+// projectEnvironment returns the environment of a saved project, minikube when none was saved
+func projectEnvironment(project *config.ProjectConfig) string {
+	if project.Environment == "" {
+		return "minikube"
+	}
+	return project.Environment
+}
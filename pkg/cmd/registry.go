@@ -0,0 +1,131 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/config"
+)
+
+// registryCmd manages the shared kind registry and its mirrors
+func registryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Manage the shared Kind registry and mirrors",
+		Long: `Manage the lifecycle of the shared ` + config.KindRegistryName + ` container and the registry mirror
+containers (docker, gcr, quay, etc.) used by Kind clusters`,
+	}
+
+	var (
+		project string
+		port    int
+		tls     bool
+	)
+
+	// registryConfig returns the registry settings saved for the project, empty when no project was given
+	registryConfig := func() (*config.ProjectConfig, error) {
+		if project == "" {
+			return &config.ProjectConfig{}, nil
+		}
+		savedConfig, err := configManager.LoadConfig(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project config: %w", err)
+		}
+		if savedConfig == nil {
+			return nil, fmt.Errorf("project %s not found", project)
+		}
+		if port == 0 {
+			port = savedConfig.RegistryPort
+		}
+		return savedConfig, nil
+	}
+
+	// start command
+	startCmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the registry and mirror containers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("registry command must not be run as sudo/root")
+			}
+
+			savedConfig, err := registryConfig()
+			if err != nil {
+				return err
+			}
+
+			manager := newKindManager(savedConfig)
+			return manager.StartRegistry(port, savedConfig.RegistryMirrors, savedConfig.RegistryAuth, tls || savedConfig.RegistryTLS)
+		},
+	}
+	startCmd.Flags().BoolVar(&tls, "tls", false, "Serve the registry over https with a self-signed certificate, the default for projects created with --registry-tls")
+	startCmd.Flags().IntVar(&port, "port", 0, "Host port for the registry. If not specified, tries 5000 and falls back to an available port above 30000")
+
+	// stop command
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop and remove the registry and mirror containers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("registry command must not be run as sudo/root")
+			}
+
+			savedConfig, err := registryConfig()
+			if err != nil {
+				return err
+			}
+
+			manager := newKindManager(savedConfig)
+			return manager.StopRegistry(savedConfig.RegistryMirrors)
+		},
+	}
+
+	// status command
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the state of the registry and mirror containers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			savedConfig, err := registryConfig()
+			if err != nil {
+				return err
+			}
+
+			manager := newKindManager(savedConfig)
+			return manager.RegistryStatus(savedConfig.RegistryMirrors)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&project, "project", "p", "", "Project name, used to include the project's custom registry mirrors")
+
+	cmd.AddCommand(startCmd)
+	cmd.AddCommand(stopCmd)
+	cmd.AddCommand(statusCmd)
+
+	return cmd
+}
@@ -0,0 +1,129 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util/helm"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+// resetCmd removes the add-ons lok8s installed from a project's clusters without deleting the clusters
+func resetCmd() *cobra.Command {
+	var (
+		project   string
+		removeCNI bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Remove the managed add-ons from a project's clusters",
+		Long: `Uninstall MetalLB along with its IPAddressPool and L2Advertisement, and the metrics-server Helm
+release, from every cluster in a project, leaving the clusters themselves running. With --cni, the Cilium
+and Calico Helm releases are removed as well. Add-ons minikube installs itself (its metrics-server addon and
+built-in Calico) are left as they are`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("reset command must not be run as sudo/root")
+			}
+
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+			if savedConfig == nil {
+				return fmt.Errorf("project %s not found", project)
+			}
+
+			clusters := 1
+			if savedConfig.NumClusters > 0 {
+				clusters = savedConfig.NumClusters
+			}
+
+			return resetClusters(projectContextNames(project, clusters), removeCNI)
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	cmd.Flags().BoolVar(&removeCNI, "cni", false, "Also uninstall Cilium and Calico. Pods lose networking until a CNI is installed again")
+
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		logger.Warnf("failed to mark project flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+// resetClusters uninstalls the managed add-ons from each cluster, carrying on with the rest when one fails
+func resetClusters(contexts []string, removeCNI bool) error {
+	kubeconfigPath, err := k8s.GetKubeConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+	helmManager := helm.NewHelmManager(kubeconfigPath)
+	metallbManager := services.NewMetalLBManager(helmManager)
+	metricsServerManager := services.NewMetricsServerManager(helmManager)
+	ciliumManager := services.NewCiliumManager(helmManager, nil)
+	calicoManager := services.NewCalicoManager(helmManager, nil)
+
+	var errs []error
+	for _, contextName := range contexts {
+		if err := metallbManager.Uninstall(contextName); err != nil {
+			logger.Errorf("❌ failed to reset cluster %s: %v", contextName, err)
+			errs = append(errs, err)
+			continue
+		}
+		if err := metricsServerManager.Uninstall(contextName); err != nil {
+			logger.Errorf("❌ failed to reset cluster %s: %v", contextName, err)
+			errs = append(errs, err)
+			continue
+		}
+		if removeCNI {
+			if err := ciliumManager.Uninstall(contextName); err != nil {
+				logger.Errorf("❌ failed to reset cluster %s: %v", contextName, err)
+				errs = append(errs, err)
+				continue
+			}
+			if err := calicoManager.Uninstall(contextName); err != nil {
+				logger.Errorf("❌ failed to reset cluster %s: %v", contextName, err)
+				errs = append(errs, err)
+				continue
+			}
+		}
+		logger.Infof("✅ reset cluster %s", contextName)
+	}
+
+	return errors.Join(errs...)
+}
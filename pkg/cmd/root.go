@@ -23,10 +23,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/day0ops/lok8s/pkg/util/docker"
 	"github.com/sirupsen/logrus"
@@ -35,14 +42,21 @@ import (
 
 	"github.com/day0ops/lok8s/pkg/cluster/kind"
 	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/cluster/report"
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/services"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
 )
 
 var (
 	cfgFile       string
 	verbose       bool
 	environment   string
+	dryRun        bool
+	kubeconfig    string
+	logFormat     string
+	quiet         bool
 	configManager *config.ConfigManager
 )
 
@@ -80,24 +94,41 @@ func init() {
 	// global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (YAML format, can be located anywhere)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose logging")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logger.FormatText, "log format (text or json), json logs have no spinner and tables go to stderr")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "only log warnings and errors, without the spinner and tables")
 	rootCmd.PersistentFlags().StringVarP(&environment, "environment", "e", "minikube", "environment to use (minikube or kind)")
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "kubeconfig file to add the cluster contexts to and use, instead of KUBECONFIG or ~/.kube/config")
 
 	// add subcommands
 	rootCmd.AddCommand(createCmd())
 	rootCmd.AddCommand(deleteCmd())
 	rootCmd.AddCommand(statusCmd())
+	rootCmd.AddCommand(startCmd())
+	rootCmd.AddCommand(stopCmd())
+	rootCmd.AddCommand(scaleCmd())
 	rootCmd.AddCommand(profileListCmd())
 	rootCmd.AddCommand(imageLoadCmd())
 	rootCmd.AddCommand(configCmd())
 	rootCmd.AddCommand(versionCmd())
+	rootCmd.AddCommand(k8sVersionsCmd())
 	rootCmd.AddCommand(kindTunnelCmd())
+	rootCmd.AddCommand(registryCmd())
+	rootCmd.AddCommand(networkCmd())
+	rootCmd.AddCommand(kubeconfigCmd())
+	rootCmd.AddCommand(kubectlCmd())
+	rootCmd.AddCommand(addonsCmd())
+	rootCmd.AddCommand(resetCmd())
+	rootCmd.AddCommand(doctorCmd())
+	rootCmd.AddCommand(pruneCmd())
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
 		// use config file from the flag.
-		viper.SetConfigFile(cfgFile)
+		path, err := config.ExpandPath(cfgFile)
+		cobra.CheckErr(err)
+		viper.SetConfigFile(path)
 	} else {
 		// find home directory.
 		home, err := os.UserHomeDir()
@@ -119,23 +150,122 @@ func initConfig() {
 
 func initializeConfig() error {
 	// initialize logger
+	if verbose && quiet {
+		return fmt.Errorf("--verbose can't be combined with --quiet")
+	}
 	if verbose {
 		logger.SetLevel(logrus.DebugLevel)
 	} else {
 		logger.SetLevel(logrus.InfoLevel)
 	}
+	if err := logger.SetFormat(logFormat); err != nil {
+		return err
+	}
+	logger.SetQuiet(quiet)
+
+	// route every kubeconfig, client and Helm operation to the given kubeconfig
+	if kubeconfig != "" {
+		path, err := config.ExpandPath(kubeconfig)
+		if err != nil {
+			return err
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig path %s: %w", kubeconfig, err)
+		}
+		k8s.SetKubeConfigPath(absPath)
+	}
 
 	return nil
 }
 
 func versionCmd() *cobra.Command {
-	return &cobra.Command{
+	var check bool
+
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the version information",
+		Long: `Print the version information. With --check, compare lok8s and the tools it manages (minikube,
+cloud-provider-kind and on macOS vfkit and vmnet-helper) against their latest GitHub releases`,
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Printf(config.AppName+" version %s\n", config.GetVersion())
+			if !check {
+				return
+			}
+
+			fmt.Println()
+			if printVersionCheck(logger.Output(), checkVersions()) {
+				logger.Infof("💡 newer releases are available")
+			}
 		},
 	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Check GitHub for newer releases of lok8s and the tools it manages")
+	return cmd
+}
+
+// k8sVersionsCmd lists the Kubernetes versions supported by each environment
+func k8sVersionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "versions",
+		Short: "List supported Kubernetes versions",
+		Long: `List the Kubernetes minor versions accepted by --kubernetes-version for each environment.
+Use --environment to only show a single environment`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			environments := []string{"kind", "minikube"}
+			if cmd.Flags().Changed("environment") {
+				if environment != "kind" && environment != "minikube" {
+					return fmt.Errorf("invalid environment: %s", environment)
+				}
+				environments = []string{environment}
+			}
+
+			for i, env := range environments {
+				versions := config.KindK8sVersions
+				if env == "minikube" {
+					versions = config.MinikubeK8sVersions
+				}
+				minors := config.SupportedK8sVersions(versions)
+
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s:\n", env)
+				if len(minors) > 0 {
+					fmt.Printf("  %-8s -> %s\n", "stable", minors[0])
+				}
+				for _, minor := range minors {
+					// strip the image digest from kind node images
+					fmt.Printf("  %-8s    %s\n", minor, strings.Split(versions[minor], "@")[0])
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// validateClusterCount checks the --num of a create. Validate skips an unset (zero) count of partial configs
+// and the merge keeps the saved count over a zero one, so -n 0 has to be rejected before either
+func validateClusterCount(numClusters int) error {
+	if numClusters < 1 || numClusters > config.MaxClusterNum {
+		return fmt.Errorf("number of clusters must be between 1 and %d, got %d", config.MaxClusterNum, numClusters)
+	}
+	return nil
+}
+
+// absoluteMounts returns the --mount values with absolute host directories, they are saved with the project
+// and have to resolve the same way from any directory
+func absoluteMounts(mounts []string) ([]string, error) {
+	resolved := make([]string, 0, len(mounts))
+	for _, mount := range mounts {
+		hostPath, containerPath, err := config.ParseMount(mount)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, hostPath+":"+containerPath)
+	}
+	return resolved, nil
 }
 
 // createCmd creates clusters using the specified environment
@@ -148,15 +278,56 @@ func createCmd() *cobra.Command {
 		memory               string
 		disk                 string
 		subnetCIDR           string
+		podCIDR              string
+		serviceCIDR          string
+		ipFamily             string
+		clusterPrefix        string
+		portMappings         []string
 		numClusters          int
 		nodeCount            int
+		ha                   bool
 		k8sVersion           string
+		nodeImage            string
 		skipMetalLB          bool
+		metallbPoolSize      int
+		metallbIPRange       string
+		metallbMode          string
+		metallbPeerAddress   string
+		metallbPeerASN       int
+		metallbASN           int
 		installCloudProvider bool
 		cni                  string
 		containerRuntime     string
 		containerEngine      string
+		enginePreference     []string
+		helmSet              []string
+		mounts               []string
+		nodeGPUs             int
+		storageClass         string
+		registryMirrorHosts  []string
+		noRegistryMirrors    bool
+		registryAuth         []string
+		registryTLS          bool
+		prefetchImages       []string
+		applySources         []string
+		ciliumChartVersion   string
+		ciliumChart          string
+		metallbChart         string
+		ciliumValuesFile     string
+		ciliumClusterMesh    bool
+		metallbValuesFile    string
+		metallbChartVersion  string
+		cloudProviderVersion string
 		recreate             bool
+		assumeYes            bool
+		parallel             bool
+		subnetSearchLimit    int
+		waitTimeout          time.Duration
+		enableCSI            bool
+		enableMetricsServer  bool
+		artifactsDir         string
+		timeout              time.Duration
+		cleanupOnFailure     bool
 	)
 
 	cmd := &cobra.Command{
@@ -174,25 +345,117 @@ func createCmd() *cobra.Command {
 				return fmt.Errorf("project name is required")
 			}
 
+			if err := validateClusterCount(numClusters); err != nil {
+				return err
+			}
+
+			if waitTimeout <= 0 {
+				return fmt.Errorf("wait timeout must be greater than zero")
+			}
+			if timeout < 0 {
+				return fmt.Errorf("timeout can't be negative")
+			}
+
+			// the values files are saved with the project, so keep them usable from any directory
+			for _, valuesFile := range []*string{&ciliumValuesFile, &metallbValuesFile} {
+				if *valuesFile == "" {
+					continue
+				}
+				absPath, err := filepath.Abs(*valuesFile)
+				if err != nil {
+					return fmt.Errorf("invalid values file %s: %w", *valuesFile, err)
+				}
+				*valuesFile = absPath
+			}
+			for _, chartPath := range []*string{&ciliumChart, &metallbChart} {
+				if *chartPath == "" {
+					continue
+				}
+				if _, err := os.Stat(*chartPath); err != nil {
+					return fmt.Errorf("invalid chart %s: %w", *chartPath, err)
+				}
+				absPath, err := filepath.Abs(*chartPath)
+				if err != nil {
+					return fmt.Errorf("invalid chart %s: %w", *chartPath, err)
+				}
+				*chartPath = absPath
+			}
+			if len(mounts) > 0 {
+				absMounts, err := absoluteMounts(mounts)
+				if err != nil {
+					return err
+				}
+				mounts = absMounts
+			}
+
+			// keep the generated kind configs and CNI manifests to reproduce a failed create
+			if artifactsDir != "" {
+				path, err := config.ExpandPath(artifactsDir)
+				if err != nil {
+					return err
+				}
+				absPath, err := filepath.Abs(path)
+				if err != nil {
+					return fmt.Errorf("invalid artifacts directory %s: %w", artifactsDir, err)
+				}
+				config.SetArtifactsDir(absPath)
+			}
+
+			// credentials of the mirror upstreams, $VAR references are kept so the secrets aren't saved
+			parsedRegistryAuth, err := config.ParseRegistryAuth(registryAuth)
+			if err != nil {
+				return err
+			}
+
 			// create command config from flags
 			cmdConfig := &config.ProjectConfig{
-				Project:              project,
-				Environment:          environment,
-				NumClusters:          numClusters,
-				NodeCount:            nodeCount,
-				K8sVersion:           k8sVersion,
-				GatewayIP:            gatewayIP,
-				SubnetCIDR:           subnetCIDR,
-				Bridge:               bridge,
-				CPU:                  cpu,
-				Memory:               memory,
-				DiskSize:             disk,
-				CNI:                  cni,
-				ContainerRuntime:     containerRuntime,
-				ContainerEngine:      containerEngine,
-				InstallMetalLB:       !skipMetalLB,
-				InstallCloudProvider: installCloudProvider,
-				SkipMetalLB:          skipMetalLB,
+				Project:                   project,
+				Environment:               environment,
+				NumClusters:               numClusters,
+				NodeCount:                 nodeCount,
+				HA:                        ha,
+				K8sVersion:                k8sVersion,
+				NodeImage:                 nodeImage,
+				GatewayIP:                 gatewayIP,
+				SubnetCIDR:                subnetCIDR,
+				PodSubnet:                 podCIDR,
+				ServiceSubnet:             serviceCIDR,
+				Bridge:                    bridge,
+				CPU:                       cpu,
+				Memory:                    memory,
+				DiskSize:                  disk,
+				CNI:                       cni,
+				ContainerRuntime:          containerRuntime,
+				ContainerEngine:           containerEngine,
+				ContainerEnginePreference: enginePreference,
+				HelmSet:                   helmSet,
+				CiliumChartVersion:        ciliumChartVersion,
+				CiliumChart:               ciliumChart,
+				MetalLBChart:              metallbChart,
+				CiliumValuesFile:          ciliumValuesFile,
+				CiliumClusterMesh:         ciliumClusterMesh,
+				MetalLBValuesFile:         metallbValuesFile,
+				MetalLBChartVersion:       metallbChartVersion,
+				CloudProviderVersion:      cloudProviderVersion,
+				InstallMetalLB:            !skipMetalLB,
+				InstallCloudProvider:      installCloudProvider,
+				SkipMetalLB:               skipMetalLB,
+				MetalLBPoolSize:           metallbPoolSize,
+				MetalLBIPRange:            metallbIPRange,
+				MetalLBMode:               metallbMode,
+				MetalLBPeerAddress:        metallbPeerAddress,
+				MetalLBPeerASN:            metallbPeerASN,
+				MetalLBASN:                metallbASN,
+				ExtraPortMappings:         portMappings,
+				Mounts:                    mounts,
+				NodeGPUs:                  nodeGPUs,
+				StorageClass:              storageClass,
+				RegistryMirrorHosts:       registryMirrorHosts,
+				NoRegistryMirrors:         noRegistryMirrors,
+				RegistryAuth:              parsedRegistryAuth,
+				RegistryTLS:               registryTLS,
+				PrefetchImages:            prefetchImages,
+				ClusterPrefix:             clusterPrefix,
 			}
 
 			// load user-defined config file if specified
@@ -213,86 +476,182 @@ func createCmd() *cobra.Command {
 				return fmt.Errorf("failed to load project config: %w", err)
 			}
 
-			// auto determine the container engine if one isn't determined
-			if finalConfig.Environment == "kind" && finalConfig.ContainerEngine == "" {
-				engine, err := docker.GetContainerRuntime()
-				if err != nil {
-					return fmt.Errorf("failed to get container runtime: %w", err)
-				}
-				finalConfig.ContainerEngine = engine
+			// explicit addon flags win over the config file and saved config, otherwise those are kept
+			if cmd.Flags().Changed("enable-csi") {
+				finalConfig.SkipCSI = !enableCSI
+			}
+			if cmd.Flags().Changed("enable-metrics-server") {
+				finalConfig.SkipMetricsServer = !enableMetricsServer
+			}
+			if cmd.Flags().Changed("ip-family") {
+				finalConfig.IPFamily = ipFamily
+			}
+			// --node-image="" drops a node image saved or set in the config file, going back to the version lookup
+			if cmd.Flags().Changed("node-image") {
+				finalConfig.NodeImage = nodeImage
 			}
 
-			// validate merged config
-			if finalConfig.NumClusters < 1 || finalConfig.NumClusters > 3 {
-				return fmt.Errorf("number of clusters must be between 1 and 3")
+			// validate merged config, only kind clusters can be ipv6 or dual-stack
+			if finalConfig.IPFamily == "" {
+				finalConfig.IPFamily = config.IPFamilyIPv4
+			}
+			if err := finalConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration: %w", err)
 			}
 
-			// validate container runtime
-			validRuntimes := []string{"containerd", "cri-o", "docker"}
-			isValidRuntime := false
-			for _, runtime := range validRuntimes {
-				if finalConfig.ContainerRuntime == runtime {
-					isValidRuntime = true
-					break
-				}
+			if subnetSearchLimit < 1 {
+				return fmt.Errorf("--subnet-search-limit must be at least 1, got %d", subnetSearchLimit)
 			}
-			if !isValidRuntime {
-				return fmt.Errorf("invalid container runtime: %s. Valid options are: %s", finalConfig.ContainerRuntime, strings.Join(validRuntimes, ", "))
+
+			if finalConfig.ClusterPrefix != "" && finalConfig.Environment != "kind" {
+				logger.Warnf("⚠️ --cluster-prefix is only supported for Kind, ignoring it")
 			}
 
-			// validate CNI
-			validCNIs := []string{"calico", "cilium", "flannel", "kindnet"}
-			isValidCNI := false
-			for _, cniOption := range validCNIs {
-				if finalConfig.CNI == cniOption {
-					isValidCNI = true
-					break
+			// an explicit node image takes precedence over the kubernetes version
+			if finalConfig.NodeImage != "" {
+				if finalConfig.Environment != "kind" {
+					logger.Warnf("⚠️ --node-image is only supported for Kind, ignoring it")
+				} else if cmd.Flags().Changed("kubernetes-version") {
+					logger.Warnf("⚠️ both --kubernetes-version and --node-image were given, using node image %s", finalConfig.NodeImage)
 				}
 			}
-			if !isValidCNI {
-				return fmt.Errorf("invalid CNI: %s. Valid options are: %s", finalConfig.CNI, strings.Join(validCNIs, ", "))
-			}
 
-			// validate kind container engine if specified
-			if finalConfig.Environment == "kind" && finalConfig.ContainerEngine != "" {
-				validKindEngines := []string{"docker", "podman"}
-				isValidKindEngine := false
-				for _, engine := range validKindEngines {
-					if finalConfig.ContainerEngine == engine {
-						isValidKindEngine = true
-						break
-					}
+			// the cpu and memory defaults are sized for minikube VMs, kind node containers are only limited when asked to
+			// with a flag, the config file or the saved config
+			if finalConfig.Environment == "kind" {
+				if cmd.Flags().Changed("disk") {
+					logger.Warnf("⚠️ --disk is only supported for Minikube, ignoring it")
+				}
+			} else {
+				if finalConfig.CPU == "" {
+					finalConfig.CPU = config.MinikubeCPU
 				}
-				if !isValidKindEngine {
-					return fmt.Errorf("invalid container engine: %s. Valid options are: %s", finalConfig.ContainerEngine, strings.Join(validKindEngines, ", "))
+				if finalConfig.Memory == "" {
+					finalConfig.Memory = config.MinikubeMemory
 				}
 			}
 
+			// projects can't share a libvirt bridge, so catch a taken one before libvirt fails on it
+			if finalConfig.Environment == "minikube" && config.IsLinux() {
+				if err := checkBridgeAvailable(configManager, project, finalConfig.Bridge); err != nil {
+					return err
+				}
+			}
+
+			bootstrapManifests, err := readBootstrapManifests(applySources)
+			if err != nil {
+				return err
+			}
+
+			// the deadline covers provisioning the clusters and their add-ons
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			// a failed first create that was rolled back should not leave behind its in-progress project config
+			_, statErr := os.Stat(configManager.GetConfigPath(project))
+			hadConfig := statErr == nil
+
 			if finalConfig.Environment == "minikube" {
-				return createMinikubeClusters(finalConfig, configManager)
+				err = createMinikubeClusters(ctx, finalConfig, parallel, cleanupOnFailure, subnetSearchLimit, waitTimeout, configManager)
 			} else if finalConfig.Environment == "kind" {
-				return createKindClusters(finalConfig, recreate, configManager)
+				// left unset so Cilium and Calico keep their longer default
+				kindWaitTimeout := waitTimeout
+				if !cmd.Flags().Changed("wait-timeout") {
+					kindWaitTimeout = 0
+				}
+				err = createKindClusters(ctx, finalConfig, recreate, assumeYes, parallel, cleanupOnFailure, kindWaitTimeout, configManager)
+			} else {
+				return fmt.Errorf("invalid environment: %s", finalConfig.Environment)
+			}
+			if err != nil && cleanupOnFailure && !hadConfig && !dryRun {
+				if deleteErr := configManager.DeleteConfig(project); deleteErr != nil {
+					logger.Warnf("failed to delete the config of the failed project %s: %v", project, deleteErr)
+				}
 			}
-			return fmt.Errorf("invalid environment: %s", finalConfig.Environment)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("create timed out after %s: %w", timeout, err)
+			}
+			if err != nil || dryRun {
+				return err
+			}
+
+			// the clusters are usable without the prefetched images, so a failed prefetch doesn't fail the create
+			if len(finalConfig.PrefetchImages) > 0 {
+				if err := prefetchProjectImages(finalConfig); err != nil {
+					logger.Warnf("⚠️ failed to prefetch images: %v", err)
+				}
+			}
+
+			// the manifests go in once the CNI and load balancer are up
+			return applyBootstrapManifests(projectContextNames(finalConfig.Project, finalConfig.NumClusters), bootstrapManifests)
 		},
 	}
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
 	cmd.Flags().StringVarP(&bridge, "bridge", "b", config.MinikubeDefaultBridgeNetName, "Bridge name (Minikube on Linux only)")
 	cmd.Flags().StringVarP(&gatewayIP, "gateway-ip", "g", config.KindNetworkGatewayIP, "Gateway IP address (Kind only). If not specified will automatically determine from the given network subnet")
-	cmd.Flags().StringVarP(&cpu, "cpu", "c", config.MinikubeCPU, "Number of CPUs to allocate (Minikube only)")
-	cmd.Flags().StringVarP(&memory, "memory", "m", config.MinikubeMemory, "Amount of memory to allocate (Minikube only)")
+	cmd.Flags().StringVarP(&cpu, "cpu", "c", "", fmt.Sprintf("Number of CPUs to allocate (Minikube VMs, %s by default, or a limit for each Kind node container)", config.MinikubeCPU))
+	cmd.Flags().StringVarP(&memory, "memory", "m", "", fmt.Sprintf("Amount of memory to allocate (Minikube VMs, %s by default, or a limit for each Kind node container)", config.MinikubeMemory))
 	cmd.Flags().StringVarP(&disk, "disk", "d", config.MinikubeDiskSize, "Amount of disk space to allocate (Minikube only)")
 	cmd.Flags().StringVarP(&subnetCIDR, "subnet-cidr", "s", config.DefaultNetworkSubnetCIDR, "Subnet CIDR for the network (Linux & Minikube only)")
+	cmd.Flags().IntVar(&subnetSearchLimit, "subnet-search-limit", config.DefaultSubnetSearchLimit, "Maximum number of subnets to try when the subnet CIDR is already in use (Linux & Minikube only)")
+	cmd.Flags().StringVar(&podCIDR, "pod-cidr", config.KindPodSubnet, "Pod subnet CIDR for the cluster (Kind only)")
+	cmd.Flags().StringVar(&serviceCIDR, "service-cidr", config.KindServiceSubnet, "Service subnet CIDR for the cluster (Kind only)")
+	cmd.Flags().StringSliceVar(&portMappings, "port-mapping", nil, "Extra hostPort:containerPort[/protocol] mapping on the control-plane node, repeatable (Kind only, single cluster)")
+	cmd.Flags().StringVar(&ipFamily, "ip-family", config.IPFamilyIPv4, "Cluster IP family (Kind only). Options: ipv4, ipv6, or dual. Use comma separated --pod-cidr/--service-cidr values for dual")
+	cmd.Flags().StringVar(&clusterPrefix, "cluster-prefix", "", "Base name for the Kind clusters and their node containers, e.g. the project name (Kind only). Defaults to kind1, kind2, ...")
 	cmd.Flags().IntVarP(&numClusters, "num", "n", config.DefaultClusterNum, "Number of clusters to create (1-3)")
 	cmd.Flags().IntVarP(&nodeCount, "nodes", "z", config.DefaultNodeCount, "Number of worker nodes per cluster")
+	cmd.Flags().BoolVar(&ha, "ha", false, "Start multi-control-plane clusters, the first 3 of --nodes become control planes and the API server certificate also covers the VIP reserved in the libvirt network, lok8s does not put a load balancer on that address (Minikube only)")
 	cmd.Flags().StringVarP(&k8sVersion, "kubernetes-version", "k", "stable", "Kubernetes version to use")
+	cmd.Flags().StringVar(&nodeImage, "node-image", "", "Custom kindest/node image to use, bypassing the Kubernetes version lookup, an empty value clears a saved image (Kind only)")
 	cmd.Flags().BoolVar(&skipMetalLB, "skip-metallb-install", false, "Skip MetalLB load balancer installation")
+	cmd.Flags().IntVar(&metallbPoolSize, "metallb-pool-size", config.MetalLBDefaultIPsPerCluster, "Number of IPs to allocate to each cluster's MetalLB address pool")
+	cmd.Flags().StringVar(&metallbIPRange, "metallb-ip-range", "", "Exact MetalLB address pool instead of a generated one, x.x.x.start-x.x.x.end in the cluster network (comma separated, one per cluster)")
+	cmd.Flags().StringVar(&metallbMode, "metallb-mode", "", "How MetalLB advertises service IPs (Options: l2 or bgp, default l2)")
+	cmd.Flags().StringVar(&metallbPeerAddress, "metallb-peer-address", "", "Address of the BGP router MetalLB peers with (requires --metallb-mode bgp)")
+	cmd.Flags().IntVar(&metallbPeerASN, "metallb-peer-asn", 0, fmt.Sprintf("ASN of the BGP router MetalLB peers with (default %d, requires --metallb-mode bgp)", config.MetalLBDefaultPeerASN))
+	cmd.Flags().IntVar(&metallbASN, "metallb-asn", 0, fmt.Sprintf("ASN MetalLB speaks BGP as (default %d, requires --metallb-mode bgp)", config.MetalLBDefaultASN))
 	cmd.Flags().BoolVar(&installCloudProvider, "install-cloud-provider", false, "Install cloud-provider-kind for load balancer functionality (Kind only, preferred over MetalLB)")
-	cmd.Flags().StringVar(&cni, "cni", "cilium", "CNI plugin to use (Options: calico, cilium, flannel, or kindnet)")
+	cmd.Flags().StringVar(&cni, "cni", "cilium", "CNI plugin to use (Options: calico, cilium, flannel, kindnet, or none to bring your own)")
 	cmd.Flags().StringVar(&containerRuntime, "container-runtime", "containerd", "Container runtime to use (Kind only, Options: containerd, cri-o, or docker)")
 	cmd.Flags().StringVar(&containerEngine, "container-engine", "", "Preferred container engine for kind clusters (Kind only, Options: docker or podman). If not specified, auto-detects available engine")
+	cmd.Flags().StringSliceVar(&enginePreference, "container-engine-preference", nil, "Order to auto-detect container engines in when --container-engine is not set (Kind only), e.g. podman,docker. Defaults to docker,podman")
 	cmd.Flags().BoolVar(&recreate, "recreate", false, "Recreate clusters even if they already exist (will delete existing clusters first)")
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt when recreating clusters, needed when stdin is not a terminal (e.g. CI)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the generated cluster configuration without provisioning anything")
+	cmd.Flags().BoolVar(&parallel, "parallel", false, fmt.Sprintf("Create multiple clusters concurrently (up to %d at a time)", config.MaxParallelClusters))
+	cmd.Flags().StringArrayVar(&prefetchImages, "prefetch-image", nil, "Image loaded into every cluster once it is created, repeatable. Saved with the project so a recreate loads it again")
+	cmd.Flags().StringArrayVar(&applySources, "apply", nil, "Manifest file, directory of manifests or URL applied to every cluster once it is created, repeatable")
+	cmd.Flags().StringArrayVar(&mounts, "mount", nil, "Mount a host directory into every node as host:container, repeatable (Minikube supports a single mount)")
+	cmd.Flags().IntVar(&nodeGPUs, "gpu", 0, "Number of fake nvidia.com/gpu resources advertised by every worker node, for scheduler testing (Kind only)")
+	cmd.Flags().StringSliceVar(&registryMirrorHosts, "registry-mirror", nil, "Only start the pull-through mirrors of these registry hosts, e.g. docker.io,quay.io (Kind only). Defaults to all of them")
+	cmd.Flags().StringArrayVar(&registryAuth, "registry-auth", nil, "Credentials a pull-through mirror uses for its upstream as host=username:password, repeatable (Kind only). The password must be a $VAR reference (in single quotes) so the secret stays out of the saved config, e.g. 'docker.io=$DOCKER_USER:$DOCKER_TOKEN'")
+	cmd.Flags().BoolVar(&registryTLS, "registry-tls", false, "Serve the local registry over https with a self-signed certificate kept in ~/.lok8s/registry-certs (Kind only). An existing registry keeps its scheme until it's recreated with 'registry stop'")
+	cmd.Flags().BoolVar(&noRegistryMirrors, "no-registry-mirrors", false, "Don't start any pull-through registry mirrors, only the local registry (Kind only)")
+	cmd.Flags().StringArrayVar(&helmSet, "helm-set", nil, fmt.Sprintf("Override a Helm value of an installed chart as release.key=value, repeatable (releases: %s, calico and metrics-server on Kind only), e.g. metallb.speaker.frr.enabled=true", strings.Join(config.ValidHelmReleases, ", ")))
+	cmd.Flags().StringVar(&ciliumValuesFile, "cilium-values", "", "Helm values file for Cilium, --helm-set overrides are merged over it")
+	cmd.Flags().BoolVar(&ciliumClusterMesh, "cilium-clustermesh", false, "Connect the Cilium installs of all clusters into a cluster mesh (kind with the cilium CNI only)")
+	cmd.Flags().StringVar(&metallbValuesFile, "metallb-values", "", "Helm values file for MetalLB, --helm-set overrides are merged over it")
+	cmd.Flags().StringVar(&ciliumChartVersion, "cilium-chart-version", "", "Cilium Helm chart version to install, e.g. 1.16.5. Defaults to the latest chart")
+	cmd.Flags().StringVar(&metallbChartVersion, "metallb-chart-version", "", "MetalLB Helm chart version to install, e.g. 0.14.9. Defaults to the latest chart")
+	cmd.Flags().StringVar(&ciliumChart, "cilium-chart", "", "Local Cilium chart directory or .tgz archive to install instead of the cilium/cilium repo chart")
+	cmd.Flags().StringVar(&metallbChart, "metallb-chart", "", "Local MetalLB chart directory or .tgz archive to install instead of the metallb/metallb repo chart")
+	cmd.Flags().StringVar(&cloudProviderVersion, "cloud-provider-version", "", "cloud-provider-kind release to download (kind only), e.g. 0.6.0. Defaults to the latest release")
+	cmd.Flags().BoolVar(&enableCSI, "enable-csi", true, "Set up a default StorageClass (csi-hostpath-driver on Minikube, local-path on Kind)")
+	cmd.Flags().StringVar(&storageClass, "storage-class", "", "Create a local-path StorageClass with this name and make it the default instead of standard, e.g. gp2 (Kind only)")
+	cmd.Flags().BoolVar(&enableMetricsServer, "enable-metrics-server", true, "Install metrics-server (addon on Minikube, Helm chart on Kind)")
+	cmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "Write the generated kind configs and CNI manifests to this directory and keep them, to inspect the inputs of a failed create")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Deadline for the whole create (e.g. 30m), provisioning is cancelled once it passes. No deadline by default")
+	cmd.Flags().BoolVar(&cleanupOnFailure, "cleanup-on-failure", false, "Delete the clusters this create added or recreated, with their contexts and MetalLB allocations, when it fails or times out. Clusters it left alone are kept")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", config.DefaultReadinessTimeout, "How long to wait for nodes, the CNI and MetalLB to become ready (e.g. 90s, 10m). Cilium and Calico wait 10m when not set")
 
 	if err := cmd.MarkFlagRequired("project"); err != nil {
 		logger.Warnf("failed to mark project flag as required: %v", err)
@@ -304,9 +663,10 @@ func createCmd() *cobra.Command {
 // deleteCmd deletes clusters using the specified environment
 func deleteCmd() *cobra.Command {
 	var (
-		project     string
-		numClusters int
-		force       bool
+		project      string
+		numClusters  int
+		clusterIndex int
+		force        bool
 	)
 
 	cmd := &cobra.Command{
@@ -344,11 +704,14 @@ func deleteCmd() *cobra.Command {
 			if clusters < 1 || clusters > 3 {
 				return fmt.Errorf("number of clusters must be between 1 and 3")
 			}
+			if cmd.Flags().Changed("cluster") && (clusterIndex < 1 || clusterIndex > clusters) {
+				return fmt.Errorf("cluster must be between 1 and %d", clusters)
+			}
 
 			if env == "minikube" {
-				return deleteMinikubeClusters(project, clusters, force)
+				return deleteMinikubeClusters(project, clusters, clusterIndex, force)
 			} else if env == "kind" {
-				return deleteKindClusters(project, clusters, force)
+				return deleteKindClusters(project, clusters, clusterIndex, force)
 			}
 			return fmt.Errorf("invalid environment: %s", env)
 		},
@@ -356,6 +719,7 @@ func deleteCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
 	cmd.Flags().IntVarP(&numClusters, "num", "n", 1, "Number of clusters to delete (1-3)")
+	cmd.Flags().IntVar(&clusterIndex, "cluster", 0, "Only delete this cluster (1-N) of a multi-cluster project, keeping the project configuration")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force cleanup")
 
 	if err := cmd.MarkFlagRequired("project"); err != nil {
@@ -373,10 +737,11 @@ func runCreateCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	// show help for create command
-	fmt.Printf("Creating clusters using %s environment.\n", environment)
-	fmt.Println("Use '" + config.AppName + " create --help' for create command options.")
-	fmt.Println("Use '" + config.AppName + " --environment kind' to use kind instead.")
-	fmt.Println()
+	out := logger.Output()
+	fmt.Fprintf(out, "Creating clusters using %s environment.\n", environment)
+	fmt.Fprintln(out, "Use '"+config.AppName+" create --help' for create command options.")
+	fmt.Fprintln(out, "Use '"+config.AppName+" --environment kind' to use kind instead.")
+	fmt.Fprintln(out)
 
 	createCmd := createCmd()
 	createCmd.SetArgs([]string{"--help"})
@@ -384,74 +749,203 @@ func runCreateCommand(cmd *cobra.Command, args []string) error {
 }
 
 // Helper functions to call the appropriate managers
-func createMinikubeClusters(finalConfig *config.ProjectConfig, configManager *config.ConfigManager) error {
+func createMinikubeClusters(ctx context.Context, finalConfig *config.ProjectConfig, parallel, cleanupOnFailure bool, subnetSearchLimit int, waitTimeout time.Duration, configManager *config.ConfigManager) error {
 	opts := &minikube.CreateOptions{
-		Project:          finalConfig.Project,
-		Bridge:           finalConfig.Bridge,
-		CPU:              finalConfig.CPU,
-		Memory:           finalConfig.Memory,
-		Disk:             finalConfig.DiskSize,
-		SubnetCIDR:       finalConfig.SubnetCIDR,
-		NumClusters:      finalConfig.NumClusters,
-		NodeCount:        finalConfig.NodeCount,
-		K8sVersion:       finalConfig.K8sVersion,
-		InstallMetalLB:   finalConfig.InstallMetalLB,
-		Verbose:          verbose,
-		CNI:              finalConfig.CNI,
-		ContainerRuntime: finalConfig.ContainerRuntime,
+		Project:             finalConfig.Project,
+		Bridge:              finalConfig.Bridge,
+		CPU:                 finalConfig.CPU,
+		Memory:              finalConfig.Memory,
+		Disk:                finalConfig.DiskSize,
+		SubnetCIDR:          finalConfig.SubnetCIDR,
+		NumClusters:         finalConfig.NumClusters,
+		NodeCount:           finalConfig.NodeCount,
+		HA:                  finalConfig.HA,
+		K8sVersion:          finalConfig.K8sVersion,
+		InstallMetalLB:      finalConfig.InstallMetalLB,
+		MetalLBPoolSize:     finalConfig.MetalLBPoolSize,
+		MetalLBIPRanges:     config.MetalLBIPRanges(finalConfig.MetalLBIPRange),
+		MetalLBBGPPeer:      metallbBGPPeer(finalConfig),
+		Verbose:             verbose,
+		CNI:                 finalConfig.CNI,
+		ContainerRuntime:    finalConfig.ContainerRuntime,
+		EnableCSI:           !finalConfig.SkipCSI,
+		EnableMetrics:       !finalConfig.SkipMetricsServer,
+		ReadinessTimeout:    waitTimeout,
+		DryRun:              dryRun,
+		Parallel:            parallel,
+		HelmSet:             finalConfig.HelmSet,
+		Mounts:              finalConfig.Mounts,
+		CiliumChartVersion:  finalConfig.CiliumChartVersion,
+		CiliumChart:         finalConfig.CiliumChart,
+		CiliumValuesFile:    finalConfig.CiliumValuesFile,
+		MetalLBValuesFile:   finalConfig.MetalLBValuesFile,
+		MetalLBChartVersion: finalConfig.MetalLBChartVersion,
+		MetalLBChart:        finalConfig.MetalLBChart,
+		SubnetSearchLimit:   subnetSearchLimit,
+		CleanupOnFailure:    cleanupOnFailure,
+	}
+
+	// save the intended clusters up front so delete still finds them when the create fails partway
+	if !dryRun {
+		saveCreateInProgress(configManager, finalConfig)
 	}
 
 	manager := minikube.NewManager()
-	err := manager.CreateClusters(opts)
+	err := manager.CreateClusters(ctx, opts)
 	if err != nil {
 		return err
 	}
 
+	// nothing was provisioned so there is nothing to persist
+	if dryRun {
+		return nil
+	}
+
 	// Update finalConfig with actual subnet used (may have been changed by FreeSubnet)
 	if opts.SubnetCIDR != "" && opts.SubnetCIDR != finalConfig.SubnetCIDR {
 		finalConfig.SubnetCIDR = opts.SubnetCIDR
 		logger.Debugf("updating saved config with actual subnet: %s", finalConfig.SubnetCIDR)
 	}
 
-	// save config only after successful cluster creation
-	if err := configManager.SaveConfig(finalConfig.Project, finalConfig); err != nil {
-		logger.Warnf("failed to save project config: %v", err)
-	}
-
+	finalizeCreateConfig(configManager, finalConfig)
 	return nil
 }
 
-func createKindClusters(finalConfig *config.ProjectConfig, recreate bool, configManager *config.ConfigManager) error {
+func createKindClusters(ctx context.Context, finalConfig *config.ProjectConfig, recreate, assumeYes, parallel, cleanupOnFailure bool, waitTimeout time.Duration, configManager *config.ConfigManager) error {
 	opts := &kind.CreateOptions{
-		Project:                  finalConfig.Project,
-		GatewayIP:                finalConfig.GatewayIP,
-		SubnetCIDR:               finalConfig.SubnetCIDR,
-		NumClusters:              finalConfig.NumClusters,
-		NodeCount:                finalConfig.NodeCount,
-		K8sVersion:               finalConfig.K8sVersion,
-		InstallMetalLB:           finalConfig.InstallMetalLB,
-		InstallCloudProvider:     finalConfig.InstallCloudProvider,
-		CNI:                      finalConfig.CNI,
-		ContainerRuntime:         finalConfig.ContainerRuntime,
-		PreferredContainerEngine: finalConfig.ContainerEngine,
-		Recreate:                 recreate,
+		Project:                   finalConfig.Project,
+		ClusterPrefix:             finalConfig.ClusterPrefix,
+		GatewayIP:                 finalConfig.GatewayIP,
+		SubnetCIDR:                finalConfig.SubnetCIDR,
+		PodSubnet:                 finalConfig.PodSubnet,
+		ServiceSubnet:             finalConfig.ServiceSubnet,
+		IPFamily:                  finalConfig.IPFamily,
+		NumClusters:               finalConfig.NumClusters,
+		NodeCount:                 finalConfig.NodeCount,
+		K8sVersion:                finalConfig.K8sVersion,
+		NodeImage:                 finalConfig.NodeImage,
+		InstallMetalLB:            finalConfig.InstallMetalLB,
+		MetalLBPoolSize:           finalConfig.MetalLBPoolSize,
+		MetalLBIPRanges:           config.MetalLBIPRanges(finalConfig.MetalLBIPRange),
+		MetalLBBGPPeer:            metallbBGPPeer(finalConfig),
+		InstallCloudProvider:      finalConfig.InstallCloudProvider,
+		CNI:                       finalConfig.CNI,
+		CPU:                       finalConfig.CPU,
+		Memory:                    finalConfig.Memory,
+		ContainerRuntime:          finalConfig.ContainerRuntime,
+		PreferredContainerEngine:  finalConfig.ContainerEngine,
+		ContainerEnginePreference: finalConfig.ContainerEnginePreference,
+		HelmSet:                   finalConfig.HelmSet,
+		CiliumChartVersion:        finalConfig.CiliumChartVersion,
+		CiliumChart:               finalConfig.CiliumChart,
+		CiliumValuesFile:          finalConfig.CiliumValuesFile,
+		CiliumClusterMesh:         finalConfig.CiliumClusterMesh,
+		CiliumClusterIDs:          finalConfig.CiliumClusterIDs,
+		MetalLBValuesFile:         finalConfig.MetalLBValuesFile,
+		MetalLBChartVersion:       finalConfig.MetalLBChartVersion,
+		MetalLBChart:              finalConfig.MetalLBChart,
+		CloudProviderVersion:      finalConfig.CloudProviderVersion,
+		Recreate:                  recreate,
+		AssumeYes:                 assumeYes,
+		Parallel:                  parallel,
+		RegistryMirrors:           finalConfig.RegistryMirrors,
+		RegistryMirrorHosts:       finalConfig.RegistryMirrorHosts,
+		NoRegistryMirrors:         finalConfig.NoRegistryMirrors,
+		RegistryAuth:              finalConfig.RegistryAuth,
+		RegistryTLS:               finalConfig.RegistryTLS,
+		NodeLabels:                finalConfig.NodeLabels,
+		NodeTaints:                finalConfig.NodeTaints,
+		NodeGPUs:                  finalConfig.NodeGPUs,
+		WorkerNodes:               finalConfig.WorkerNodes,
+		ExtraPortMappings:         finalConfig.ExtraPortMappings,
+		Mounts:                    finalConfig.Mounts,
+		EnableStorageClass:        !finalConfig.SkipCSI,
+		StorageClass:              finalConfig.StorageClass,
+		EnableMetrics:             !finalConfig.SkipMetricsServer,
+		ReadinessTimeout:          waitTimeout,
+		DryRun:                    dryRun,
+		CleanupOnFailure:          cleanupOnFailure,
 	}
 
-	manager := kind.NewManager()
-	err := manager.CreateClusters(opts)
+	// save the intended clusters up front so delete still finds them when the create fails partway
+	if !dryRun {
+		saveCreateInProgress(configManager, finalConfig)
+	}
+
+	manager := newKindManager(finalConfig)
+	err := manager.CreateClusters(ctx, opts)
 	if err != nil {
 		return err
 	}
 
-	// save config only after successful cluster creation
-	if err := configManager.SaveConfig(finalConfig.Project, finalConfig); err != nil {
-		logger.Warnf("failed to save project config: %v", err)
+	// nothing was provisioned so there is nothing to persist
+	if dryRun {
+		return nil
+	}
+
+	// Update finalConfig with actual network used (may have been changed to a free subnet or an existing network)
+	if opts.SubnetCIDR != "" && opts.SubnetCIDR != finalConfig.SubnetCIDR {
+		finalConfig.SubnetCIDR = opts.SubnetCIDR
+		logger.Debugf("updating saved config with actual subnet: %s", finalConfig.SubnetCIDR)
+	}
+	if opts.GatewayIP != "" && opts.GatewayIP != finalConfig.GatewayIP {
+		finalConfig.GatewayIP = opts.GatewayIP
+		logger.Debugf("updating saved config with actual gateway IP: %s", finalConfig.GatewayIP)
 	}
 
+	// keep the cilium cluster IDs so recreated clusters rejoin the mesh under the same ID
+	if len(opts.CiliumClusterIDs) > 0 {
+		finalConfig.CiliumClusterIDs = opts.CiliumClusterIDs
+	}
+
+	// record the registry port so it can be discovered later
+	if opts.RegistryPort > 0 {
+		finalConfig.RegistryPort = opts.RegistryPort
+		logger.Debugf("updating saved config with registry port: %d", finalConfig.RegistryPort)
+	}
+
+	finalizeCreateConfig(configManager, finalConfig)
 	return nil
 }
 
-func deleteMinikubeClusters(project string, numClusters int, force bool) error {
+// saveCreateInProgress saves the project config marked as in progress before any cluster is created. A saved
+// config keeps its settings and the larger cluster count until the create succeeds, so a create that fails before
+// reaching the existing clusters doesn't hide them from delete
+func saveCreateInProgress(configManager *config.ConfigManager, finalConfig *config.ProjectConfig) {
+	savedConfig, err := configManager.LoadConfig(finalConfig.Project)
+	if err != nil {
+		logger.Warnf("failed to load project config: %v", err)
+		return
+	}
+
+	inProgress := finalConfig
+	if savedConfig != nil {
+		inProgress = savedConfig
+		inProgress.NumClusters = max(savedConfig.NumClusters, finalConfig.NumClusters)
+	}
+	inProgress.CreateInProgress = true
+	if err := configManager.SaveConfig(finalConfig.Project, inProgress); err != nil {
+		logger.Warnf("failed to save project config: %v", err)
+	}
+}
+
+// finalizeCreateConfig saves the project config of a successful create, keeping the MetalLB allocations that were
+// saved to it while the clusters were created
+func finalizeCreateConfig(configManager *config.ConfigManager, finalConfig *config.ProjectConfig) {
+	savedConfig, err := configManager.LoadConfig(finalConfig.Project)
+	if err != nil {
+		logger.Warnf("failed to load project config: %v", err)
+	} else if savedConfig != nil {
+		finalConfig.MetalLBAllocations = savedConfig.MetalLBAllocations
+	}
+
+	finalConfig.CreateInProgress = false
+	if err := configManager.SaveConfig(finalConfig.Project, finalConfig); err != nil {
+		logger.Warnf("failed to save project config: %v", err)
+	}
+}
+
+func deleteMinikubeClusters(project string, numClusters, clusterIndex int, force bool) error {
 	// load saved config to get Bridge and SubnetCIDR
 	savedConfig, err := configManager.LoadConfig(project)
 	if err != nil {
@@ -471,32 +965,127 @@ func deleteMinikubeClusters(project string, numClusters int, force bool) error {
 	}
 
 	opts := &minikube.DeleteOptions{
-		Project:     project,
-		NumClusters: numClusters,
-		Force:       force,
-		Bridge:      bridge,
-		SubnetCIDR:  subnetCIDR,
+		Project:      project,
+		NumClusters:  numClusters,
+		ClusterIndex: clusterIndex,
+		Force:        force,
+		Bridge:       bridge,
+		SubnetCIDR:   subnetCIDR,
 	}
 
 	manager := minikube.NewManager()
 	return manager.DeleteClusters(opts)
 }
 
-func deleteKindClusters(project string, numClusters int, force bool) error {
+func deleteKindClusters(project string, numClusters, clusterIndex int, force bool) error {
+	// load saved config to get any custom registry mirrors
+	savedConfig, err := configManager.LoadConfig(project)
+	if err != nil {
+		logger.Warnf("failed to load saved config for project %s: %v", project, err)
+	}
+
 	opts := &kind.DeleteOptions{
-		Project:     project,
-		NumClusters: numClusters,
-		Force:       force,
+		Project:      project,
+		NumClusters:  numClusters,
+		ClusterIndex: clusterIndex,
+		Force:        force,
+	}
+	if savedConfig != nil {
+		opts.ClusterPrefix = savedConfig.ClusterPrefix
+		opts.RegistryMirrors = savedConfig.RegistryMirrors
 	}
 
-	manager := kind.NewManager()
+	manager := newKindManager(savedConfig)
 	return manager.DeleteClusters(opts)
 }
 
+// metallbBGPPeer returns the BGP peer MetalLB advertises to, nil unless the project uses the bgp mode
+func metallbBGPPeer(projectConfig *config.ProjectConfig) *services.MetalLBBGPPeer {
+	if projectConfig.MetalLBMode != config.MetalLBModeBGP {
+		return nil
+	}
+	return &services.MetalLBBGPPeer{
+		Address: projectConfig.MetalLBPeerAddress,
+		ASN:     projectConfig.MetalLBPeerASN,
+		MyASN:   projectConfig.MetalLBASN,
+	}
+}
+
+// checkBridgeAvailable returns an error when another saved minikube project already uses the bridge
+func checkBridgeAvailable(configManager *config.ConfigManager, project, bridge string) error {
+	if bridge == "" {
+		bridge = config.MinikubeDefaultBridgeNetName
+	}
+
+	projects, err := configManager.ListConfigs()
+	if err != nil {
+		return fmt.Errorf("failed to list project configs: %w", err)
+	}
+
+	for _, other := range projects {
+		if other == project {
+			continue
+		}
+		otherConfig, err := configManager.LoadConfig(other)
+		if err != nil || otherConfig == nil || otherConfig.Environment != "minikube" {
+			continue
+		}
+
+		otherBridge := otherConfig.Bridge
+		if otherBridge == "" {
+			otherBridge = config.MinikubeDefaultBridgeNetName
+		}
+		if otherBridge == bridge {
+			return fmt.Errorf("bridge %s is already used by project %s, pick another one with --bridge", bridge, other)
+		}
+	}
+
+	return nil
+}
+
+// savedClusterPrefix returns the kind cluster prefix saved for a project, empty when nothing was saved
+func savedClusterPrefix(savedConfig *config.ProjectConfig) string {
+	if savedConfig == nil {
+		return ""
+	}
+	return savedConfig.ClusterPrefix
+}
+
+// newKindManager returns a kind manager running on the container engine saved for a project, the detected one
+// when nothing was saved
+func newKindManager(savedConfig *config.ProjectConfig) *kind.Manager {
+	if savedConfig == nil {
+		return kind.NewManagerWithContainerEngine("", nil)
+	}
+	return kind.NewManagerWithContainerEngine(savedConfig.ContainerEngine, savedConfig.ContainerEnginePreference)
+}
+
+// kindManagerForProject returns a kind manager running on the container engine saved for the project
+func kindManagerForProject(project string) *kind.Manager {
+	savedConfig, err := configManager.LoadConfig(project)
+	if err != nil {
+		logger.Warnf("failed to load saved config for project %s: %v", project, err)
+	}
+	return newKindManager(savedConfig)
+}
+
+// projectContainerRuntime returns the container engine saved for the project, the detected one when nothing was saved
+func projectContainerRuntime(project string) (string, error) {
+	savedConfig, err := configManager.LoadConfig(project)
+	if err != nil {
+		logger.Warnf("failed to load saved config for project %s: %v", project, err)
+	}
+	if savedConfig == nil {
+		return docker.ResolveContainerRuntime("", nil)
+	}
+	return docker.ResolveContainerRuntime(savedConfig.ContainerEngine, savedConfig.ContainerEnginePreference)
+}
+
 // statusCmd shows the status of clusters
 func statusCmd() *cobra.Command {
 	var (
 		project string
+		output  string
 	)
 
 	cmd := &cobra.Command{
@@ -508,6 +1097,10 @@ func statusCmd() *cobra.Command {
 				return fmt.Errorf("project name is required")
 			}
 
+			if err := report.ValidateFormat(output); err != nil {
+				return err
+			}
+
 			// load saved config to get environment and other settings
 			savedConfig, err := configManager.LoadConfig(project)
 			if err != nil {
@@ -517,6 +1110,8 @@ func statusCmd() *cobra.Command {
 			// use saved config if available, otherwise use defaults
 			env := environment
 			clusters := 1
+			registryPort := 0
+			cni := ""
 			if savedConfig != nil {
 				if savedConfig.Environment != "" {
 					env = savedConfig.Environment
@@ -524,6 +1119,8 @@ func statusCmd() *cobra.Command {
 				if savedConfig.NumClusters > 0 {
 					clusters = savedConfig.NumClusters
 				}
+				registryPort = savedConfig.RegistryPort
+				cni = savedConfig.CNI
 			}
 
 			if clusters < 1 || clusters > 3 {
@@ -531,15 +1128,16 @@ func statusCmd() *cobra.Command {
 			}
 
 			if env == "minikube" {
-				return statusMinikubeClusters(project, clusters)
+				return statusMinikubeClusters(project, clusters, cni, output)
 			} else if env == "kind" {
-				return statusKindClusters(project, clusters)
+				return statusKindClusters(project, savedClusterPrefix(savedConfig), clusters, registryPort, cni, output)
 			}
 			return fmt.Errorf("invalid environment: %s", env)
 		},
 	}
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", report.FormatTable, "Output format (Options: table or json)")
 
 	if err := cmd.MarkFlagRequired("project"); err != nil {
 		logger.Warnf("failed to mark project flag as required: %v", err)
@@ -548,24 +1146,195 @@ func statusCmd() *cobra.Command {
 	return cmd
 }
 
-func statusMinikubeClusters(project string, numClusters int) error {
+func statusMinikubeClusters(project string, numClusters int, cni, outputFormat string) error {
 	opts := &minikube.StatusOptions{
-		Project:     project,
-		NumClusters: numClusters,
+		Project:      project,
+		NumClusters:  numClusters,
+		CNI:          cni,
+		OutputFormat: outputFormat,
 	}
 
 	manager := minikube.NewManager()
 	return manager.StatusClusters(opts)
 }
 
-func statusKindClusters(project string, numClusters int) error {
+func statusKindClusters(project, clusterPrefix string, numClusters, registryPort int, cni, outputFormat string) error {
 	opts := &kind.StatusOptions{
+		Project:       project,
+		ClusterPrefix: clusterPrefix,
+		NumClusters:   numClusters,
+		RegistryPort:  registryPort,
+		CNI:           cni,
+		OutputFormat:  outputFormat,
+	}
+
+	manager := kindManagerForProject(project)
+	return manager.StatusClusters(opts)
+}
+
+// startCmd starts previously stopped clusters
+func startCmd() *cobra.Command {
+	var (
+		project string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start stopped Kubernetes clusters",
+		Long:  `Start one or more previously stopped Kubernetes clusters for a project without recreating them`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("start command must not be run as sudo/root")
+			}
+
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+
+			// load saved config to get environment and other settings
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+
+			// use saved config if available, otherwise use defaults
+			env := environment
+			clusters := 1
+			cni := ""
+			if savedConfig != nil {
+				if savedConfig.Environment != "" {
+					env = savedConfig.Environment
+				}
+				if savedConfig.NumClusters > 0 {
+					clusters = savedConfig.NumClusters
+				}
+				cni = savedConfig.CNI
+			}
+
+			if clusters < 1 || clusters > 3 {
+				return fmt.Errorf("number of clusters must be between 1 and 3")
+			}
+
+			if env == "minikube" {
+				return startMinikubeClusters(project, clusters)
+			} else if env == "kind" {
+				return startKindClusters(project, savedClusterPrefix(savedConfig), cni, clusters)
+			}
+			return fmt.Errorf("invalid environment: %s", env)
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		logger.Warnf("failed to mark project flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+// stopCmd stops clusters without deleting them
+func stopCmd() *cobra.Command {
+	var (
+		project string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop Kubernetes clusters",
+		Long:  `Stop one or more Kubernetes clusters for a project so they can be started again later`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("stop command must not be run as sudo/root")
+			}
+
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+
+			// load saved config to get environment and other settings
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+
+			// use saved config if available, otherwise use defaults
+			env := environment
+			clusters := 1
+			if savedConfig != nil {
+				if savedConfig.Environment != "" {
+					env = savedConfig.Environment
+				}
+				if savedConfig.NumClusters > 0 {
+					clusters = savedConfig.NumClusters
+				}
+			}
+
+			if clusters < 1 || clusters > 3 {
+				return fmt.Errorf("number of clusters must be between 1 and 3")
+			}
+
+			if env == "minikube" {
+				return stopMinikubeClusters(project, clusters)
+			} else if env == "kind" {
+				return stopKindClusters(project, savedClusterPrefix(savedConfig), clusters)
+			}
+			return fmt.Errorf("invalid environment: %s", env)
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		logger.Warnf("failed to mark project flag as required: %v", err)
+	}
+
+	return cmd
+}
+
+func startMinikubeClusters(project string, numClusters int) error {
+	opts := &minikube.StartOptions{
 		Project:     project,
 		NumClusters: numClusters,
 	}
 
-	manager := kind.NewManager()
-	return manager.StatusClusters(opts)
+	manager := minikube.NewManager()
+	return manager.StartClusters(opts)
+}
+
+func startKindClusters(project, clusterPrefix, cni string, numClusters int) error {
+	opts := &kind.StartOptions{
+		Project:       project,
+		ClusterPrefix: clusterPrefix,
+		NumClusters:   numClusters,
+		CNI:           cni,
+	}
+
+	manager := kindManagerForProject(project)
+	return manager.StartClusters(opts)
+}
+
+func stopMinikubeClusters(project string, numClusters int) error {
+	opts := &minikube.StopOptions{
+		Project:     project,
+		NumClusters: numClusters,
+	}
+
+	manager := minikube.NewManager()
+	return manager.StopClusters(opts)
+}
+
+func stopKindClusters(project, clusterPrefix string, numClusters int) error {
+	opts := &kind.StopOptions{
+		Project:       project,
+		ClusterPrefix: clusterPrefix,
+		NumClusters:   numClusters,
+	}
+
+	manager := kindManagerForProject(project)
+	return manager.StopClusters(opts)
 }
 
 // profileListCmd lists profiles/clusters
@@ -600,22 +1369,31 @@ func listKindClusters() error {
 // imageLoadCmd loads Docker images into clusters
 func imageLoadCmd() *cobra.Command {
 	var (
-		project string
-		image   string
+		project  string
+		images   []string
+		archives []string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "image-load",
 		Short: "Load Docker images into clusters",
-		Long:  `Load a Docker image into all clusters for a project`,
+		Long:  `Load one or more Docker images or image archives (created with 'docker save') into all clusters for a project`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if project == "" {
 				return fmt.Errorf("project name is required")
 			}
 
-			if image == "" {
-				return fmt.Errorf("image name is required")
+			if len(images) == 0 && len(archives) == 0 {
+				return fmt.Errorf("image name or archive path is required")
+			}
+
+			// validate archives exist before touching any cluster
+			for _, archive := range archives {
+				if !docker.IsImageArchive(archive) {
+					return fmt.Errorf("image archive %s does not exist or is not a .tar file", archive)
+				}
 			}
+			images = append(images, archives...)
 
 			// load saved config to get environment and number of clusters
 			savedConfig, err := configManager.LoadConfig(project)
@@ -640,31 +1418,56 @@ func imageLoadCmd() *cobra.Command {
 			}
 
 			if env == "minikube" {
-				return loadImageMinikube(project, image, clusters)
+				return loadImageMinikube(project, images, clusters)
 			} else if env == "kind" {
-				return loadImageKind(project, image, clusters)
+				return loadImageKind(project, savedClusterPrefix(savedConfig), images, clusters)
 			}
 			return fmt.Errorf("invalid environment: %s", env)
 		},
 	}
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
-	cmd.Flags().StringVarP(&image, "image", "i", "", "Docker image name to load (required)")
+	cmd.Flags().StringArrayVarP(&images, "image", "i", nil, "Docker image name to load, can be repeated")
+	cmd.Flags().StringArrayVarP(&archives, "archive", "a", nil, "Path to an image archive (.tar) to load, can be repeated")
 
 	if err := cmd.MarkFlagRequired("project"); err != nil {
 		logger.Warnf("failed to mark project flag as required: %v", err)
 	}
-	if err := cmd.MarkFlagRequired("image"); err != nil {
-		logger.Warnf("failed to mark image flag as required: %v", err)
-	}
+	cmd.MarkFlagsOneRequired("image", "archive")
 
 	return cmd
 }
 
-func loadImageMinikube(project, image string, numClusters int) error {
+// prefetchProjectImages loads the prefetch images of a project into each of its clusters, kind only loads images from the
+// local image store so they are pulled there first
+func prefetchProjectImages(projectConfig *config.ProjectConfig) error {
+	if projectConfig.Environment == "minikube" {
+		return loadImageMinikube(projectConfig.Project, projectConfig.PrefetchImages, projectConfig.NumClusters)
+	}
+
+	containerRuntime, err := docker.ResolveContainerRuntime(projectConfig.ContainerEngine, projectConfig.ContainerEnginePreference)
+	if err != nil {
+		return err
+	}
+	for _, image := range projectConfig.PrefetchImages {
+		if docker.IsImageArchive(image) {
+			continue
+		}
+		status := logger.NewStatus()
+		status.Start(fmt.Sprintf("pulling image %s", image))
+		if err := docker.PullImage(containerRuntime, image); err != nil {
+			status.End(false)
+			return err
+		}
+		status.End(true)
+	}
+	return loadImageKind(projectConfig.Project, projectConfig.ClusterPrefix, projectConfig.PrefetchImages, projectConfig.NumClusters)
+}
+
+func loadImageMinikube(project string, images []string, numClusters int) error {
 	opts := &minikube.LoadImageOptions{
 		Project:     project,
-		Image:       image,
+		Images:      images,
 		NumClusters: numClusters,
 	}
 
@@ -672,14 +1475,15 @@ func loadImageMinikube(project, image string, numClusters int) error {
 	return manager.LoadImage(opts)
 }
 
-func loadImageKind(project, image string, numClusters int) error {
+func loadImageKind(project, clusterPrefix string, images []string, numClusters int) error {
 	opts := &kind.LoadImageOptions{
-		Project:     project,
-		Image:       image,
-		NumClusters: numClusters,
+		Project:       project,
+		ClusterPrefix: clusterPrefix,
+		Images:        images,
+		NumClusters:   numClusters,
 	}
 
-	manager := kind.NewManager()
+	manager := kindManagerForProject(project)
 	return manager.LoadImage(opts)
 }
 
@@ -732,16 +1536,119 @@ func configCmd() *cobra.Command {
 			}
 
 			fmt.Printf("Configuration for project: %s\n", project)
+			if projectConfig.CreateInProgress {
+				fmt.Printf("  ⚠️ the last create did not finish, run delete to clean up its clusters or create again\n")
+			}
 			fmt.Printf("  Environment: %s\n", projectConfig.Environment)
 			fmt.Printf("  Clusters: %d\n", projectConfig.NumClusters)
 			fmt.Printf("  Nodes: %d\n", projectConfig.NodeCount)
+			if projectConfig.HA {
+				fmt.Printf("  HA: %v\n", projectConfig.HA)
+			}
 			fmt.Printf("  Kubernetes Version: %s\n", projectConfig.K8sVersion)
+			if projectConfig.NodeImage != "" {
+				fmt.Printf("  Node Image: %s\n", projectConfig.NodeImage)
+			}
 			fmt.Printf("  Gateway IP: %s\n", projectConfig.GatewayIP)
 			fmt.Printf("  Subnet CIDR: %s\n", projectConfig.SubnetCIDR)
+			if projectConfig.PodSubnet != "" {
+				fmt.Printf("  Pod CIDR: %s\n", projectConfig.PodSubnet)
+			}
+			if projectConfig.ServiceSubnet != "" {
+				fmt.Printf("  Service CIDR: %s\n", projectConfig.ServiceSubnet)
+			}
+			if projectConfig.IPFamily != "" {
+				fmt.Printf("  IP Family: %s\n", projectConfig.IPFamily)
+			}
 			fmt.Printf("  CNI: %s\n", projectConfig.CNI)
+			if projectConfig.CiliumChartVersion != "" {
+				fmt.Printf("  Cilium Chart Version: %s\n", projectConfig.CiliumChartVersion)
+			}
+			if projectConfig.CiliumChart != "" {
+				fmt.Printf("  Cilium Chart: %s\n", projectConfig.CiliumChart)
+			}
+			if projectConfig.CiliumValuesFile != "" {
+				fmt.Printf("  Cilium Values File: %s\n", projectConfig.CiliumValuesFile)
+			}
+			if projectConfig.CiliumClusterMesh {
+				fmt.Printf("  Cilium Cluster Mesh: %v\n", projectConfig.CiliumClusterMesh)
+			}
 			fmt.Printf("  Container Runtime: %s\n", projectConfig.ContainerRuntime)
+			if len(projectConfig.ContainerEnginePreference) > 0 {
+				fmt.Printf("  Container Engine Preference: %s\n", strings.Join(projectConfig.ContainerEnginePreference, ", "))
+			}
+			fmt.Printf("  Enable CSI: %v\n", !projectConfig.SkipCSI)
+			if projectConfig.StorageClass != "" {
+				fmt.Printf("  Storage Class: %s\n", projectConfig.StorageClass)
+			}
+			fmt.Printf("  Enable Metrics Server: %v\n", !projectConfig.SkipMetricsServer)
+			if projectConfig.RegistryPort > 0 {
+				fmt.Printf("  Registry Port: %d\n", projectConfig.RegistryPort)
+			}
+			if len(projectConfig.RegistryMirrors) > 0 {
+				fmt.Printf("  Registry Mirrors:\n")
+				hosts := make([]string, 0, len(projectConfig.RegistryMirrors))
+				for host := range projectConfig.RegistryMirrors {
+					hosts = append(hosts, host)
+				}
+				sort.Strings(hosts)
+				for _, host := range hosts {
+					fmt.Printf("    %s: %s\n", host, projectConfig.RegistryMirrors[host])
+				}
+			}
+			if len(projectConfig.RegistryMirrorHosts) > 0 {
+				fmt.Printf("  Registry Mirror Hosts: %s\n", strings.Join(projectConfig.RegistryMirrorHosts, ", "))
+			}
+			if projectConfig.NoRegistryMirrors {
+				fmt.Printf("  No Registry Mirrors: %v\n", projectConfig.NoRegistryMirrors)
+			}
+			if len(projectConfig.PrefetchImages) > 0 {
+				fmt.Printf("  Prefetch Images: %s\n", strings.Join(projectConfig.PrefetchImages, ", "))
+			}
+			if projectConfig.RegistryTLS {
+				fmt.Printf("  Registry TLS: %v\n", projectConfig.RegistryTLS)
+			}
+			// only the hosts, the credentials are never shown
+			if len(projectConfig.RegistryAuth) > 0 {
+				hosts := make([]string, 0, len(projectConfig.RegistryAuth))
+				for host := range projectConfig.RegistryAuth {
+					hosts = append(hosts, host)
+				}
+				sort.Strings(hosts)
+				fmt.Printf("  Registry Auth: %s\n", strings.Join(hosts, ", "))
+			}
+			if len(projectConfig.ExtraPortMappings) > 0 {
+				fmt.Printf("  Extra Port Mappings: %s\n", strings.Join(projectConfig.ExtraPortMappings, ", "))
+			}
+			if len(projectConfig.Mounts) > 0 {
+				fmt.Printf("  Mounts: %s\n", strings.Join(projectConfig.Mounts, ", "))
+			}
+			if projectConfig.NodeGPUs > 0 {
+				fmt.Printf("  Node GPUs: %d\n", projectConfig.NodeGPUs)
+			}
 			fmt.Printf("  Install MetalLB: %v\n", projectConfig.InstallMetalLB)
+			if projectConfig.MetalLBPoolSize > 0 {
+				fmt.Printf("  MetalLB Pool Size: %d\n", projectConfig.MetalLBPoolSize)
+			}
+			if projectConfig.MetalLBIPRange != "" {
+				fmt.Printf("  MetalLB IP Range: %s\n", projectConfig.MetalLBIPRange)
+			}
+			if projectConfig.MetalLBMode == config.MetalLBModeBGP {
+				fmt.Printf("  MetalLB Mode: bgp (peer %s)\n", projectConfig.MetalLBPeerAddress)
+			}
+			if projectConfig.MetalLBChartVersion != "" {
+				fmt.Printf("  MetalLB Chart Version: %s\n", projectConfig.MetalLBChartVersion)
+			}
+			if projectConfig.MetalLBChart != "" {
+				fmt.Printf("  MetalLB Chart: %s\n", projectConfig.MetalLBChart)
+			}
+			if projectConfig.MetalLBValuesFile != "" {
+				fmt.Printf("  MetalLB Values File: %s\n", projectConfig.MetalLBValuesFile)
+			}
 			fmt.Printf("  Install Cloud Provider: %v\n", projectConfig.InstallCloudProvider)
+			if projectConfig.CloudProviderVersion != "" {
+				fmt.Printf("  Cloud Provider Version: %s\n", projectConfig.CloudProviderVersion)
+			}
 			return nil
 		},
 	}
@@ -761,9 +1668,120 @@ func configCmd() *cobra.Command {
 		},
 	}
 
+	// set command
+	setCmd := &cobra.Command{
+		Use:   "set [project] [key] [value]",
+		Short: "Set a configuration value for a project",
+		Long: `Set a single configuration value for a project using its YAML key (e.g. node_count, cni).
+Lists are given as comma separated values and maps as comma separated key=value pairs, an empty value clears the key.`,
+		Example: `  lok8s config set myproject node_count 3
+  lok8s config set myproject node_taints dedicated=infra:NoSchedule
+  lok8s config set myproject registry_mirrors quay.io=https://mirror.example.com`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, key, value := args[0], args[1], args[2]
+			projectConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load config for project %s: %w", project, err)
+			}
+			if projectConfig == nil {
+				return fmt.Errorf("no configuration found for project: %s", project)
+			}
+
+			if err := projectConfig.SetField(key, value); err != nil {
+				return err
+			}
+			if err := projectConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration: %w", err)
+			}
+
+			if err := configManager.SaveConfig(project, projectConfig); err != nil {
+				return fmt.Errorf("failed to save config for project %s: %w", project, err)
+			}
+			fmt.Printf("Set %s for project: %s\n", key, project)
+			return nil
+		},
+	}
+
+	// edit command
+	editCmd := &cobra.Command{
+		Use:   "edit [project]",
+		Short: "Edit the configuration for a project in $EDITOR",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return editProjectConfig(args[0])
+		},
+	}
+
 	cmd.AddCommand(listCmd)
 	cmd.AddCommand(showCmd)
+	cmd.AddCommand(setCmd)
+	cmd.AddCommand(editCmd)
 	cmd.AddCommand(deleteCmd)
 
 	return cmd
 }
+
+// editProjectConfig opens a copy of the project config in $EDITOR (vi if not set) and saves it back
+// only if the result still parses as a project config for the same project
+func editProjectConfig(project string) error {
+	configPath := configManager.GetConfigPath(project)
+	original, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no configuration found for project: %s", project)
+		}
+		return fmt.Errorf("failed to read config for project %s: %w", project, err)
+	}
+
+	tmpFile, err := os.CreateTemp("", fmt.Sprintf("%s-%s-*.yaml", config.AppName, project))
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(original); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	tmpFile.Close()
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	editorCmd := exec.Command(editor[0], append(editor[1:], tmpFile.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", editor[0], err)
+	}
+
+	edited, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read edited config: %w", err)
+	}
+	if bytes.Equal(original, edited) {
+		fmt.Printf("No changes made to the configuration for project: %s\n", project)
+		return nil
+	}
+
+	projectConfig, err := config.ParseProjectConfig(edited)
+	if err != nil {
+		return fmt.Errorf("edited config is invalid, no changes were saved: %w", err)
+	}
+	if projectConfig.Project != project {
+		return fmt.Errorf("edited config is for project %q rather than %q, no changes were saved", projectConfig.Project, project)
+	}
+	if err := projectConfig.Validate(); err != nil {
+		return fmt.Errorf("edited config is invalid, no changes were saved: %w", err)
+	}
+
+	if err := configManager.SaveConfig(project, projectConfig); err != nil {
+		return fmt.Errorf("failed to save config for project %s: %w", project, err)
+	}
+	fmt.Printf("Updated configuration for project: %s\n", project)
+	return nil
+}
@@ -0,0 +1,133 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// scaleCmd changes the number of nodes of a project's clusters without recreating them
+func scaleCmd() *cobra.Command {
+	var (
+		project   string
+		nodeCount int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Change the number of nodes of a project's clusters",
+		Long: `Add or remove nodes until every cluster of a Minikube project has the given number of nodes,
+including the control plane. The newest nodes are removed first. Kind clusters can't be resized in place`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// check if running as sudo/root
+			if syscall.Geteuid() == 0 {
+				return fmt.Errorf("scale command must not be run as sudo/root")
+			}
+
+			if project == "" {
+				return fmt.Errorf("project name is required")
+			}
+			if nodeCount < 1 {
+				return fmt.Errorf("number of nodes must be at least 1")
+			}
+
+			savedConfig, err := configManager.LoadConfig(project)
+			if err != nil {
+				return fmt.Errorf("failed to load project config: %w", err)
+			}
+			if savedConfig == nil {
+				return fmt.Errorf("no configuration found for project: %s", project)
+			}
+
+			env := environment
+			if savedConfig.Environment != "" {
+				env = savedConfig.Environment
+			}
+			if env == "kind" {
+				return fmt.Errorf("scaling kind clusters is not supported, create the project again with --recreate and the new --nodes")
+			} else if env != "minikube" {
+				return fmt.Errorf("invalid environment: %s", env)
+			}
+
+			// the newest nodes are removed first, an HA cluster has to keep its control planes
+			if savedConfig.HA && nodeCount < config.MinHANodes {
+				return fmt.Errorf("HA clusters keep their %d control planes, the number of nodes must be at least %d", config.MinHANodes, config.MinHANodes)
+			}
+
+			clusters := savedConfig.NumClusters
+			if clusters < 1 || clusters > 3 {
+				return fmt.Errorf("number of clusters must be between 1 and 3")
+			}
+
+			opts := &minikube.ScaleOptions{
+				Project:         project,
+				NumClusters:     clusters,
+				NodeCount:       nodeCount,
+				CNI:             savedConfig.CNI,
+				MetalLBIPRanges: config.MetalLBIPRanges(savedConfig.MetalLBIPRange),
+				MetalLBBGPPeer:  metallbBGPPeer(savedConfig),
+			}
+
+			manager := minikube.NewManager()
+			reached, scaleErr := manager.ScaleClusters(opts)
+			if scaleErr != nil && (reached == 0 || reached == savedConfig.NodeCount) {
+				return scaleErr
+			}
+
+			// reload so the MetalLB allocations refreshed by the scale aren't overwritten, a scale that stopped
+			// part way still records the nodes that were added or removed before the error
+			updatedConfig, err := configManager.LoadConfig(project)
+			if err != nil || updatedConfig == nil {
+				updatedConfig = savedConfig
+			}
+			updatedConfig.NodeCount = reached
+			if err := configManager.SaveConfig(project, updatedConfig); err != nil {
+				if scaleErr != nil {
+					logger.Warnf("⚠️ failed to save the node count %d reached by the scale: %v", reached, err)
+					return scaleErr
+				}
+				return fmt.Errorf("failed to save project config: %w", err)
+			}
+			return scaleErr
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project name (required)")
+	cmd.Flags().IntVarP(&nodeCount, "nodes", "z", 0, "Number of nodes each cluster should have, including the control plane (required)")
+
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		logger.Warnf("failed to mark project flag as required: %v", err)
+	}
+	if err := cmd.MarkFlagRequired("nodes"); err != nil {
+		logger.Warnf("failed to mark nodes flag as required: %v", err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"text/tabwriter"
+
+	"github.com/day0ops/lok8s/pkg/cluster/minikube"
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/services"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
+	"github.com/day0ops/lok8s/pkg/util/github"
+	"github.com/day0ops/lok8s/pkg/util/version"
+)
+
+// toolVersionPattern finds the version in the --version output of a tool, e.g. "vfkit version: v0.6.1"
+var toolVersionPattern = regexp.MustCompile(`v?([0-9]+\.[0-9]+\.[0-9]+)`)
+
+// toolVersion is the installed and latest release of lok8s or one of the tools it manages
+type toolVersion struct {
+	name      string
+	installed string // empty when the tool is not installed
+	latest    string // empty when the latest release couldn't be looked up
+}
+
+// status describes how the installed version compares to the latest release
+func (t toolVersion) status() string {
+	switch {
+	case t.installed == "":
+		return "not installed"
+	case !version.IsValidSemver(t.installed):
+		return "development build"
+	case t.latest == "":
+		return "unknown"
+	case version.Compare(t.installed, t.latest) < 0:
+		return "upgrade available"
+	default:
+		return "up to date"
+	}
+}
+
+// checkVersions looks up the installed and latest versions of lok8s and the tools it downloads or installs, vfkit
+// and vmnet-helper are only used on macOS
+func checkVersions() []toolVersion {
+	githubClient := github.NewGitHubClient()
+	latest := func(owner, repo string) string {
+		release, err := githubClient.GetLatestVersion(owner, repo)
+		if err != nil {
+			logger.Warnf("⚠️ failed to get the latest %s release: %v", repo, err)
+			return ""
+		}
+		return release
+	}
+
+	minikubeVersion, err := minikube.NewBinaryManager().InstalledVersion()
+	if err != nil {
+		logger.Debugf("%v", err)
+	}
+
+	versions := []toolVersion{
+		{name: config.AppName, installed: config.GetVersion(), latest: latest(config.AppRepoOwner, config.AppName)},
+		{name: "minikube", installed: minikubeVersion, latest: latest("kubernetes", "minikube")},
+		{name: "cloud-provider-kind", installed: services.NewCloudProviderKindManager().CachedVersion(), latest: latest("kubernetes-sigs", "cloud-provider-kind")},
+	}
+	if config.IsDarwin() {
+		versions = append(versions,
+			toolVersion{name: "vfkit", installed: commandVersion("vfkit", "--version"), latest: latest("crc-org", "vfkit")},
+			toolVersion{name: "vmnet-helper", installed: commandVersion(filepath.Join(config.VmnetHelperInstallPath, "bin", "vmnet-helper"), "--version"), latest: latest("minikube-machine", "vmnet-helper")},
+		)
+	}
+	return versions
+}
+
+// commandVersion returns the version a tool prints for the args, empty when it isn't installed
+func commandVersion(name string, args ...string) string {
+	output, err := utilexec.Output(name, args...)
+	if err != nil {
+		logger.Debugf("failed to get the %s version: %v", name, err)
+		return ""
+	}
+	return parseToolVersion(string(output))
+}
+
+// parseToolVersion returns the first x.y.z version in the output without its v prefix
+func parseToolVersion(output string) string {
+	if match := toolVersionPattern.FindStringSubmatch(output); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// printVersionCheck prints the versions as a table and returns whether any of them can be upgraded
+func printVersionCheck(w io.Writer, versions []toolVersion) bool {
+	upgradable := false
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "TOOL\tINSTALLED\tLATEST\tSTATUS")
+	for _, tool := range versions {
+		status := tool.status()
+		if status == "upgrade available" {
+			upgradable = true
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", tool.name, orDash(tool.installed), orDash(tool.latest), status)
+	}
+	tw.Flush()
+	return upgradable
+}
+
+// orDash returns the value, or "-" when it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
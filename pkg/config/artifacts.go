@@ -0,0 +1,55 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// artifactsDir keeps the generated kind configs and CNI manifests when set, they go to the temp dir otherwise
+var artifactsDir string
+
+// SetArtifactsDir writes the generated kind configs and CNI manifests to dir and keeps them, so the inputs of a
+// failed create can be inspected. An empty dir restores the temp dir
+func SetArtifactsDir(dir string) {
+	artifactsDir = dir
+}
+
+// KeepArtifacts reports whether the generated artifacts are kept once used
+func KeepArtifacts() bool {
+	return artifactsDir != ""
+}
+
+// ArtifactPath returns the path to write a generated artifact to, creating the artifacts dir when needed
+func ArtifactPath(name string) (string, error) {
+	if artifactsDir == "" {
+		return filepath.Join(os.TempDir(), name), nil
+	}
+
+	if err := os.MkdirAll(artifactsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory %s: %w", artifactsDir, err)
+	}
+	return filepath.Join(artifactsDir, name), nil
+}
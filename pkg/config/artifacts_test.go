@@ -0,0 +1,26 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Artifacts", func() {
+	It("should write to the temp dir and not keep the artifacts by default", func() {
+		Expect(KeepArtifacts()).To(BeFalse())
+		Expect(ArtifactPath("kind-kind1.yaml")).To(Equal(filepath.Join(os.TempDir(), "kind-kind1.yaml")))
+	})
+
+	It("should keep the artifacts in the artifacts dir, creating it", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "artifacts")
+		SetArtifactsDir(dir)
+		DeferCleanup(SetArtifactsDir, "")
+
+		Expect(KeepArtifacts()).To(BeTrue())
+		Expect(ArtifactPath("kind-kind1.yaml")).To(Equal(filepath.Join(dir, "kind-kind1.yaml")))
+		Expect(dir).To(BeADirectory())
+	})
+})
@@ -24,20 +24,50 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/util/version"
 )
 
 const (
 	// application info
 	AppName = "lok8s"
+	// GitHub owner of the lok8s repository, its releases are checked by version --check
+	AppRepoOwner = "day0ops"
+	// label set on the container networks lok8s creates
+	ManagedLabel = "lok8s.managed"
 
 	// network defaults
 	DefaultNetworkSubnetCIDR = "10.89.0.0/16"
+	// how far to move and how many subnets to probe when the requested libvirt subnet is taken
+	DefaultSubnetSearchStep  = 1
+	DefaultSubnetSearchLimit = 50
 
 	// cluster level defaults
 	DefaultClusterNum = 1
 	DefaultNodeCount  = 2
+	MaxClusterNum     = 3
+	// upper bound on clusters provisioned at the same time with --parallel
+	MaxParallelClusters = 3
+
+	// cluster IP families (Kind only)
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+	IPFamilyDual = "dual"
+
+	// MetalLB advertisement modes
+	MetalLBModeL2  = "l2"
+	MetalLBModeBGP = "bgp"
+
+	// how long to wait for nodes and add-ons to become ready during creation
+	DefaultReadinessTimeout = 5 * time.Minute
+
+	// how long to wait for the Cilium and Calico agents when no --wait-timeout is given, they take longer to roll out
+	DefaultCNIReadinessTimeout = 10 * time.Minute
 
 	// Kind defaults
 	KindNetworkName      = "kind"
@@ -45,6 +75,15 @@ const (
 	KindRegistryName     = "kind-registry"
 	KindRegistryPort     = 5000
 	KindControlPlanePort = 7000
+	KindPodSubnet        = "10.100.0.0/16"
+	KindServiceSubnet    = "10.255.100.0/24"
+	// IPv6 pod and service ranges used by ipv6 and dual-stack Kind clusters
+	KindPodSubnetIPv6     = "fd00:10:100::/56"
+	KindServiceSubnetIPv6 = "fd00:10:255::/112"
+	// KindNetworkSubnetIPv6 is added to the kind network so nodes get an IPv6 address
+	KindNetworkSubnetIPv6 = "fc00:f853:ccd:e793::/64"
+	// KindDefaultStorageClass is backed by the local-path provisioner bundled with kindest/node
+	KindDefaultStorageClass = "standard"
 
 	// Minikube defaults
 	MinikubeCPU                   = "4"
@@ -58,13 +97,22 @@ const (
 	// MinikubeServiceIPRangeBase is the base IP range for service cluster IP ranges
 	// Format: 10.255.{clusterIndex}.0/24
 	MinikubeServiceIPRangeBase = "10.255"
+	// MinikubeCSIStorageClass is created by the csi-hostpath-driver addon
+	MinikubeCSIStorageClass = "csi-hostpath-sc"
 
 	// MetalLB defaults
 	MetalLBRangeMinLastOctet = 200
 	MetalLBRangeMaxLastOctet = 254
+	// number of IPs allocated to each cluster's MetalLB pool
+	MetalLBDefaultIPsPerCluster = 20
+	// private ASNs MetalLB and its BGP peer use unless configured
+	MetalLBDefaultASN     = 64500
+	MetalLBDefaultPeerASN = 64501
 
 	// vfkit minimum supported version (macOS)
 	VfkitMinSupportedVersion = "0.6.1"
+	// where vmnet-helper is installed (macOS)
+	VmnetHelperInstallPath = "/opt/vmnet-helper"
 
 	// Minikube minimum supported version
 	MinikubeMinSupportedVersion = "1.36.0"
@@ -107,6 +155,7 @@ var (
 		"1.32": "1.32.6",
 	}
 
+	// KindRegistries maps registry mirror container names to the upstream registry they proxy
 	KindRegistries = map[string]string{
 		"docker":             "https://registry-1.docker.io",
 		"us-docker":          "https://us-docker.pkg.dev",
@@ -114,6 +163,25 @@ var (
 		"quay":               "https://quay.io",
 		"gcr":                "https://gcr.io",
 	}
+
+	// KindRegistryHosts maps registry hosts to the mirror container names in KindRegistries
+	KindRegistryHosts = map[string]string{
+		"docker.io":                  "docker",
+		"us-docker.pkg.dev":          "us-docker",
+		"us-central1-docker.pkg.dev": "us-central1-docker",
+		"quay.io":                    "quay",
+		"gcr.io":                     "gcr",
+	}
+
+	// supported values for the create options
+	ValidEnvironments      = []string{"minikube", "kind"}
+	ValidContainerRuntimes = []string{"containerd", "cri-o", "docker"}
+	ValidCNIs              = []string{"calico", "cilium", "flannel", "kindnet", "none"}
+	ValidIPFamilies        = []string{IPFamilyIPv4, IPFamilyIPv6, IPFamilyDual}
+	ValidContainerEngines  = []string{"docker", "podman"}
+	ValidMetalLBModes      = []string{MetalLBModeL2, MetalLBModeBGP}
+	// Helm releases installed by lok8s that accept --helm-set overrides
+	ValidHelmReleases = []string{"calico", "cilium", "metallb", "metrics-server"}
 )
 
 // GetOS returns the current operating system
@@ -136,6 +204,57 @@ func IsDarwin() bool {
 	return runtime.GOOS == "darwin"
 }
 
+// IsWSL returns true if running on Linux inside the Windows Subsystem for Linux (WSL2)
+func IsWSL() bool {
+	if !IsLinux() {
+		return false
+	}
+	procVersion, err := os.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	return isWSLKernel(string(procVersion))
+}
+
+// isWSLKernel reports whether /proc/version describes a WSL kernel, e.g. "Linux version 5.15.153.1-microsoft-standard-WSL2"
+func isWSLKernel(procVersion string) bool {
+	return strings.Contains(strings.ToLower(procVersion), "microsoft")
+}
+
+// UnsupportedOSError returns the error for an operating system lok8s can't provision clusters on
+func UnsupportedOSError() error {
+	return unsupportedOSError(GetOS())
+}
+
+// unsupportedOSError names the detected platform and the supported ones
+func unsupportedOSError(goos string) error {
+	if goos == "windows" {
+		return fmt.Errorf("unsupported operating system: windows. lok8s supports Linux and macOS, on Windows run it inside WSL2 where Docker-based kind clusters work")
+	}
+	return fmt.Errorf("unsupported operating system: %s. lok8s supports Linux (including WSL2) and macOS", goos)
+}
+
+// SupportedK8sVersions returns the minor versions in a version mapping (e.g. KindK8sVersions), newest first
+func SupportedK8sVersions(versions map[string]string) []string {
+	minors := make([]string, 0, len(versions))
+	for minor := range versions {
+		minors = append(minors, minor)
+	}
+	sort.Slice(minors, func(i, j int) bool {
+		return version.Compare(minors[i], minors[j]) > 0
+	})
+	return minors
+}
+
+// LatestK8sVersion returns the newest minor version in a version mapping and what it maps to, used for "stable"
+func LatestK8sVersion(versions map[string]string) (string, string, error) {
+	minors := SupportedK8sVersions(versions)
+	if len(minors) == 0 {
+		return "", "", fmt.Errorf("no Kubernetes versions available")
+	}
+	return minors[0], versions[minors[0]], nil
+}
+
 // GetMinikubeServiceIPRange returns the service cluster IP range for a given cluster index
 // Format: 10.255.{clusterIndex}.0/24
 // Example: clusterIndex 1 -> "10.255.1.0/24", clusterIndex 2 -> "10.255.2.0/24"
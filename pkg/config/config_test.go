@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/day0ops/lok8s/pkg/util/version"
 )
 
 var _ = Describe("Config", func() {
@@ -101,6 +103,64 @@ var _ = Describe("Config", func() {
 				expected := runtime.GOOS == "darwin"
 				Expect(IsDarwin()).To(Equal(expected))
 			})
+
+			It("should detect a WSL2 kernel", func() {
+				Expect(isWSLKernel("Linux version 5.15.153.1-microsoft-standard-WSL2 (root@1c602f52c2e4) (gcc (GCC) 11.2.0)")).To(BeTrue())
+				Expect(isWSLKernel("Linux version 6.8.0-45-generic (buildd@lcy02-amd64-115) (x86_64-linux-gnu-gcc-13)")).To(BeFalse())
+			})
+
+			It("should name the detected platform and the supported ones", func() {
+				Expect(unsupportedOSError("freebsd")).To(MatchError(ContainSubstring("unsupported operating system: freebsd. lok8s supports Linux (including WSL2) and macOS")))
+				Expect(unsupportedOSError("windows")).To(MatchError(ContainSubstring("run it inside WSL2")))
+			})
+		})
+
+		Context("Supported Kubernetes versions", func() {
+			It("should list every minor version newest first", func() {
+				versions := SupportedK8sVersions(map[string]string{
+					"1.9":  "v1.9.0",
+					"1.31": "v1.31.0",
+					"1.10": "v1.10.0",
+				})
+				Expect(versions).To(Equal([]string{"1.31", "1.10", "1.9"}))
+			})
+
+			It("should include all kind versions", func() {
+				Expect(SupportedK8sVersions(KindK8sVersions)).To(HaveLen(len(KindK8sVersions)))
+			})
+
+			It("should always pick the newest minor version as the latest", func() {
+				versions := map[string]string{
+					"1.9":  "1.9.11",
+					"1.32": "1.32.6",
+					"1.34": "1.34.0",
+					"1.33": "1.33.1",
+					"1.10": "1.10.13",
+				}
+				// map iteration order is random, so repeat to catch any dependence on it
+				for i := 0; i < 100; i++ {
+					minor, latest, err := LatestK8sVersion(versions)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(minor).To(Equal("1.34"))
+					Expect(latest).To(Equal("1.34.0"))
+				}
+			})
+
+			It("should pick the newest supported minikube and kind versions", func() {
+				for _, versions := range []map[string]string{MinikubeK8sVersions, KindK8sVersions} {
+					minor, _, err := LatestK8sVersion(versions)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(minor).To(Equal(SupportedK8sVersions(versions)[0]))
+					for other := range versions {
+						Expect(version.Compare(minor, other)).To(BeNumerically(">=", 0))
+					}
+				}
+			})
+
+			It("should fail when there are no versions", func() {
+				_, _, err := LatestK8sVersion(map[string]string{})
+				Expect(err).To(HaveOccurred())
+			})
 		})
 
 		Context("Platform detection consistency", func() {
@@ -0,0 +1,158 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigKeys returns the YAML keys of every ProjectConfig field, sorted
+func ConfigKeys() []string {
+	t := reflect.TypeOf(ProjectConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := yamlKey(t.Field(i)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// yamlKey returns the YAML key of a struct field
+func yamlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
+
+// SetField sets the field with the given YAML key from its command line form. Lists are comma separated
+// and maps are comma separated key=value pairs, an empty value clears the field
+func (pc *ProjectConfig) SetField(key, value string) error {
+	if key == "project" {
+		return fmt.Errorf("the project name can't be changed, create a new project instead")
+	}
+
+	v := reflect.ValueOf(pc).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if yamlKey(v.Type().Field(i)) != key {
+			continue
+		}
+
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.String:
+			field.SetString(value)
+		case field.Kind() == reflect.Int:
+			n := 0
+			if value != "" {
+				var err error
+				if n, err = strconv.Atoi(value); err != nil {
+					return fmt.Errorf("invalid value %q for %s, expected a number", value, key)
+				}
+			}
+			field.SetInt(int64(n))
+		case field.Kind() == reflect.Bool:
+			b := false
+			if value != "" {
+				var err error
+				if b, err = strconv.ParseBool(value); err != nil {
+					return fmt.Errorf("invalid value %q for %s, expected true or false", value, key)
+				}
+			}
+			field.SetBool(b)
+		case field.Type() == reflect.TypeOf([]string{}):
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		case field.Type() == reflect.TypeOf(map[string]string{}):
+			var entries map[string]string
+			for _, pair := range strings.Split(value, ",") {
+				if pair = strings.TrimSpace(pair); pair == "" {
+					continue
+				}
+				k, val, ok := strings.Cut(pair, "=")
+				if !ok || k == "" {
+					return fmt.Errorf("invalid value %q for %s, expected key=value pairs", pair, key)
+				}
+				if entries == nil {
+					entries = make(map[string]string)
+				}
+				entries[k] = val
+			}
+			field.Set(reflect.ValueOf(entries))
+		default:
+			return fmt.Errorf("%s can't be set from the command line, use 'config edit' instead", key)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown config key %q, valid keys are: %s", key, strings.Join(ConfigKeys(), ", "))
+}
+
+// unknownFieldPattern matches the yaml.v3 error for a key that isn't a struct field
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type \S+$`)
+
+// ParseProjectConfig parses a project config, rejecting any keys that aren't ProjectConfig fields
+func ParseProjectConfig(data []byte) (*ProjectConfig, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var config ProjectConfig
+	if err := decoder.Decode(&config); err != nil {
+		// an empty document is an empty config, same as yaml.Unmarshal
+		if errors.Is(err, io.EOF) {
+			return &config, nil
+		}
+
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+		problems := make([]string, 0, len(typeErr.Errors))
+		for _, msg := range typeErr.Errors {
+			if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+				msg = fmt.Sprintf("line %s: unknown key %q", m[1], m[2])
+			}
+			problems = append(problems, msg)
+		}
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return &config, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config fields", func() {
+	Describe("ConfigKeys", func() {
+		It("should list the YAML keys of the project config", func() {
+			keys := ConfigKeys()
+			Expect(keys).To(ContainElements("project", "node_count", "cni", "registry_mirrors", "metallb_allocations"))
+			Expect(keys).NotTo(ContainElement("NodeCount"))
+		})
+	})
+
+	Describe("SetField", func() {
+		var projectConfig *ProjectConfig
+
+		BeforeEach(func() {
+			projectConfig = &ProjectConfig{
+				Project:    "myproject",
+				NodeCount:  2,
+				NodeLabels: map[string]string{"tier": "web"},
+			}
+		})
+
+		It("should set scalar fields", func() {
+			Expect(projectConfig.SetField("node_count", "3")).To(Succeed())
+			Expect(projectConfig.SetField("cni", "calico")).To(Succeed())
+			Expect(projectConfig.SetField("install_metallb", "true")).To(Succeed())
+
+			Expect(projectConfig.NodeCount).To(Equal(3))
+			Expect(projectConfig.CNI).To(Equal("calico"))
+			Expect(projectConfig.InstallMetalLB).To(BeTrue())
+		})
+
+		It("should set lists and maps from comma separated values", func() {
+			Expect(projectConfig.SetField("node_taints", "dedicated=infra:NoSchedule, gpu=true:NoExecute")).To(Succeed())
+			Expect(projectConfig.SetField("node_labels", "tier=db,zone=a")).To(Succeed())
+
+			Expect(projectConfig.NodeTaints).To(Equal([]string{"dedicated=infra:NoSchedule", "gpu=true:NoExecute"}))
+			Expect(projectConfig.NodeLabels).To(Equal(map[string]string{"tier": "db", "zone": "a"}))
+		})
+
+		It("should clear a field given an empty value", func() {
+			Expect(projectConfig.SetField("node_labels", "")).To(Succeed())
+			Expect(projectConfig.SetField("node_count", "")).To(Succeed())
+
+			Expect(projectConfig.NodeLabels).To(BeEmpty())
+			Expect(projectConfig.NodeCount).To(BeZero())
+		})
+
+		It("should reject unknown keys", func() {
+			err := projectConfig.SetField("nodes", "3")
+			Expect(err).To(MatchError(ContainSubstring(`unknown config key "nodes"`)))
+			Expect(err).To(MatchError(ContainSubstring("node_count")))
+		})
+
+		It("should reject values of the wrong type", func() {
+			Expect(projectConfig.SetField("node_count", "three")).To(MatchError(ContainSubstring("expected a number")))
+			Expect(projectConfig.SetField("skip_csi", "maybe")).To(MatchError(ContainSubstring("expected true or false")))
+			Expect(projectConfig.SetField("node_labels", "tier")).To(MatchError(ContainSubstring("expected key=value pairs")))
+			Expect(projectConfig.NodeCount).To(Equal(2))
+		})
+
+		It("should refuse to change the project or set structured fields", func() {
+			Expect(projectConfig.SetField("project", "other")).To(HaveOccurred())
+			Expect(projectConfig.SetField("worker_nodes", "1")).To(MatchError(ContainSubstring("config edit")))
+			Expect(projectConfig.Project).To(Equal("myproject"))
+		})
+	})
+
+	Describe("ParseProjectConfig", func() {
+		It("should parse a valid config", func() {
+			projectConfig, err := ParseProjectConfig([]byte("project: myproject\nnode_count: 3\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projectConfig.Project).To(Equal("myproject"))
+			Expect(projectConfig.NodeCount).To(Equal(3))
+		})
+
+		It("should treat an empty document as an empty config", func() {
+			projectConfig, err := ParseProjectConfig([]byte(""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*projectConfig).To(BeZero())
+		})
+
+		It("should report the line of every unknown key", func() {
+			_, err := ParseProjectConfig([]byte("project: myproject\nnum_cluster: 3\nworker_nodes:\n  1:\n    label:\n      pool: gpu\n"))
+			Expect(err).To(MatchError(`line 2: unknown key "num_cluster"; line 5: unknown key "label"`))
+		})
+
+		It("should reject values of the wrong type", func() {
+			_, err := ParseProjectConfig([]byte("project: myproject\nnode_count: three\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+		})
+	})
+})
@@ -25,7 +25,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/day0ops/lok8s/pkg/logger"
 	"gopkg.in/yaml.v3"
@@ -40,29 +42,113 @@ type ProjectConfig struct {
 	NumClusters int    `yaml:"num_clusters"`
 	NodeCount   int    `yaml:"node_count"`
 	K8sVersion  string `yaml:"k8s_version"`
+	NodeImage   string `yaml:"node_image,omitempty"`
+	// base name of the kind clusters (kind only), kind1, kind2, ... when empty
+	ClusterPrefix string `yaml:"cluster_prefix,omitempty"`
 
 	// network options
-	GatewayIP  string `yaml:"gateway_ip"`
-	SubnetCIDR string `yaml:"subnet_cidr"`
-	Bridge     string `yaml:"bridge"`
+	GatewayIP     string `yaml:"gateway_ip"`
+	SubnetCIDR    string `yaml:"subnet_cidr"`
+	Bridge        string `yaml:"bridge"`
+	PodSubnet     string `yaml:"pod_subnet,omitempty"`
+	ServiceSubnet string `yaml:"service_subnet,omitempty"`
+	IPFamily      string `yaml:"ip_family,omitempty"` // ipv4, ipv6 or dual (kind only)
 
 	// minikube specific options
 	CPU      string `yaml:"cpu"`
 	Memory   string `yaml:"memory"`
 	DiskSize string `yaml:"disk_size"`
+	// start multi-control-plane clusters, the API server certificate covers the VIP reserved in the libvirt network (minikube only)
+	HA bool `yaml:"ha,omitempty"`
+
+	// storage and metrics add-ons enabled at creation (on unless skipped)
+	SkipCSI           bool `yaml:"skip_csi,omitempty"`
+	SkipMetricsServer bool `yaml:"skip_metrics_server,omitempty"`
+	// extra local-path StorageClass made the default in place of standard, e.g. gp2 (kind only)
+	StorageClass string `yaml:"storage_class,omitempty"`
 
 	// kind specific options
 	CNI              string `yaml:"cni"`
 	ContainerRuntime string `yaml:"container_runtime"`
 	ContainerEngine  string `yaml:"container_engine"`
+	RegistryPort     int    `yaml:"registry_port,omitempty"`
+
+	// engines to try in order when ContainerEngine is not set, defaults to docker then podman
+	ContainerEnginePreference []string `yaml:"container_engine_preference,omitempty"`
+
+	// release.key=value overrides merged over the built-in Helm values, e.g. metallb.speaker.frr.enabled=true
+	HelmSet []string `yaml:"helm_set,omitempty"`
+	// pinned Helm chart versions, the latest chart is used when empty
+	CiliumChartVersion  string `yaml:"cilium_chart_version,omitempty"`
+	MetalLBChartVersion string `yaml:"metallb_chart_version,omitempty"`
+	// local chart directories or archives installed in place of the repo charts, e.g. for air-gapped hosts
+	CiliumChart  string `yaml:"cilium_chart,omitempty"`
+	MetalLBChart string `yaml:"metallb_chart,omitempty"`
+	// Helm values files merged beneath the HelmSet overrides
+	CiliumValuesFile  string `yaml:"cilium_values_file,omitempty"`
+	MetalLBValuesFile string `yaml:"metallb_values_file,omitempty"`
+	// connect the Cilium installs of all clusters into a cluster mesh (kind only)
+	CiliumClusterMesh bool `yaml:"cilium_clustermesh,omitempty"`
+
+	// registry host -> upstream URL, merged on top of the default kind registry mirrors
+	RegistryMirrors map[string]string `yaml:"registry_mirrors,omitempty"`
+	// only start the mirrors of these registry hosts (e.g. docker.io), all of them when empty
+	RegistryMirrorHosts []string `yaml:"registry_mirror_hosts,omitempty"`
+	// start no registry mirrors at all, only the local kind-registry
+	NoRegistryMirrors bool `yaml:"no_registry_mirrors,omitempty"`
+	// registry host -> username:password used by its mirror to pull from the upstream, $VAR references are
+	// expanded when the mirrors start so the secrets themselves don't need to be saved
+	RegistryAuth map[string]string `yaml:"registry_auth,omitempty"`
+	// serve the local kind-registry over https with a self-signed certificate instead of plain http
+	RegistryTLS bool `yaml:"registry_tls,omitempty"`
+	// images loaded into every cluster once it is created, so a recreate re-warms the clusters
+	PrefetchImages []string `yaml:"prefetch_images,omitempty"`
+
+	// labels and taints (key=value:Effect) applied to every kind worker node
+	NodeLabels map[string]string `yaml:"node_labels,omitempty"`
+	NodeTaints []string          `yaml:"node_taints,omitempty"`
+	// fake nvidia.com/gpu resources advertised by every kind worker node, for scheduler testing
+	NodeGPUs int `yaml:"node_gpus,omitempty"`
+	// per worker overrides keyed by worker index (starting at 1), added on top of NodeLabels and NodeTaints
+	WorkerNodes map[int]WorkerNodeConfig `yaml:"worker_nodes,omitempty"`
+	// extra hostPort:containerPort[/protocol] mappings published by the kind control-plane node
+	ExtraPortMappings []string `yaml:"extra_port_mappings,omitempty"`
+	// host:container directories mounted into every node, minikube supports a single mount
+	Mounts []string `yaml:"mounts,omitempty"`
 
 	// load balancer options
 	InstallMetalLB       bool `yaml:"install_metallb"`
 	InstallCloudProvider bool `yaml:"install_cloud_provider"`
 	SkipMetalLB          bool `yaml:"skip_metallb"`
+	MetalLBPoolSize      int  `yaml:"metallb_pool_size,omitempty"`
+	// exact MetalLB pools used instead of generated ones, comma separated x.x.x.start-x.x.x.end ranges, one per cluster
+	MetalLBIPRange string `yaml:"metallb_ip_range,omitempty"`
+	// how MetalLB advertises the service IPs, l2 (default) or bgp to the peer router below
+	MetalLBMode        string `yaml:"metallb_mode,omitempty"`
+	MetalLBPeerAddress string `yaml:"metallb_peer_address,omitempty"`
+	MetalLBPeerASN     int    `yaml:"metallb_peer_asn,omitempty"`
+	MetalLBASN         int    `yaml:"metallb_asn,omitempty"`
+	// pinned cloud-provider-kind release, the latest release is downloaded when empty
+	CloudProviderVersion string `yaml:"cloud_provider_version,omitempty"`
 
 	// MetalLB IP allocation tracking
 	MetalLBAllocations []MetalLBAllocation `yaml:"metallb_allocations,omitempty"`
+
+	// host ports cloud-provider-kind last published for the LoadBalancer services, to report the ones that changed
+	LoadBalancerPorts []LoadBalancerPort `yaml:"load_balancer_ports,omitempty"`
+
+	// Cilium cluster IDs assigned to the meshed clusters, keyed by kubeconfig context
+	CiliumClusterIDs map[string]int `yaml:"cilium_cluster_ids,omitempty"`
+
+	// set while a create is running, still set afterwards when that create failed partway
+	CreateInProgress bool `yaml:"create_in_progress,omitempty"`
+}
+
+// WorkerNodeConfig holds the scheduling labels, taints and fake GPUs for a single kind worker node
+type WorkerNodeConfig struct {
+	Labels map[string]string `yaml:"labels,omitempty"`
+	Taints []string          `yaml:"taints,omitempty"` // key=value:Effect
+	GPUs   int               `yaml:"gpus,omitempty"`   // fake nvidia.com/gpu resources, replaces NodeGPUs for this worker
 }
 
 // MetalLBAllocation tracks IP ranges and node IPs for a cluster
@@ -75,6 +161,15 @@ type MetalLBAllocation struct {
 	IPRange     string `yaml:"ip_range"`    // full IP range string (x.x.x.start-x.x.x.end)
 }
 
+// LoadBalancerPort is a host port cloud-provider-kind published for a port of a LoadBalancer service
+type LoadBalancerPort struct {
+	Cluster      string `yaml:"cluster"`
+	LoadBalancer string `yaml:"load_balancer"`
+	ServicePort  string `yaml:"service_port"`
+	Protocol     string `yaml:"protocol"`
+	HostPort     string `yaml:"host_port"`
+}
+
 // ConfigManager handles project configuration persistence
 type ConfigManager struct {
 	configDir string
@@ -122,13 +217,13 @@ func (cm *ConfigManager) LoadConfig(project string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
 	}
 
-	var config ProjectConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	config, err := ParseProjectConfig(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
 
 	logger.Debugf("loaded config for project %s from %s", project, configPath)
-	return &config, nil
+	return config, nil
 }
 
 // SaveConfig saves configuration for a project
@@ -193,8 +288,40 @@ func (cm *ConfigManager) ListConfigs() ([]string, error) {
 	return projects, nil
 }
 
+// ExpandPath expands a leading ~ or ~user to the home directory and any environment variables in path,
+// for paths the shell didn't expand such as quoted flag values
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+
+	name, rest, _ := strings.Cut(path[1:], string(filepath.Separator))
+	var homeDir string
+	if name == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		homeDir = home
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to expand %s: %w", path, err)
+		}
+		homeDir = u.HomeDir
+	}
+
+	return filepath.Join(homeDir, rest), nil
+}
+
 // LoadConfigFromFile loads configuration from a user-defined YAML file
 func LoadConfigFromFile(filePath string) (*ProjectConfig, error) {
+	filePath, err := ExpandPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("config file does not exist: %s", filePath)
@@ -206,13 +333,16 @@ func LoadConfigFromFile(filePath string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to read config file %s: %w", filePath, err)
 	}
 
-	var config ProjectConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	config, err := ParseProjectConfig(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", filePath, err)
 	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", filePath, err)
+	}
 
 	logger.Debugf("loaded config from file: %s", filePath)
-	return &config, nil
+	return config, nil
 }
 
 // MergeConfigs merges two configurations, with the second one taking precedence
@@ -232,6 +362,9 @@ func MergeConfigs(base, override *ProjectConfig) *ProjectConfig {
 	if override.NodeCount > 0 {
 		merged.NodeCount = override.NodeCount
 	}
+	if override.HA {
+		merged.HA = true
+	}
 	if override.K8sVersion != "" {
 		merged.K8sVersion = override.K8sVersion
 	}
@@ -262,11 +395,126 @@ func MergeConfigs(base, override *ProjectConfig) *ProjectConfig {
 	if override.ContainerEngine != "" {
 		merged.ContainerEngine = override.ContainerEngine
 	}
+	if len(override.ContainerEnginePreference) > 0 {
+		merged.ContainerEnginePreference = override.ContainerEnginePreference
+	}
+	if len(override.HelmSet) > 0 {
+		merged.HelmSet = override.HelmSet
+	}
+	if override.CiliumChartVersion != "" {
+		merged.CiliumChartVersion = override.CiliumChartVersion
+	}
+	if override.MetalLBChartVersion != "" {
+		merged.MetalLBChartVersion = override.MetalLBChartVersion
+	}
+	if override.CloudProviderVersion != "" {
+		merged.CloudProviderVersion = override.CloudProviderVersion
+	}
+	if override.CiliumChart != "" {
+		merged.CiliumChart = override.CiliumChart
+	}
+	if override.MetalLBChart != "" {
+		merged.MetalLBChart = override.MetalLBChart
+	}
+	if override.CiliumValuesFile != "" {
+		merged.CiliumValuesFile = override.CiliumValuesFile
+	}
+	if override.MetalLBValuesFile != "" {
+		merged.MetalLBValuesFile = override.MetalLBValuesFile
+	}
+	if override.CiliumClusterMesh {
+		merged.CiliumClusterMesh = true
+	}
+	if override.NodeImage != "" {
+		merged.NodeImage = override.NodeImage
+	}
+	if override.ClusterPrefix != "" {
+		merged.ClusterPrefix = override.ClusterPrefix
+	}
+	if override.StorageClass != "" {
+		merged.StorageClass = override.StorageClass
+	}
+	if override.PodSubnet != "" {
+		merged.PodSubnet = override.PodSubnet
+	}
+	if override.ServiceSubnet != "" {
+		merged.ServiceSubnet = override.ServiceSubnet
+	}
+	if override.IPFamily != "" {
+		merged.IPFamily = override.IPFamily
+	}
+	if override.RegistryPort > 0 {
+		merged.RegistryPort = override.RegistryPort
+	}
+	if len(override.RegistryMirrors) > 0 {
+		merged.RegistryMirrors = override.RegistryMirrors
+	}
+	if len(override.NodeLabels) > 0 {
+		merged.NodeLabels = override.NodeLabels
+	}
+	if len(override.NodeTaints) > 0 {
+		merged.NodeTaints = override.NodeTaints
+	}
+	if override.NodeGPUs > 0 {
+		merged.NodeGPUs = override.NodeGPUs
+	}
+	if len(override.WorkerNodes) > 0 {
+		merged.WorkerNodes = override.WorkerNodes
+	}
+	if len(override.ExtraPortMappings) > 0 {
+		merged.ExtraPortMappings = override.ExtraPortMappings
+	}
+	if len(override.Mounts) > 0 {
+		merged.Mounts = override.Mounts
+	}
+	if len(override.RegistryMirrorHosts) > 0 {
+		merged.RegistryMirrorHosts = override.RegistryMirrorHosts
+	}
+	if override.NoRegistryMirrors {
+		merged.NoRegistryMirrors = true
+	}
+	if len(override.RegistryAuth) > 0 {
+		merged.RegistryAuth = override.RegistryAuth
+	}
+	if override.RegistryTLS {
+		merged.RegistryTLS = true
+	}
+	if len(override.PrefetchImages) > 0 {
+		merged.PrefetchImages = override.PrefetchImages
+	}
+	if override.MetalLBPoolSize > 0 {
+		merged.MetalLBPoolSize = override.MetalLBPoolSize
+	}
+	if override.MetalLBIPRange != "" {
+		merged.MetalLBIPRange = override.MetalLBIPRange
+	}
+	if override.MetalLBMode != "" {
+		merged.MetalLBMode = override.MetalLBMode
+		// the peer settings only apply to bgp, switching to l2 drops them
+		if override.MetalLBMode == MetalLBModeL2 {
+			merged.MetalLBPeerAddress, merged.MetalLBPeerASN, merged.MetalLBASN = "", 0, 0
+		}
+	}
+	if override.MetalLBPeerAddress != "" {
+		merged.MetalLBPeerAddress = override.MetalLBPeerAddress
+	}
+	if override.MetalLBPeerASN > 0 {
+		merged.MetalLBPeerASN = override.MetalLBPeerASN
+	}
+	if override.MetalLBASN > 0 {
+		merged.MetalLBASN = override.MetalLBASN
+	}
 
 	// boolean flags are always overridden
 	merged.InstallMetalLB = override.InstallMetalLB
 	merged.InstallCloudProvider = override.InstallCloudProvider
 	merged.SkipMetalLB = override.SkipMetalLB
+	if override.SkipCSI {
+		merged.SkipCSI = true
+	}
+	if override.SkipMetricsServer {
+		merged.SkipMetricsServer = true
+	}
 
 	return &merged
 }
@@ -300,6 +548,9 @@ func (cm *ConfigManager) MergeConfig(project string, cmdConfig *ProjectConfig) (
 	if cmdConfig.NodeCount > 0 {
 		mergedConfig.NodeCount = cmdConfig.NodeCount
 	}
+	if cmdConfig.HA {
+		mergedConfig.HA = true
+	}
 	if cmdConfig.K8sVersion != "" {
 		mergedConfig.K8sVersion = cmdConfig.K8sVersion
 	}
@@ -330,11 +581,126 @@ func (cm *ConfigManager) MergeConfig(project string, cmdConfig *ProjectConfig) (
 	if cmdConfig.ContainerEngine != "" {
 		mergedConfig.ContainerEngine = cmdConfig.ContainerEngine
 	}
+	if len(cmdConfig.ContainerEnginePreference) > 0 {
+		mergedConfig.ContainerEnginePreference = cmdConfig.ContainerEnginePreference
+	}
+	if len(cmdConfig.HelmSet) > 0 {
+		mergedConfig.HelmSet = cmdConfig.HelmSet
+	}
+	if cmdConfig.CiliumChartVersion != "" {
+		mergedConfig.CiliumChartVersion = cmdConfig.CiliumChartVersion
+	}
+	if cmdConfig.MetalLBChartVersion != "" {
+		mergedConfig.MetalLBChartVersion = cmdConfig.MetalLBChartVersion
+	}
+	if cmdConfig.CloudProviderVersion != "" {
+		mergedConfig.CloudProviderVersion = cmdConfig.CloudProviderVersion
+	}
+	if cmdConfig.CiliumChart != "" {
+		mergedConfig.CiliumChart = cmdConfig.CiliumChart
+	}
+	if cmdConfig.MetalLBChart != "" {
+		mergedConfig.MetalLBChart = cmdConfig.MetalLBChart
+	}
+	if cmdConfig.CiliumValuesFile != "" {
+		mergedConfig.CiliumValuesFile = cmdConfig.CiliumValuesFile
+	}
+	if cmdConfig.MetalLBValuesFile != "" {
+		mergedConfig.MetalLBValuesFile = cmdConfig.MetalLBValuesFile
+	}
+	if cmdConfig.CiliumClusterMesh {
+		mergedConfig.CiliumClusterMesh = true
+	}
+	if cmdConfig.NodeImage != "" {
+		mergedConfig.NodeImage = cmdConfig.NodeImage
+	}
+	if cmdConfig.ClusterPrefix != "" {
+		mergedConfig.ClusterPrefix = cmdConfig.ClusterPrefix
+	}
+	if cmdConfig.StorageClass != "" {
+		mergedConfig.StorageClass = cmdConfig.StorageClass
+	}
+	if cmdConfig.PodSubnet != "" {
+		mergedConfig.PodSubnet = cmdConfig.PodSubnet
+	}
+	if cmdConfig.ServiceSubnet != "" {
+		mergedConfig.ServiceSubnet = cmdConfig.ServiceSubnet
+	}
+	if cmdConfig.IPFamily != "" {
+		mergedConfig.IPFamily = cmdConfig.IPFamily
+	}
+	if cmdConfig.RegistryPort > 0 {
+		mergedConfig.RegistryPort = cmdConfig.RegistryPort
+	}
+	if len(cmdConfig.RegistryMirrors) > 0 {
+		mergedConfig.RegistryMirrors = cmdConfig.RegistryMirrors
+	}
+	if len(cmdConfig.NodeLabels) > 0 {
+		mergedConfig.NodeLabels = cmdConfig.NodeLabels
+	}
+	if len(cmdConfig.NodeTaints) > 0 {
+		mergedConfig.NodeTaints = cmdConfig.NodeTaints
+	}
+	if cmdConfig.NodeGPUs > 0 {
+		mergedConfig.NodeGPUs = cmdConfig.NodeGPUs
+	}
+	if len(cmdConfig.WorkerNodes) > 0 {
+		mergedConfig.WorkerNodes = cmdConfig.WorkerNodes
+	}
+	if len(cmdConfig.ExtraPortMappings) > 0 {
+		mergedConfig.ExtraPortMappings = cmdConfig.ExtraPortMappings
+	}
+	if len(cmdConfig.Mounts) > 0 {
+		mergedConfig.Mounts = cmdConfig.Mounts
+	}
+	if len(cmdConfig.RegistryMirrorHosts) > 0 {
+		mergedConfig.RegistryMirrorHosts = cmdConfig.RegistryMirrorHosts
+	}
+	if cmdConfig.NoRegistryMirrors {
+		mergedConfig.NoRegistryMirrors = true
+	}
+	if len(cmdConfig.RegistryAuth) > 0 {
+		mergedConfig.RegistryAuth = cmdConfig.RegistryAuth
+	}
+	if cmdConfig.RegistryTLS {
+		mergedConfig.RegistryTLS = true
+	}
+	if len(cmdConfig.PrefetchImages) > 0 {
+		mergedConfig.PrefetchImages = cmdConfig.PrefetchImages
+	}
+	if cmdConfig.MetalLBPoolSize > 0 {
+		mergedConfig.MetalLBPoolSize = cmdConfig.MetalLBPoolSize
+	}
+	if cmdConfig.MetalLBIPRange != "" {
+		mergedConfig.MetalLBIPRange = cmdConfig.MetalLBIPRange
+	}
+	if cmdConfig.MetalLBMode != "" {
+		mergedConfig.MetalLBMode = cmdConfig.MetalLBMode
+		// the peer settings only apply to bgp, switching to l2 drops them
+		if cmdConfig.MetalLBMode == MetalLBModeL2 {
+			mergedConfig.MetalLBPeerAddress, mergedConfig.MetalLBPeerASN, mergedConfig.MetalLBASN = "", 0, 0
+		}
+	}
+	if cmdConfig.MetalLBPeerAddress != "" {
+		mergedConfig.MetalLBPeerAddress = cmdConfig.MetalLBPeerAddress
+	}
+	if cmdConfig.MetalLBPeerASN > 0 {
+		mergedConfig.MetalLBPeerASN = cmdConfig.MetalLBPeerASN
+	}
+	if cmdConfig.MetalLBASN > 0 {
+		mergedConfig.MetalLBASN = cmdConfig.MetalLBASN
+	}
 
 	// boolean flags are always overridden by command line
 	mergedConfig.InstallMetalLB = cmdConfig.InstallMetalLB
 	mergedConfig.InstallCloudProvider = cmdConfig.InstallCloudProvider
 	mergedConfig.SkipMetalLB = cmdConfig.SkipMetalLB
+	if cmdConfig.SkipCSI {
+		mergedConfig.SkipCSI = true
+	}
+	if cmdConfig.SkipMetricsServer {
+		mergedConfig.SkipMetricsServer = true
+	}
 
 	return &mergedConfig, nil
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"os/user"
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -68,6 +69,39 @@ var _ = Describe("ProjectConfig", func() {
 						InstallMetalLB:       false,
 						InstallCloudProvider: true,
 						SkipMetalLB:          true,
+						RegistryPort:         30001,
+						NodeImage:            "example.com/kindest/node:custom",
+						ClusterPrefix:        "dev",
+						StorageClass:         "gp2",
+						PodSubnet:            "10.120.0.0/16",
+						ServiceSubnet:        "10.121.0.0/24",
+						IPFamily:             "dual",
+						NodeLabels:           map[string]string{"env": "dev"},
+						NodeTaints:           []string{"dedicated=lok8s:NoSchedule"},
+						NodeGPUs:             1,
+						WorkerNodes: map[int]WorkerNodeConfig{
+							2: {Labels: map[string]string{"pool": "gpu"}, Taints: []string{"nvidia.com/gpu=present:NoSchedule"}, GPUs: 4},
+						},
+						ExtraPortMappings:         []string{"8080:30080", "8443:30443/tcp"},
+						Mounts:                    []string{"/home/dev/src:/src"},
+						ContainerEnginePreference: []string{"podman", "docker"},
+						HelmSet:                   []string{"metallb.speaker.frr.enabled=true"},
+						CiliumChartVersion:        "1.16.5",
+						MetalLBChartVersion:       "0.14.9",
+						CloudProviderVersion:      "0.6.0",
+						CiliumValuesFile:          "/home/dev/cilium-values.yaml",
+						MetalLBValuesFile:         "/home/dev/metallb-values.yaml",
+						CiliumClusterMesh:         true,
+						RegistryMirrorHosts:       []string{"docker.io", "quay.io"},
+						NoRegistryMirrors:         true,
+						RegistryAuth:              map[string]string{"docker.io": "$DOCKER_USER:$DOCKER_TOKEN"},
+						RegistryTLS:               true,
+						PrefetchImages:            []string{"nginx:1.27", "busybox:1.36"},
+						CiliumClusterIDs:          map[string]int{"test-project-1": 1, "test-project-2": 2},
+						LoadBalancerPorts: []LoadBalancerPort{
+							{Cluster: "kind1", LoadBalancer: "default/nginx", ServicePort: "80", Protocol: "TCP", HostPort: "54321"},
+						},
+						CreateInProgress: true,
 					}
 
 					// Save config
@@ -91,16 +125,50 @@ var _ = Describe("ProjectConfig", func() {
 					Expect(loadedConfig.CNI).To(Equal(config.CNI))
 					Expect(loadedConfig.InstallMetalLB).To(Equal(config.InstallMetalLB))
 					Expect(loadedConfig.InstallCloudProvider).To(Equal(config.InstallCloudProvider))
+					Expect(loadedConfig.RegistryPort).To(Equal(config.RegistryPort))
+					Expect(loadedConfig.NodeImage).To(Equal(config.NodeImage))
+					Expect(loadedConfig.ClusterPrefix).To(Equal(config.ClusterPrefix))
+					Expect(loadedConfig.PodSubnet).To(Equal(config.PodSubnet))
+					Expect(loadedConfig.ServiceSubnet).To(Equal(config.ServiceSubnet))
+					Expect(loadedConfig.IPFamily).To(Equal(config.IPFamily))
+					Expect(loadedConfig.NodeLabels).To(Equal(config.NodeLabels))
+					Expect(loadedConfig.NodeTaints).To(Equal(config.NodeTaints))
+					Expect(loadedConfig.NodeGPUs).To(Equal(config.NodeGPUs))
+					Expect(loadedConfig.StorageClass).To(Equal(config.StorageClass))
+					Expect(loadedConfig.LoadBalancerPorts).To(Equal(config.LoadBalancerPorts))
+					Expect(loadedConfig.WorkerNodes).To(Equal(config.WorkerNodes))
+					Expect(loadedConfig.ExtraPortMappings).To(Equal(config.ExtraPortMappings))
+					Expect(loadedConfig.Mounts).To(Equal(config.Mounts))
+					Expect(loadedConfig.ContainerEnginePreference).To(Equal(config.ContainerEnginePreference))
+					Expect(loadedConfig.HelmSet).To(Equal(config.HelmSet))
+					Expect(loadedConfig.CiliumChartVersion).To(Equal(config.CiliumChartVersion))
+					Expect(loadedConfig.MetalLBChartVersion).To(Equal(config.MetalLBChartVersion))
+					Expect(loadedConfig.CloudProviderVersion).To(Equal(config.CloudProviderVersion))
+					Expect(loadedConfig.CiliumValuesFile).To(Equal(config.CiliumValuesFile))
+					Expect(loadedConfig.MetalLBValuesFile).To(Equal(config.MetalLBValuesFile))
+					Expect(loadedConfig.CiliumClusterMesh).To(BeTrue())
+					Expect(loadedConfig.RegistryMirrorHosts).To(Equal(config.RegistryMirrorHosts))
+					Expect(loadedConfig.NoRegistryMirrors).To(BeTrue())
+					Expect(loadedConfig.CreateInProgress).To(BeTrue())
+					Expect(loadedConfig.RegistryAuth).To(Equal(config.RegistryAuth))
+					Expect(loadedConfig.RegistryTLS).To(BeTrue())
+					Expect(loadedConfig.PrefetchImages).To(Equal(config.PrefetchImages))
+					Expect(loadedConfig.CiliumClusterIDs).To(Equal(config.CiliumClusterIDs))
 				})
 
 				It("should save and load config with MetalLB allocations", func() {
 					project := "test-project-metallb"
 					config := &ProjectConfig{
-						Project:     project,
-						Environment: "kind",
-						NumClusters: 2,
-						NodeCount:   3,
-						K8sVersion:  "v1.28.0",
+						Project:            project,
+						Environment:        "kind",
+						NumClusters:        2,
+						NodeCount:          3,
+						K8sVersion:         "v1.28.0",
+						MetalLBPoolSize:    10,
+						MetalLBIPRange:     "192.168.102.240-192.168.102.245,192.168.102.246-192.168.102.250",
+						MetalLBMode:        MetalLBModeBGP,
+						MetalLBPeerAddress: "172.18.0.1",
+						MetalLBPeerASN:     65001,
 						MetalLBAllocations: []MetalLBAllocation{
 							{
 								ClusterName: "test-project-1",
@@ -131,6 +199,11 @@ var _ = Describe("ProjectConfig", func() {
 					Expect(loadedConfig).NotTo(BeNil())
 
 					// Verify MetalLB allocations
+					Expect(loadedConfig.MetalLBPoolSize).To(Equal(10))
+					Expect(loadedConfig.MetalLBIPRange).To(Equal("192.168.102.240-192.168.102.245,192.168.102.246-192.168.102.250"))
+					Expect(loadedConfig.MetalLBMode).To(Equal(MetalLBModeBGP))
+					Expect(loadedConfig.MetalLBPeerAddress).To(Equal("172.18.0.1"))
+					Expect(loadedConfig.MetalLBPeerASN).To(Equal(65001))
 					Expect(loadedConfig.MetalLBAllocations).To(HaveLen(2))
 					Expect(loadedConfig.MetalLBAllocations[0].ClusterName).To(Equal("test-project-1"))
 					Expect(loadedConfig.MetalLBAllocations[0].IPPrefix).To(Equal("192.168.102"))
@@ -148,6 +221,18 @@ var _ = Describe("ProjectConfig", func() {
 				})
 			})
 
+			Context("Load config with unknown keys", func() {
+				It("should reject the saved config", func() {
+					project := "typo-project"
+					err := os.WriteFile(cm.GetConfigPath(project), []byte("project: typo-project\nnode_cont: 3\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					config, err := cm.LoadConfig(project)
+					Expect(err).To(MatchError(ContainSubstring(`line 2: unknown key "node_cont"`)))
+					Expect(config).To(BeNil())
+				})
+			})
+
 			Context("Load non-existent config", func() {
 				It("should return nil for non-existent project", func() {
 					project := "non-existent-project"
@@ -224,6 +309,51 @@ var _ = Describe("ProjectConfig", func() {
 		})
 	})
 
+	Describe("ExpandPath", func() {
+		var homeDir string
+
+		BeforeEach(func() {
+			homeDir = GinkgoT().TempDir()
+			GinkgoT().Setenv("HOME", homeDir)
+		})
+
+		It("should expand a bare ~ to the home directory", func() {
+			Expect(ExpandPath("~")).To(Equal(homeDir))
+			Expect(ExpandPath("~/clusters/dev.yaml")).To(Equal(filepath.Join(homeDir, "clusters", "dev.yaml")))
+		})
+
+		It("should expand ~user to that user's home directory", func() {
+			current, err := user.Current()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ExpandPath("~" + current.Username + "/dev.yaml")).To(Equal(filepath.Join(current.HomeDir, "dev.yaml")))
+		})
+
+		It("should fail for an unknown user", func() {
+			_, err := ExpandPath("~no-such-lok8s-user/dev.yaml")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should expand environment variables", func() {
+			GinkgoT().Setenv("LOK8S_CLUSTERS", "/srv/clusters")
+			Expect(ExpandPath("$HOME/dev.yaml")).To(Equal(filepath.Join(homeDir, "dev.yaml")))
+			Expect(ExpandPath("${LOK8S_CLUSTERS}/dev.yaml")).To(Equal("/srv/clusters/dev.yaml"))
+		})
+
+		It("should leave paths that need no expansion alone", func() {
+			Expect(ExpandPath("/etc/lok8s/dev.yaml")).To(Equal("/etc/lok8s/dev.yaml"))
+			Expect(ExpandPath("configs/dev~1.yaml")).To(Equal("configs/dev~1.yaml"))
+		})
+
+		It("should be applied when loading a config file", func() {
+			Expect(os.WriteFile(filepath.Join(homeDir, "dev.yaml"), []byte("project: dev\n"), 0644)).To(Succeed())
+
+			config, err := LoadConfigFromFile("~/dev.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Project).To(Equal("dev"))
+		})
+	})
+
 	Describe("User-defined config files", func() {
 		Context("LoadConfigFromFile", func() {
 			var tempDir string
@@ -240,7 +370,7 @@ var _ = Describe("ProjectConfig", func() {
 environment: "kind"
 num_clusters: 2
 node_count: 3
-kubernetes_version: "v1.28.0"
+k8s_version: "v1.28.0"
 gateway_ip: "10.89.0.1"
 subnet_cidr: "10.89.0.0/16"
 cni: "cilium"
@@ -265,6 +395,22 @@ skip_metallb: true`
 				Expect(config.CNI).To(Equal("cilium"))
 				Expect(config.InstallMetalLB).To(BeFalse())
 				Expect(config.InstallCloudProvider).To(BeTrue())
+				Expect(config.K8sVersion).To(Equal("v1.28.0"))
+			})
+
+			It("should reject misspelled keys with their line", func() {
+				configFile := filepath.Join(tempDir, "typo-config.yaml")
+
+				yamlContent := `project: "test-project"
+environment: "kind"
+num_cluster: 3`
+
+				err := os.WriteFile(configFile, []byte(yamlContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				config, err := LoadConfigFromFile(configFile)
+				Expect(err).To(MatchError(ContainSubstring(`line 3: unknown key "num_cluster"`)))
+				Expect(config).To(BeNil())
 			})
 
 			It("should return error for non-existent file", func() {
@@ -412,6 +558,46 @@ invalid: yaml: content: [`
 					Expect(merged.SkipMetalLB).To(Equal(override.SkipMetalLB))
 				})
 			})
+
+			Context("Registry mirrors", func() {
+				It("should keep base registry mirrors when override has none", func() {
+					base.RegistryMirrors = map[string]string{"ghcr.io": "https://ghcr-proxy.internal"}
+					override = &ProjectConfig{}
+
+					merged := MergeConfigs(base, override)
+					Expect(merged.RegistryMirrors).To(Equal(base.RegistryMirrors))
+				})
+
+				It("should replace registry mirrors when override has some", func() {
+					base.RegistryMirrors = map[string]string{"ghcr.io": "https://ghcr-proxy.internal"}
+					override = &ProjectConfig{
+						RegistryMirrors: map[string]string{"harbor.internal": "https://harbor.internal"},
+					}
+
+					merged := MergeConfigs(base, override)
+					Expect(merged.RegistryMirrors).To(Equal(override.RegistryMirrors))
+				})
+			})
+
+			Context("Minikube addons", func() {
+				It("should keep skipped addons from the base config", func() {
+					base.SkipCSI = true
+					base.SkipMetricsServer = true
+					override = &ProjectConfig{}
+
+					merged := MergeConfigs(base, override)
+					Expect(merged.SkipCSI).To(BeTrue())
+					Expect(merged.SkipMetricsServer).To(BeTrue())
+				})
+
+				It("should skip addons when the override skips them", func() {
+					override = &ProjectConfig{SkipCSI: true}
+
+					merged := MergeConfigs(base, override)
+					Expect(merged.SkipCSI).To(BeTrue())
+					Expect(merged.SkipMetricsServer).To(BeFalse())
+				})
+			})
 		})
 
 		Context("ConfigManager.MergeConfig", func() {
@@ -520,6 +706,32 @@ invalid: yaml: content: [`
 				Expect(mergedConfig.InstallCloudProvider).To(Equal(cmdConfig.InstallCloudProvider))
 				Expect(mergedConfig.SkipMetalLB).To(Equal(cmdConfig.SkipMetalLB))
 			})
+
+			It("should drop the saved BGP peer when switching MetalLB to l2", func() {
+				project := "metallb-mode-project"
+				Expect(cm.SaveConfig(project, &ProjectConfig{
+					Project:            project,
+					Environment:        "kind",
+					NumClusters:        1,
+					MetalLBMode:        MetalLBModeBGP,
+					MetalLBPeerAddress: "172.18.0.1",
+					MetalLBPeerASN:     65001,
+					MetalLBASN:         64512,
+				})).To(Succeed())
+
+				mergedConfig, err := cm.MergeConfig(project, &ProjectConfig{Project: project, MetalLBMode: MetalLBModeL2})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mergedConfig.MetalLBMode).To(Equal(MetalLBModeL2))
+				Expect(mergedConfig.MetalLBPeerAddress).To(BeEmpty())
+				Expect(mergedConfig.MetalLBPeerASN).To(BeZero())
+				Expect(mergedConfig.MetalLBASN).To(BeZero())
+				Expect(validateMetalLBBGP(mergedConfig)).To(Succeed())
+
+				// without a mode the saved peer is kept
+				mergedConfig, err = cm.MergeConfig(project, &ProjectConfig{Project: project})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mergedConfig.MetalLBPeerAddress).To(Equal("172.18.0.1"))
+			})
 		})
 	})
 })
@@ -0,0 +1,357 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// clusterPrefixPattern matches the names kind accepts for a cluster, leaving room for the -N suffix
+var clusterPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// sizePattern matches the memory and disk sizes accepted by minikube, e.g. 8192, 8g, 8GB or 8GiB
+var sizePattern = regexp.MustCompile(`(?i)^[0-9]+(\.[0-9]+)?\s*([kmgt]i?b?|b)?$`)
+
+// storageClassNamePattern matches a kubernetes object name (a DNS subdomain) a StorageClass can be given
+var storageClassNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// MinHANodes is the number of control planes minikube starts an HA cluster with
+const MinHANodes = 3
+
+// envReferencePattern matches a single $VAR or ${VAR} environment variable reference
+var envReferencePattern = regexp.MustCompile(`^\$(\{[A-Za-z_][A-Za-z0-9_]*\}|[A-Za-z_][A-Za-z0-9_]*)$`)
+
+// releaseVersionPattern matches a pinned release version, e.g. 0.6.0 or v0.6.0
+var releaseVersionPattern = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// Validate checks the values of the config and returns every problem found, joined into one error.
+// Unset (zero) values are skipped so partial configs such as a user config file can be validated too
+func (pc *ProjectConfig) Validate() error {
+	var errs []error
+
+	if pc.NumClusters != 0 && (pc.NumClusters < 1 || pc.NumClusters > MaxClusterNum) {
+		errs = append(errs, fmt.Errorf("number of clusters must be between 1 and %d, got %d", MaxClusterNum, pc.NumClusters))
+	}
+	if pc.NodeCount < 0 {
+		errs = append(errs, fmt.Errorf("node count can't be negative, got %d", pc.NodeCount))
+	}
+	if pc.NodeGPUs < 0 {
+		errs = append(errs, fmt.Errorf("node GPUs can't be negative, got %d", pc.NodeGPUs))
+	}
+	fakeGPUs := pc.NodeGPUs > 0
+	for index, worker := range pc.WorkerNodes {
+		if worker.GPUs < 0 {
+			errs = append(errs, fmt.Errorf("worker node %d GPUs can't be negative, got %d", index, worker.GPUs))
+		}
+		fakeGPUs = fakeGPUs || worker.GPUs > 0
+	}
+	if fakeGPUs && pc.Environment != "" && pc.Environment != "kind" {
+		errs = append(errs, fmt.Errorf("fake GPUs are only supported for Kind"))
+	}
+	if pc.MetalLBPoolSize < 0 {
+		errs = append(errs, fmt.Errorf("MetalLB pool size can't be negative, got %d", pc.MetalLBPoolSize))
+	}
+	errs = append(errs, validateMetalLBBGP(pc), validateMetalLBIPRanges(pc))
+
+	errs = append(errs,
+		validateOption("environment", pc.Environment, ValidEnvironments),
+		validateOption("container runtime", pc.ContainerRuntime, ValidContainerRuntimes),
+		validateOption("CNI", pc.CNI, ValidCNIs),
+		validateOption("IP family", pc.IPFamily, ValidIPFamilies),
+		validateOption("container engine", pc.ContainerEngine, ValidContainerEngines),
+	)
+	for _, engine := range pc.ContainerEnginePreference {
+		errs = append(errs, validateOption("container engine", engine, ValidContainerEngines))
+	}
+	for _, set := range pc.HelmSet {
+		release, _, _ := strings.Cut(set, ".")
+		if !strings.Contains(set, "=") || !slices.Contains(ValidHelmReleases, release) {
+			errs = append(errs, fmt.Errorf("invalid Helm value override: %s. Use release.key=value with a release of: %s", set, strings.Join(ValidHelmReleases, ", ")))
+		}
+	}
+	if pc.Environment != "" && pc.Environment != "kind" && pc.IPFamily != "" && pc.IPFamily != IPFamilyIPv4 {
+		errs = append(errs, fmt.Errorf("IP family %s is only supported for Kind", pc.IPFamily))
+	}
+	for _, mount := range pc.Mounts {
+		if _, _, err := ParseMount(mount); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if pc.CiliumClusterMesh && pc.Environment != "" && pc.Environment != "kind" {
+		errs = append(errs, fmt.Errorf("cilium cluster mesh is only supported for Kind"))
+	}
+	if pc.CiliumClusterMesh && pc.CNI != "" && pc.CNI != "cilium" {
+		errs = append(errs, fmt.Errorf("cilium cluster mesh requires the cilium CNI, got %s", pc.CNI))
+	}
+	if pc.NoRegistryMirrors && len(pc.RegistryMirrorHosts) > 0 {
+		errs = append(errs, fmt.Errorf("registry mirror hosts can't be combined with no registry mirrors"))
+	}
+	if pc.HA && pc.Environment == "kind" {
+		errs = append(errs, fmt.Errorf("HA clusters are only supported for Minikube"))
+	}
+	if pc.HA && pc.NodeCount > 0 && pc.NodeCount < MinHANodes {
+		errs = append(errs, fmt.Errorf("HA clusters need at least %d nodes for their control planes, got %d", MinHANodes, pc.NodeCount))
+	}
+	if pc.RegistryTLS && pc.Environment != "" && pc.Environment != "kind" {
+		errs = append(errs, fmt.Errorf("registry TLS is only supported for Kind"))
+	}
+	if pc.CloudProviderVersion != "" && pc.CloudProviderVersion != "latest" && !releaseVersionPattern.MatchString(pc.CloudProviderVersion) {
+		errs = append(errs, fmt.Errorf("invalid cloud-provider-kind version: %s. Use a release like 0.6.0 or latest", pc.CloudProviderVersion))
+	}
+	for host, credentials := range pc.RegistryAuth {
+		if host == "" || credentials == "" {
+			errs = append(errs, fmt.Errorf("invalid registry auth for %q. Use host: username:password", host))
+		} else if err := checkRegistryPassword(host, credentials); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if pc.Environment == "minikube" && len(pc.Mounts) > 1 {
+		errs = append(errs, fmt.Errorf("minikube supports a single mount, got %d", len(pc.Mounts)))
+	}
+
+	if pc.ClusterPrefix != "" && !clusterPrefixPattern.MatchString(pc.ClusterPrefix) {
+		errs = append(errs, fmt.Errorf("invalid cluster prefix: %s. Use lowercase letters, digits and '-'", pc.ClusterPrefix))
+	}
+
+	if pc.StorageClass != "" && (len(pc.StorageClass) > 253 || !storageClassNamePattern.MatchString(pc.StorageClass)) {
+		errs = append(errs, fmt.Errorf("invalid storage class name: %s. Use lowercase letters, digits, '-' and '.'", pc.StorageClass))
+	}
+	if pc.StorageClass != "" && pc.Environment != "" && pc.Environment != "kind" {
+		errs = append(errs, fmt.Errorf("a storage class name is only supported for Kind"))
+	}
+
+	if pc.GatewayIP != "" && net.ParseIP(pc.GatewayIP) == nil {
+		errs = append(errs, fmt.Errorf("invalid gateway IP: %s", pc.GatewayIP))
+	}
+	errs = append(errs,
+		validateCIDRs("subnet CIDR", pc.SubnetCIDR),
+		validateCIDRs("pod CIDR", pc.PodSubnet),
+		validateCIDRs("service CIDR", pc.ServiceSubnet),
+	)
+
+	if pc.CPU != "" && !isUnlimited(pc.CPU) {
+		if n, err := strconv.Atoi(pc.CPU); err != nil || n < 1 {
+			errs = append(errs, fmt.Errorf("invalid CPU count: %s. Use a whole number, max or no-limit", pc.CPU))
+		}
+	}
+	if pc.Memory != "" && !isUnlimited(pc.Memory) && !sizePattern.MatchString(pc.Memory) {
+		errs = append(errs, fmt.Errorf("invalid memory size: %s. Use a size such as 8192, 8g or 8GiB", pc.Memory))
+	}
+	if pc.DiskSize != "" && !sizePattern.MatchString(pc.DiskSize) {
+		errs = append(errs, fmt.Errorf("invalid disk size: %s. Use a size such as 20000mb, 20g or 20GiB", pc.DiskSize))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseMount parses a mount in the host:container form, returning the host path made absolute
+func ParseMount(mount string) (hostPath, containerPath string, err error) {
+	hostPath, containerPath, found := strings.Cut(mount, ":")
+	if !found || hostPath == "" || !path.IsAbs(containerPath) {
+		return "", "", fmt.Errorf("invalid mount: %s. Use host:container with an absolute container path", mount)
+	}
+
+	hostPath, err = filepath.Abs(hostPath)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid mount host path %s: %w", hostPath, err)
+	}
+	return hostPath, containerPath, nil
+}
+
+// ParseRegistryAuth parses host=username:password values into the registry auth of the project config, the
+// credentials are never part of the error
+func ParseRegistryAuth(values []string) (map[string]string, error) {
+	auth := make(map[string]string, len(values))
+	for _, value := range values {
+		host, credentials, found := strings.Cut(value, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid registry auth. Use host=username:password")
+		}
+		if host == "" || credentials == "" {
+			return nil, fmt.Errorf("invalid registry auth for %q. Use host=username:password", host)
+		}
+		if err := checkRegistryPassword(host, credentials); err != nil {
+			return nil, err
+		}
+		auth[host] = credentials
+	}
+	return auth, nil
+}
+
+// checkRegistryPassword ensures the password of username:password registry credentials is a $VAR reference, the
+// credentials are saved with the project so a literal secret would end up in its config file
+func checkRegistryPassword(host, credentials string) error {
+	_, password, _ := strings.Cut(credentials, ":")
+	if !envReferencePattern.MatchString(password) {
+		return fmt.Errorf("registry auth for %q must read the password from an environment variable, e.g. %s=$USER:$TOKEN, so it isn't saved with the project", host, host)
+	}
+	return nil
+}
+
+// ParseRegistryCredentials expands the $VAR references of username:password registry credentials and splits them
+func ParseRegistryCredentials(credentials string) (username, password string, err error) {
+	username, password, found := strings.Cut(os.ExpandEnv(credentials), ":")
+	if !found || username == "" || password == "" {
+		return "", "", fmt.Errorf("invalid registry credentials, expected username:password once the environment variables are expanded")
+	}
+	return username, password, nil
+}
+
+// CheckMountHostPaths ensures the host path of every mount exists, so a typo fails before anything is provisioned
+func CheckMountHostPaths(mounts []string) error {
+	for _, mount := range mounts {
+		hostPath, _, err := ParseMount(mount)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(hostPath); err != nil {
+			return fmt.Errorf("host path of mount %s does not exist: %w", mount, err)
+		}
+	}
+	return nil
+}
+
+// MetalLBIPRanges splits the comma separated MetalLB IP ranges of a project, one per cluster
+func MetalLBIPRanges(value string) []string {
+	if value == "" {
+		return nil
+	}
+	ranges := strings.Split(value, ",")
+	for i := range ranges {
+		ranges[i] = strings.TrimSpace(ranges[i])
+	}
+	return ranges
+}
+
+// ParseMetalLBIPRange parses an x.x.x.start-x.x.x.end IPv4 range into its first three octets and the last octets
+// it starts and ends at. Pools are tracked by last octet, so both ends must share the first three. Whether the range
+// is in the cluster network is only known once the network exists, see services.MetalLBManager.SetSubnet
+func ParseMetalLBIPRange(ipRange string) (prefix string, start, end int, err error) {
+	first, last, found := strings.Cut(ipRange, "-")
+	startIP, endIP := net.ParseIP(first).To4(), net.ParseIP(last).To4()
+	if !found || startIP == nil || endIP == nil {
+		return "", 0, 0, fmt.Errorf("invalid MetalLB IP range: %s. Use x.x.x.start-x.x.x.end IPv4 addresses", ipRange)
+	}
+	if !startIP.Mask(net.CIDRMask(24, 32)).Equal(endIP.Mask(net.CIDRMask(24, 32))) {
+		return "", 0, 0, fmt.Errorf("invalid MetalLB IP range: %s. Both ends must be in the same /24", ipRange)
+	}
+	if startIP[3] > endIP[3] {
+		return "", 0, 0, fmt.Errorf("invalid MetalLB IP range: %s. The range ends before it starts", ipRange)
+	}
+	return fmt.Sprintf("%d.%d.%d", startIP[0], startIP[1], startIP[2]), int(startIP[3]), int(endIP[3]), nil
+}
+
+// validateMetalLBIPRanges checks the explicit MetalLB IP ranges parse, don't overlap and cover every cluster
+func validateMetalLBIPRanges(pc *ProjectConfig) error {
+	ranges := MetalLBIPRanges(pc.MetalLBIPRange)
+	if len(ranges) == 0 {
+		return nil
+	}
+	if pc.NumClusters > 0 && len(ranges) != pc.NumClusters {
+		return fmt.Errorf("MetalLB IP range needs one range per cluster, got %d for %d clusters", len(ranges), pc.NumClusters)
+	}
+
+	type parsedRange struct {
+		prefix     string
+		start, end int
+	}
+	parsed := make([]parsedRange, 0, len(ranges))
+	for i, ipRange := range ranges {
+		prefix, start, end, err := ParseMetalLBIPRange(ipRange)
+		if err != nil {
+			return err
+		}
+		for j, other := range parsed {
+			if other.prefix == prefix && other.start <= end && start <= other.end {
+				return fmt.Errorf("MetalLB IP ranges %s and %s overlap", ranges[j], ranges[i])
+			}
+		}
+		parsed = append(parsed, parsedRange{prefix, start, end})
+	}
+	return nil
+}
+
+// maxASN is the largest 4 byte autonomous system number
+const maxASN = 4294967295
+
+// validateMetalLBBGP checks the MetalLB mode and that the BGP peer settings are only given, and complete, in bgp mode
+func validateMetalLBBGP(pc *ProjectConfig) error {
+	if err := validateOption("MetalLB mode", pc.MetalLBMode, ValidMetalLBModes); err != nil {
+		return err
+	}
+	if pc.MetalLBASN < 0 || pc.MetalLBASN > maxASN {
+		return fmt.Errorf("MetalLB ASN must be between 1 and %d, got %d", maxASN, pc.MetalLBASN)
+	}
+	if pc.MetalLBPeerASN < 0 || pc.MetalLBPeerASN > maxASN {
+		return fmt.Errorf("MetalLB peer ASN must be between 1 and %d, got %d", maxASN, pc.MetalLBPeerASN)
+	}
+
+	if pc.MetalLBMode != MetalLBModeBGP {
+		if pc.MetalLBPeerAddress != "" || pc.MetalLBPeerASN > 0 || pc.MetalLBASN > 0 {
+			return fmt.Errorf("MetalLB BGP peer settings require --metallb-mode %s", MetalLBModeBGP)
+		}
+		return nil
+	}
+	if pc.MetalLBPeerAddress == "" {
+		return fmt.Errorf("MetalLB %s mode requires a peer address", MetalLBModeBGP)
+	}
+	if net.ParseIP(pc.MetalLBPeerAddress) == nil {
+		return fmt.Errorf("invalid MetalLB peer address: %s", pc.MetalLBPeerAddress)
+	}
+	return nil
+}
+
+// validateOption checks an optional value is one of the valid options
+func validateOption(name, value string, options []string) error {
+	if value == "" || slices.Contains(options, value) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s: %s. Valid options are: %s", name, value, strings.Join(options, ", "))
+}
+
+// validateCIDRs checks an optional, comma separated list of CIDRs parses
+func validateCIDRs(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, cidr := range strings.Split(value, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid %s: %s", name, cidr)
+		}
+	}
+	return nil
+}
+
+// isUnlimited reports whether a minikube resource is given as max or no-limit
+func isUnlimited(value string) bool {
+	return value == "max" || value == "no-limit"
+}
@@ -0,0 +1,334 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	validConfig := func() *ProjectConfig {
+		return &ProjectConfig{
+			Project:          "myproject",
+			Environment:      "kind",
+			NumClusters:      2,
+			NodeCount:        2,
+			GatewayIP:        KindNetworkGatewayIP,
+			SubnetCIDR:       DefaultNetworkSubnetCIDR,
+			PodSubnet:        KindPodSubnet + "," + KindPodSubnetIPv6,
+			ServiceSubnet:    KindServiceSubnet,
+			IPFamily:         IPFamilyDual,
+			CPU:              MinikubeCPU,
+			Memory:           MinikubeMemory,
+			DiskSize:         MinikubeDiskSize,
+			CNI:              "cilium",
+			ContainerRuntime: "containerd",
+			ContainerEngine:  "docker",
+		}
+	}
+
+	It("should accept a valid config", func() {
+		Expect(validConfig().Validate()).To(Succeed())
+	})
+
+	It("should accept an empty config", func() {
+		Expect((&ProjectConfig{}).Validate()).To(Succeed())
+	})
+
+	It("should accept the minikube resource formats", func() {
+		for _, size := range []string{"8192", "8g", "8GB", "8GiB", "1.5g", "20000mb"} {
+			pc := validConfig()
+			pc.Memory = size
+			pc.DiskSize = size
+			Expect(pc.Validate()).To(Succeed(), size)
+		}
+
+		pc := validConfig()
+		pc.CPU = "max"
+		pc.Memory = "no-limit"
+		Expect(pc.Validate()).To(Succeed())
+	})
+
+	It("should report every problem at once", func() {
+		pc := validConfig()
+		pc.NumClusters = 99
+		pc.CNI = "ciliumm"
+		pc.ContainerRuntime = "rkt"
+		pc.SubnetCIDR = "10.89.0.0/33"
+		pc.CPU = "four"
+		pc.Memory = "lots"
+		pc.DiskSize = "10 gigs"
+
+		err := pc.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("number of clusters must be between 1 and 3, got 99"))
+		Expect(err.Error()).To(ContainSubstring("invalid CNI: ciliumm"))
+		Expect(err.Error()).To(ContainSubstring("invalid container runtime: rkt"))
+		Expect(err.Error()).To(ContainSubstring("invalid subnet CIDR: 10.89.0.0/33"))
+		Expect(err.Error()).To(ContainSubstring("invalid CPU count: four"))
+		Expect(err.Error()).To(ContainSubstring("invalid memory size: lots"))
+		Expect(err.Error()).To(ContainSubstring("invalid disk size: 10 gigs"))
+	})
+
+	It("should accept none to bring your own CNI", func() {
+		pc := validConfig()
+		pc.CNI = "none"
+		Expect(pc.Validate()).To(Succeed())
+	})
+
+	It("should only accept cluster prefixes kind can use", func() {
+		pc := validConfig()
+		pc.ClusterPrefix = "team-a"
+		Expect(pc.Validate()).To(Succeed())
+
+		for _, prefix := range []string{"Team", "team_a", "-team", "team-"} {
+			pc.ClusterPrefix = prefix
+			Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid cluster prefix")), prefix)
+		}
+	})
+
+	It("should only accept storage class names kubernetes can use", func() {
+		pc := validConfig()
+		for _, name := range []string{"gp2", "premium-rwo", "ebs.gp3"} {
+			pc.StorageClass = name
+			Expect(pc.Validate()).To(Succeed(), name)
+		}
+
+		for _, name := range []string{"GP2", "gp_2", "-gp2", "gp2."} {
+			pc.StorageClass = name
+			Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid storage class name")), name)
+		}
+
+		pc.StorageClass = "gp2"
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("a storage class name is only supported for Kind")))
+	})
+
+	It("should only accept known engines in the container engine preference", func() {
+		pc := validConfig()
+		pc.ContainerEnginePreference = []string{"podman", "docker"}
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.ContainerEnginePreference = []string{"podman", "containerd"}
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid container engine: containerd")))
+	})
+
+	It("should only accept Helm value overrides for the installed releases", func() {
+		pc := validConfig()
+		pc.HelmSet = []string{"metallb.speaker.resources.requests.memory=200Mi", "cilium.hubble.enabled=true"}
+		Expect(pc.Validate()).To(Succeed())
+
+		for _, set := range []string{"speaker.frr.enabled=true", "istio.pilot.enabled=true", "metallb.speaker.frr.enabled"} {
+			pc.HelmSet = []string{set}
+			Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid Helm value override: "+set)), set)
+		}
+	})
+
+	It("should only accept host:container mounts", func() {
+		pc := validConfig()
+		pc.Mounts = []string{"./src:/src", "/etc/ssl/certs:/etc/ssl/certs"}
+		Expect(pc.Validate()).To(Succeed())
+
+		for _, mount := range []string{"/src", ":/src", "/src:src"} {
+			pc.Mounts = []string{mount}
+			Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid mount: "+mount)), mount)
+		}
+	})
+
+	It("should only allow a single mount for minikube", func() {
+		pc := validConfig()
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		pc.Mounts = []string{"/src:/src", "/certs:/certs"}
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("minikube supports a single mount, got 2")))
+	})
+
+	It("should check the mount host paths exist", func() {
+		hostPath := GinkgoT().TempDir()
+		Expect(CheckMountHostPaths([]string{hostPath + ":/src"})).To(Succeed())
+		Expect(CheckMountHostPaths([]string{hostPath + "/missing:/src"})).To(MatchError(ContainSubstring("does not exist")))
+	})
+
+	It("should only allow the cilium cluster mesh for kind with cilium", func() {
+		pc := validConfig()
+		pc.CiliumClusterMesh = true
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.CNI = "calico"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("cilium cluster mesh requires the cilium CNI, got calico")))
+
+		pc = validConfig()
+		pc.CiliumClusterMesh = true
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("cilium cluster mesh is only supported for Kind")))
+	})
+
+	It("should not combine registry mirror hosts with no registry mirrors", func() {
+		pc := validConfig()
+		pc.RegistryMirrorHosts = []string{"docker.io"}
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.NoRegistryMirrors = true
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("registry mirror hosts can't be combined with no registry mirrors")))
+	})
+
+	It("should accept a pinned cloud-provider-kind release", func() {
+		pc := validConfig()
+		for _, version := range []string{"0.6.0", "v0.6.0", "latest"} {
+			pc.CloudProviderVersion = version
+			Expect(pc.Validate()).To(Succeed())
+		}
+
+		pc.CloudProviderVersion = "0.6"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid cloud-provider-kind version")))
+	})
+
+	It("should only allow fake GPUs for kind", func() {
+		pc := validConfig()
+		pc.NodeGPUs = 2
+		pc.WorkerNodes = map[int]WorkerNodeConfig{1: {GPUs: 4}}
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.WorkerNodes = map[int]WorkerNodeConfig{1: {GPUs: -1}}
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("worker node 1 GPUs can't be negative")))
+
+		pc.WorkerNodes = nil
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("fake GPUs are only supported for Kind")))
+	})
+
+	It("should require a valid peer in MetalLB BGP mode", func() {
+		pc := validConfig()
+		pc.MetalLBMode = MetalLBModeBGP
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("MetalLB bgp mode requires a peer address")))
+
+		pc.MetalLBPeerAddress = "router"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid MetalLB peer address: router")))
+
+		pc.MetalLBPeerAddress = "172.18.0.1"
+		pc.MetalLBPeerASN = 65001
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.MetalLBASN = 4294967296
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("MetalLB ASN must be between 1 and 4294967295")))
+
+		pc.MetalLBASN = 0
+		pc.MetalLBMode = "ecmp"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid MetalLB mode: ecmp")))
+
+		pc.MetalLBMode = MetalLBModeL2
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("MetalLB BGP peer settings require --metallb-mode bgp")))
+	})
+
+	It("should accept one MetalLB IP range per cluster", func() {
+		pc := validConfig()
+		pc.NumClusters = 2
+		pc.MetalLBIPRange = "192.168.99.240-192.168.99.245, 192.168.99.246-192.168.99.250"
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.MetalLBIPRange = "192.168.99.240-192.168.99.250"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("needs one range per cluster, got 1 for 2 clusters")))
+
+		pc.MetalLBIPRange = "192.168.99.240-192.168.99.250,192.168.99.250-192.168.99.254"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("overlap")))
+	})
+
+	It("should only parse MetalLB IP ranges within a /24", func() {
+		prefix, start, end, err := ParseMetalLBIPRange("192.168.99.240-192.168.99.250")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefix).To(Equal("192.168.99"))
+		Expect(start).To(Equal(240))
+		Expect(end).To(Equal(250))
+
+		for _, invalid := range []string{"192.168.99.240", "192.168.99.240-192.168.100.10", "192.168.99.250-192.168.99.240", "fd00::1-fd00::9"} {
+			_, _, _, err := ParseMetalLBIPRange(invalid)
+			Expect(err).To(HaveOccurred(), invalid)
+		}
+	})
+
+	It("should only allow registry TLS for kind", func() {
+		pc := validConfig()
+		pc.RegistryTLS = true
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("registry TLS is only supported for Kind")))
+	})
+
+	It("should only allow HA for minikube with enough nodes for the control planes", func() {
+		pc := validConfig()
+		pc.HA = true
+		pc.NodeCount = 3
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("HA clusters are only supported for Minikube")))
+
+		pc.Environment = "minikube"
+		pc.IPFamily = IPFamilyIPv4
+		Expect(pc.Validate()).To(Succeed())
+
+		pc.NodeCount = 2
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("HA clusters need at least 3 nodes for their control planes, got 2")))
+	})
+
+	It("should parse the registry auth without echoing the credentials", func() {
+		auth, err := ParseRegistryAuth([]string{"docker.io=user:${DOCKER_TOKEN}", "ghcr.io=$GHCR_USER:$GHCR_TOKEN"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth).To(Equal(map[string]string{"docker.io": "user:${DOCKER_TOKEN}", "ghcr.io": "$GHCR_USER:$GHCR_TOKEN"}))
+
+		_, err = ParseRegistryAuth([]string{"user:s3cret"})
+		Expect(err).To(MatchError(ContainSubstring("invalid registry auth")))
+		Expect(err.Error()).NotTo(ContainSubstring("s3cret"))
+
+		_, err = ParseRegistryAuth([]string{"docker.io="})
+		Expect(err).To(MatchError(ContainSubstring(`invalid registry auth for "docker.io"`)))
+	})
+
+	It("should reject literal registry passwords", func() {
+		for _, credentials := range []string{"user:s3cret", "$DOCKER_USER:s3cret", "user:pre$TOKEN", "user"} {
+			_, err := ParseRegistryAuth([]string{"docker.io=" + credentials})
+			Expect(err).To(MatchError(ContainSubstring(`registry auth for "docker.io" must read the password from an environment variable`)))
+			Expect(err.Error()).NotTo(ContainSubstring("s3cret"))
+		}
+
+		pc := &ProjectConfig{Project: "myproject", RegistryAuth: map[string]string{"docker.io": "user:s3cret"}}
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("must read the password from an environment variable")))
+	})
+
+	It("should expand the environment in registry credentials", func() {
+		GinkgoT().Setenv("LOK8S_TEST_TOKEN", "s3cret")
+		username, password, err := ParseRegistryCredentials("user:$LOK8S_TEST_TOKEN")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(username).To(Equal("user"))
+		Expect(password).To(Equal("s3cret"))
+
+		_, _, err = ParseRegistryCredentials("user:$LOK8S_TEST_UNSET")
+		Expect(err).To(MatchError(ContainSubstring("expected username:password")))
+	})
+
+	It("should check every CIDR in a dual-stack list", func() {
+		pc := validConfig()
+		pc.PodSubnet = KindPodSubnet + ",fd00:10:100::/200"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("invalid pod CIDR: fd00:10:100::/200")))
+	})
+
+	It("should only allow ipv6 and dual-stack for kind", func() {
+		pc := validConfig()
+		pc.Environment = "minikube"
+		Expect(pc.Validate()).To(MatchError(ContainSubstring("IP family dual is only supported for Kind")))
+	})
+
+	It("should be applied when loading a config file", func() {
+		configFile := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(configFile, []byte("project: myproject\nnum_clusters: 99\ncni: ciliumm\n"), 0644)).To(Succeed())
+
+		projectConfig, err := LoadConfigFromFile(configFile)
+		Expect(err).To(MatchError(ContainSubstring("number of clusters must be between 1 and 3")))
+		Expect(err).To(MatchError(ContainSubstring("invalid CNI: ciliumm")))
+		Expect(projectConfig).To(BeNil())
+	})
+})
@@ -23,11 +23,15 @@
 package logger
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 )
 
+// ansiEscape matches the color and cursor escape sequences
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]")
+
 // ColoredFormatter wraps logrus.TextFormatter and adds color to ✓ and ✗ characters
 type ColoredFormatter struct {
 	*logrus.TextFormatter
@@ -42,9 +46,10 @@ func (f *ColoredFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 		return nil, err
 	}
 
-	// if colors are not enabled, return as-is
+	// the base formatter is forced to color to keep its layout, strip the colors when the
+	// output isn't a terminal so captured logs don't carry escape sequences
 	if !f.colorEnabled {
-		return data, nil
+		return ansiEscape.ReplaceAll(data, nil), nil
 	}
 
 	// color ✓ in green and ✗ in red, with a space before them
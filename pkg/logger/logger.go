@@ -23,30 +23,88 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/sirupsen/logrus"
 )
 
+// log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
 var log = logrus.New()
 
+var (
+	// jsonFormat is set when the logs are written as JSON
+	jsonFormat bool
+	// quiet is set when only warnings and errors are logged
+	quiet bool
+)
+
 func init() {
 	// Set default configuration
 	log.SetOutput(os.Stdout)
+	log.SetFormatter(newTextFormatter())
+	log.SetLevel(logrus.InfoLevel)
+}
 
-	// Use custom formatter that colors ✓ and ✗ characters
+// newTextFormatter returns the human readable formatter that colors ✓ and ✗ characters
+func newTextFormatter() logrus.Formatter {
 	baseFormatter := &logrus.TextFormatter{
 		FullTimestamp: true,
 		ForceColors:   true,
 	}
 
-	formatter := &ColoredFormatter{
+	return &ColoredFormatter{
 		TextFormatter: baseFormatter,
 		colorEnabled:  ColorEnabled(),
 	}
+}
 
-	log.SetFormatter(formatter)
-	log.SetLevel(logrus.InfoLevel)
+// SetFormat switches between the human readable text logs and JSON logs for log aggregation.
+// The spinner is disabled for JSON logs
+func SetFormat(format string) error {
+	switch format {
+	case FormatText:
+		jsonFormat = false
+		log.SetFormatter(newTextFormatter())
+	case FormatJSON:
+		jsonFormat = true
+		log.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid log format: %s (valid formats: %s, %s)", format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// SetQuiet only logs warnings and errors when enabled, disabling the spinner and the output printed outside
+// the logger
+func SetQuiet(enabled bool) {
+	quiet = enabled
+	if quiet {
+		log.SetLevel(logrus.WarnLevel)
+	}
+}
+
+// Interactive reports whether the output is meant for a person, with the spinner and emoji decorations
+func Interactive() bool {
+	return !jsonFormat && !quiet
+}
+
+// Output returns the writer for output printed outside the logger such as tables. It is stdout for text
+// logs, stderr for JSON logs so the log stream stays parseable and discards everything when quiet
+func Output() io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	if jsonFormat {
+		return os.Stderr
+	}
+	return os.Stdout
 }
 
 // updateFormatterColors updates the formatter's colorEnabled state
@@ -43,7 +43,7 @@ type Status struct {
 
 // StatusForLogger returns a new status object for the logger.
 // If the logger's output is a terminal and supports spinners, that spinner
-// will be used for the status.
+// will be used for the status unless the logs are JSON or quiet.
 // Similar to kind's StatusForLogger implementation.
 func StatusForLogger(l *logrus.Logger) *Status {
 	s := &Status{
@@ -52,8 +52,15 @@ func StatusForLogger(l *logrus.Logger) *Status {
 		failureFormat: "✗ %s\n",
 	}
 
-	// Check if we're writing to a smart terminal (supports colors/spinners)
-	if writer := l.Out; writer != nil {
+	// JSON logs carry one message per entry
+	if jsonFormat {
+		s.successFormat = "✓ %s"
+		s.failureFormat = "✗ %s"
+	}
+
+	// Check if we're writing to a smart terminal (supports colors/spinners), the spinner
+	// is only used for interactive output
+	if writer := l.Out; writer != nil && Interactive() {
 		// Check if the writer is already a Spinner (like kind does)
 		if spinner, ok := writer.(*Spinner); ok {
 			s.spinner = spinner
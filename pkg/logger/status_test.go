@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Status", func() {
+	var (
+		out    *bytes.Buffer
+		logger *logrus.Logger
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		logger = logrus.New()
+		logger.SetOutput(out)
+		logger.SetFormatter(&ColoredFormatter{
+			TextFormatter: &logrus.TextFormatter{DisableTimestamp: true, ForceColors: true},
+			colorEnabled:  IsSmartTerminal(out),
+		})
+	})
+
+	It("should print plain start and done lines when not attached to a terminal", func() {
+		status := StatusForLogger(logger)
+		Expect(status.spinner).To(BeNil())
+
+		status.Start("creating cluster")
+		status.End(true)
+		status.Start("installing MetalLB")
+		status.End(false)
+
+		Expect(out.String()).NotTo(ContainSubstring("\x1b"))
+		var lines []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		Expect(lines).To(Equal([]string{
+			"INFO  • creating cluster  ...",
+			"INFO ✓ creating cluster",
+			"INFO  • installing MetalLB  ...",
+			"INFO ✗ installing MetalLB",
+		}))
+	})
+})
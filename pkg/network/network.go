@@ -35,4 +35,31 @@ type Network struct {
 
 	// QEMU Connection URI
 	ConnectionURI string
+
+	// How far to advance the subnet between attempts when it is in use, defaults to config.DefaultSubnetSearchStep
+	SubnetSearchStep int
+
+	// Maximum number of subnets to try, defaults to config.DefaultSubnetSearchLimit
+	SubnetSearchLimit int
+
+	// VIP reserved in the subnet for the loadbalancer of multi-control-plane clusters, set by EnsureNetwork
+	HAVIP string
+}
+
+// NetworkInfo describes an existing network as reported by the host
+type NetworkInfo struct {
+	// The name of the network
+	Name string
+
+	// The name of the bridge backing the network
+	Bridge string
+
+	// Subnet of the network in CIDR format
+	Subnet string
+
+	// Gateway IP of the network
+	Gateway string
+
+	// Whether the network is active
+	Active bool
 }
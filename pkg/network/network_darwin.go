@@ -28,19 +28,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/github"
 )
 
 const (
-	vmnetInstallPath = "/opt/vmnet-helper"
+	vmnetInstallPath = config.VmnetHelperInstallPath
 	vmnetHelperPath  = vmnetInstallPath + "/bin/vmnet-helper"
+	vmnetArchiveName = "vmnet-helper.tar.gz"
 )
 
 // PrerequisiteChecks check if all the required pre-reqs are present
@@ -139,6 +140,16 @@ func (n *Network) DeleteNetwork(force bool) error {
 	return nil
 }
 
+// ListNetworks returns the shared vmnet network. macOS owns its subnet, so only whether vmnet-helper is
+// installed to attach VMs to it is reported
+func ListNetworks(connectionURI string) ([]NetworkInfo, error) {
+	present, err := isVmnetHelperPresent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check vmnet-helper: %w", err)
+	}
+	return []NetworkInfo{{Name: config.MinikubeVmnetNetworkName, Active: present}}, nil
+}
+
 // ensureInstalled ensures vmnet-helper is installed
 func ensureInstalled(ctx context.Context) error {
 	logger.Debugf("ensuring vmnet-helper is installed")
@@ -169,33 +180,26 @@ func installVmnetHelper(ctx context.Context) error {
 	logger.Debugf("installing vmnet-helper")
 
 	// download the tar.gz archive
-	archiveURL := "https://github.com/minikube-machine/vmnet-helper/releases/latest/download/vmnet-helper.tar.gz"
+	archiveURL := "https://github.com/minikube-machine/vmnet-helper/releases/latest/download/" + vmnetArchiveName
 	logger.Debugf("downloading vmnet-helper archive from %s", archiveURL)
 
-	resp, err := http.Get(archiveURL)
-	if err != nil {
-		return fmt.Errorf("failed to download vmnet-helper archive: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download vmnet-helper archive: HTTP %d", resp.StatusCode)
-	}
-
 	// create temporary file for the archive
 	tmpFile, err := os.CreateTemp("", "vmnet-helper-*.tar.gz")
 	if err != nil {
 		return fmt.Errorf("failed to create temporary file: %w", err)
 	}
+	tmpFile.Close()
 	defer os.Remove(tmpFile.Name())
 
-	// write the archive content
-	_, err = io.Copy(tmpFile, resp.Body)
-	if err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("failed to write archive: %w", err)
+	githubClient := github.NewGitHubClient()
+	if err := githubClient.DownloadBinary(archiveURL, tmpFile.Name()); err != nil {
+		return fmt.Errorf("failed to download vmnet-helper archive: %w", err)
+	}
+
+	// verify the archive before extracting it as root
+	if err := githubClient.VerifyChecksum(archiveURL+".sha256", vmnetArchiveName, tmpFile.Name()); err != nil {
+		return fmt.Errorf("vmnet-helper checksum verification failed: %w", err)
 	}
-	tmpFile.Close()
 
 	// extract the archive to /opt/vmnet-helper using sudo
 	logger.Debugf("extracting vmnet-helper to %s...", vmnetInstallPath)
@@ -26,6 +26,7 @@ package network
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"net"
 	"strings"
@@ -76,6 +77,10 @@ func (n *Network) EnsureNetwork() error {
 	if err != nil {
 		// network doesn't exist, create it
 		logger.Debugf("network %s does not exist, creating it", n.Name)
+		if err := checkBridgeFree(conn, n.Name, n.Bridge); err != nil {
+			status.End(false)
+			return err
+		}
 		if err := n.createNetwork(); err != nil {
 			status.End(false)
 			return errors.Wrapf(err, "creating network %s", n.Name)
@@ -86,9 +91,24 @@ func (n *Network) EnsureNetwork() error {
 			return errors.Wrapf(err, "setting up network %s", n.Name)
 		}
 		logger.Debugf("successfully created and activated network %s", n.Name)
+		logger.Debugf("network %s reserves %s for the HA control plane VIP", n.Name, n.HAVIP)
 		return nil
 	}
 
+	// an existing network keeps its bridge, a different --bridge only applies once the network is recreated
+	if bridge, err := libvirtNet.GetBridgeName(); err == nil && n.Bridge != "" && bridge != n.Bridge {
+		logger.Warnf("⚠️ network %s already uses bridge %s, ignoring bridge %s", n.Name, bridge, n.Bridge)
+	}
+
+	// an existing network keeps its subnet, the VIP reserved in it may differ from the requested one
+	if netXML, err := libvirtNet.GetXMLDesc(0); err != nil {
+		logger.Debugf("failed getting %s network XML: %v", n.Name, lvErr(err))
+	} else if subnetCIDR, _ := parseNetworkXMLSubnet(netXML); subnetCIDR != "" {
+		if _, ipNet, err := net.ParseCIDR(subnetCIDR); err == nil {
+			n.HAVIP = calculateSubnetParameters(ipNet).HAVIP
+		}
+	}
+
 	// network exists, free the handle (setupNetwork will look it up again)
 	if err := libvirtNet.Free(); err != nil {
 		logger.Debugf("failed freeing network handle: %v", lvErr(err))
@@ -99,6 +119,43 @@ func (n *Network) EnsureNetwork() error {
 		status.End(false)
 		return errors.Wrapf(err, "setting up existing network %s", n.Name)
 	}
+	if n.HAVIP != "" {
+		logger.Debugf("network %s reserves %s for the HA control plane VIP", n.Name, n.HAVIP)
+	}
+
+	return nil
+}
+
+// checkBridgeFree returns an error when the bridge is taken by another libvirt network or a host interface, which
+// libvirt would otherwise only report as a failure to start the network
+func checkBridgeFree(conn *libvirt.Connect, networkName, bridge string) error {
+	if bridge == "" {
+		return nil
+	}
+
+	nets, err := conn.ListAllNetworks(0)
+	if err != nil {
+		return fmt.Errorf("failed to list libvirt networks: %w", lvErr(err))
+	}
+
+	owner := ""
+	for _, libvirtNet := range nets {
+		name, nameErr := libvirtNet.GetName()
+		bridgeName, bridgeErr := libvirtNet.GetBridgeName()
+		if err := libvirtNet.Free(); err != nil {
+			logger.Debugf("failed freeing network handle: %v", lvErr(err))
+		}
+		if nameErr == nil && bridgeErr == nil && bridgeName == bridge && name != networkName {
+			owner = name
+		}
+	}
+	if owner != "" {
+		return fmt.Errorf("bridge %s is already used by libvirt network %s, pick another one with --bridge", bridge, owner)
+	}
+
+	if _, err := net.InterfaceByName(bridge); err == nil {
+		return fmt.Errorf("bridge %s already exists on the host, pick another one with --bridge", bridge)
+	}
 
 	return nil
 }
@@ -135,9 +192,17 @@ func (n *Network) createNetwork() error {
 	}
 
 	// check if subnet is free and find a free subnet if needed (libvirt-specific)
+	step := n.SubnetSearchStep
+	if step <= 0 {
+		step = config.DefaultSubnetSearchStep
+	}
+	limit := n.SubnetSearchLimit
+	if limit <= 0 {
+		limit = config.DefaultSubnetSearchLimit
+	}
 	initialSubnet := n.Subnet
 	var freeSubnetCIDR string
-	freeSubnetCIDR, err = FindFreeLibvirtSubnet(n.Subnet, 1, 50)
+	freeSubnetCIDR, err = FindFreeLibvirtSubnet(n.Subnet, step, limit)
 	if err != nil {
 		return fmt.Errorf("failed to find free subnet starting from %s: %w", n.Subnet, err)
 	}
@@ -147,6 +212,7 @@ func (n *Network) createNetwork() error {
 		logger.Infof("subnet %s is in use, using free subnet %s instead", initialSubnet, freeSubnetCIDR)
 		n.Subnet = freeSubnetCIDR
 	}
+	logger.Infof("🌐 using subnet %s for network %s", n.Subnet, n.Name)
 
 	// parse subnet to get network parameters
 	_, ipNet, err := net.ParseCIDR(n.Subnet)
@@ -156,6 +222,7 @@ func (n *Network) createNetwork() error {
 
 	// calculate network parameters from the subnet CIDR
 	subnet := calculateSubnetParameters(ipNet)
+	n.HAVIP = subnet.HAVIP
 
 	// create the XML for the private network from our networkTmpl
 	tryNet := libvirtNetwork{
@@ -247,6 +314,8 @@ func calculateSubnetParameters(ipNet *net.IPNet) Parameters {
 	clientMax[len(clientMax)-1]--
 
 	// reserve last client IP address for multi-control-plane loadbalancer VIP address in HA cluster
+	haVIP := make(net.IP, len(clientMax))
+	copy(haVIP, clientMax)
 	clientMax[len(clientMax)-1]--
 
 	// convert netmask to dotted decimal format
@@ -261,6 +330,7 @@ func calculateSubnetParameters(ipNet *net.IPNet) Parameters {
 		ClientMin: clientMin.String(),
 		ClientMax: clientMax.String(),
 		Broadcast: broadcast.String(),
+		HAVIP:     haVIP.String(),
 		IsPrivate: isPrivateIP(ip),
 	}
 }
@@ -408,6 +478,80 @@ func setupNetwork(conn *libvirt.Connect, name string) error {
 	return nil
 }
 
+// ListNetworks returns the libvirt networks with their IPv4 subnet, gateway and whether they are active
+func ListNetworks(connectionURI string) ([]NetworkInfo, error) {
+	conn, err := getConnection(connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening libvirt connection: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Close(); err != nil {
+			logger.Errorf("failed closing libvirt connection: %v", lvErr(err))
+		}
+	}()
+
+	nets, err := conn.ListAllNetworks(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list libvirt networks: %w", lvErr(err))
+	}
+
+	var infos []NetworkInfo
+	for _, libvirtNet := range nets {
+		info, err := networkInfo(&libvirtNet)
+		if err := libvirtNet.Free(); err != nil {
+			logger.Debugf("failed freeing network handle: %v", lvErr(err))
+		}
+		if err != nil {
+			logger.Debugf("skipping network: %v", err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// networkInfo reads the name, bridge, state and first IPv4 subnet of a libvirt network
+func networkInfo(libvirtNet *libvirt.Network) (NetworkInfo, error) {
+	name, err := libvirtNet.GetName()
+	if err != nil {
+		return NetworkInfo{}, fmt.Errorf("failed getting network name: %w", lvErr(err))
+	}
+	info := NetworkInfo{Name: name}
+
+	// networks without a bridge (e.g. macvtap) have none to report
+	if bridge, err := libvirtNet.GetBridgeName(); err == nil {
+		info.Bridge = bridge
+	}
+	if info.Active, err = libvirtNet.IsActive(); err != nil {
+		return NetworkInfo{}, fmt.Errorf("failed checking network status for %s: %w", name, lvErr(err))
+	}
+
+	xmlDesc, err := libvirtNet.GetXMLDesc(0)
+	if err != nil {
+		return NetworkInfo{}, fmt.Errorf("failed getting %s network XML: %w", name, lvErr(err))
+	}
+	info.Subnet, info.Gateway = parseNetworkXMLSubnet(xmlDesc)
+	return info, nil
+}
+
+// parseNetworkXMLSubnet returns the first IPv4 subnet of a libvirt network XML and the gateway address the
+// host takes on it, both empty when the network has none
+func parseNetworkXMLSubnet(xmlDesc string) (string, string) {
+	var networkXML libvirtNetworkXML
+	if err := xml.Unmarshal([]byte(xmlDesc), &networkXML); err != nil {
+		logger.Debugf("failed to unmarshal network XML: %v", err)
+		return "", ""
+	}
+	for _, ipElem := range networkXML.IP {
+		ipNet, err := ipElem.ipNet()
+		if err != nil || ipNet.IP.To4() == nil {
+			continue
+		}
+		return ipNet.String(), ipElem.Address
+	}
+	return "", ""
+}
+
 // getConnection establishes a libvirt connection
 func getConnection(connectionURI string) (*libvirt.Connect, error) {
 	conn, err := libvirt.NewConnect(connectionURI)
@@ -54,6 +54,7 @@ type Parameters struct {
 	ClientMin string // first available client IP address after gateway
 	ClientMax string // last available client IP address before broadcast
 	Broadcast string // last network IP address
+	HAVIP     string // last client IP address, left out of the DHCP range for the VIP of multi-control-plane clusters
 	IsPrivate bool   // whether the IP is private or not
 	Interface
 }
@@ -71,6 +72,38 @@ type libvirtIPElement struct {
 	Netmask string `xml:"netmask,attr"`
 }
 
+// ipNet returns the network of the IP element, its prefix length taken from the prefix or netmask attribute
+// and defaulting to /24 when neither is set
+func (e libvirtIPElement) ipNet() (*net.IPNet, error) {
+	if net.ParseIP(e.Address) == nil {
+		return nil, fmt.Errorf("failed to parse address %s", e.Address)
+	}
+
+	var prefixLen int
+	if e.Prefix != "" {
+		if _, err := fmt.Sscanf(e.Prefix, "%d", &prefixLen); err != nil {
+			return nil, fmt.Errorf("failed to parse prefix %s: %w", e.Prefix, err)
+		}
+	} else if e.Netmask != "" {
+		netmaskIP := net.ParseIP(e.Netmask)
+		if netmaskIP == nil {
+			return nil, fmt.Errorf("failed to parse netmask %s", e.Netmask)
+		}
+		prefixLen, _ = net.IPMask(netmaskIP.To4()).Size()
+	}
+
+	if prefixLen == 0 {
+		// default to /24 if we can't determine prefix
+		prefixLen = 24
+	}
+
+	_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", e.Address, prefixLen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CIDR %s/%d: %w", e.Address, prefixLen, err)
+	}
+	return ipNet, nil
+}
+
 // FindFreeLibvirtSubnet finds a free subnet starting from the given subnet by checking libvirt networks
 // returns the CIDR of the free subnet found, or error if none found
 func FindFreeLibvirtSubnet(startSubnet string, step, tries int) (string, error) {
@@ -103,11 +136,15 @@ func FindFreeLibvirtSubnet(startSubnet string, step, tries int) (string, error)
 			return "", fmt.Errorf("invalid IPv4 subnet: %s", currSubnet)
 		}
 
+		// stop rather than wrap the octet around into an unrelated range
+		octet := 2
 		if prefix <= 16 {
-			nextIP[1] += byte(step)
-		} else {
-			nextIP[2] += byte(step)
+			octet = 1
+		}
+		if int(nextIP[octet])+step > 255 {
+			return "", fmt.Errorf("no free subnet found after %d tries starting from %s, ran out of addresses after %s", try+1, startSubnet, currSubnet)
 		}
+		nextIP[octet] += byte(step)
 
 		// construct next subnet CIDR
 		currSubnet = fmt.Sprintf("%s/%d", nextIP.String(), prefix)
@@ -162,41 +199,9 @@ func checkLibvirtSubnetOverlap(ipNet *net.IPNet) error {
 				continue
 			}
 
-			netIPAddr := net.ParseIP(ipElem.Address)
-			if netIPAddr == nil {
-				continue
-			}
-
-			// determine prefix length from prefix or netmask attribute
-			var prefixLen int
-			if ipElem.Prefix != "" {
-				if _, err := fmt.Sscanf(ipElem.Prefix, "%d", &prefixLen); err != nil {
-					logger.Debugf("failed to parse prefix %s: %v", ipElem.Prefix, err)
-					continue
-				}
-			} else if ipElem.Netmask != "" {
-				netmaskIP := net.ParseIP(ipElem.Netmask)
-				if netmaskIP != nil {
-					ones, _ := net.IPMask(netmaskIP.To4()).Size()
-					prefixLen = ones
-				} else {
-					logger.Debugf("failed to parse netmask %s", ipElem.Netmask)
-					continue
-				}
-			} else {
-				// no prefix or netmask specified, default to /24
-				prefixLen = 24
-			}
-
-			if prefixLen == 0 {
-				// default to /24 if we can't determine prefix
-				prefixLen = 24
-			}
-
-			// create network from extracted IP and prefix
-			_, existingNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", ipElem.Address, prefixLen))
+			existingNet, err := ipElem.ipNet()
 			if err != nil {
-				logger.Debugf("failed to parse CIDR %s/%d: %v", ipElem.Address, prefixLen, err)
+				logger.Debugf("skipping network IP: %v", err)
 				continue
 			}
 
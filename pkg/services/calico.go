@@ -0,0 +1,176 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/helm"
+)
+
+const (
+	// calicoOperatorNamespace is the namespace the tigera operator is installed into
+	calicoOperatorNamespace = "tigera-operator"
+	// calicoSystemNamespace is the namespace the operator deploys calico components into
+	calicoSystemNamespace = "calico-system"
+)
+
+// CalicoManager manages Calico installation and verification
+type CalicoManager struct {
+	helmManager   *helm.HelmManager
+	binaryManager BinaryManagerInterface
+	timeout       time.Duration // readiness timeout for the calico components
+}
+
+// NewCalicoManager creates a new Calico manager
+func NewCalicoManager(helmManager *helm.HelmManager, binaryManager BinaryManagerInterface) *CalicoManager {
+	return &CalicoManager{
+		helmManager:   helmManager,
+		binaryManager: binaryManager,
+		timeout:       config.DefaultCNIReadinessTimeout,
+	}
+}
+
+// SetReadinessTimeout overrides how long to wait for Calico to become ready
+func (cm *CalicoManager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		cm.timeout = timeout
+	}
+}
+
+// calicoValues returns the tigera operator chart values
+func calicoValues() map[string]interface{} {
+	return map[string]interface{}{
+		"installation": map[string]interface{}{
+			"cni": map[string]interface{}{
+				"type": "Calico",
+			},
+		},
+	}
+}
+
+// InstallCalico installs Calico using the tigera operator Helm chart
+func (cm *CalicoManager) InstallCalico(clusterName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("installing Calico on cluster %s", clusterName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	// add projectcalico repository
+	if err := cm.helmManager.AddRepository("projectcalico", "https://docs.tigera.io/calico/charts"); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to add projectcalico repository: %w", err)
+	}
+
+	// install tigera operator chart
+	if err := cm.helmManager.InstallChart(clusterName, "calico", "projectcalico/tigera-operator", "", calicoOperatorNamespace, calicoValues(), 5*time.Minute); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to install calico chart: %w", err)
+	}
+
+	// wait for calico components rolled out by the operator
+	if err := cm.WaitForCalicoReady(clusterName); err != nil {
+		status.End(false)
+		return fmt.Errorf("calico pods not ready: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the tigera operator Helm release from a cluster. Minikube clusters run its built-in Calico,
+// which has no release and is left as it is
+func (cm *CalicoManager) Uninstall(contextName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("uninstalling Calico from cluster %s", contextName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	if err := uninstallRelease(cm.helmManager, contextName, "calico", calicoOperatorNamespace); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to uninstall calico chart: %w", err)
+	}
+
+	// Success - status.End(true) will be called by defer
+	return nil
+}
+
+// WaitForCalicoReady waits for Calico to be ready
+func (cm *CalicoManager) WaitForCalicoReady(clusterName string) error {
+	logger.Debugf("waiting for Calico to be ready on cluster %s", clusterName)
+
+	client, err := cm.helmManager.GetKubernetesClient(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+
+	ctx := context.Background()
+	deadline := time.Now().Add(cm.timeout)
+
+	logger.Debugf("waiting for calico-node DaemonSet and calico-kube-controllers to be ready...")
+
+	for time.Now().Before(deadline) {
+		// check calico-node daemonset (created by the operator, so may not exist yet)
+		daemonset, err := client.AppsV1().DaemonSets(calicoSystemNamespace).Get(ctx, "calico-node", metav1.GetOptions{})
+		if err != nil {
+			logger.Debugf("failed to get calico-node daemonset: %v", err)
+			time.Sleep(10 * time.Second)
+			continue
+		}
+
+		// check calico-kube-controllers deployment
+		deployment, err := client.AppsV1().Deployments(calicoSystemNamespace).Get(ctx, "calico-kube-controllers", metav1.GetOptions{})
+		if err != nil {
+			logger.Debugf("failed to get calico-kube-controllers deployment: %v", err)
+			time.Sleep(10 * time.Second)
+			continue
+		}
+
+		daemonsetReady := daemonset.Status.DesiredNumberScheduled > 0 &&
+			daemonset.Status.NumberReady == daemonset.Status.DesiredNumberScheduled
+		controllersReady := deployment.Spec.Replicas != nil && deployment.Status.ReadyReplicas > 0 &&
+			deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
+
+		logger.Debugf("Calico status - DaemonSet: %v (%d/%d), Controllers: %v",
+			daemonsetReady, daemonset.Status.NumberReady, daemonset.Status.DesiredNumberScheduled, controllersReady)
+
+		if daemonsetReady && controllersReady {
+			return nil
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timeout waiting for Calico to be ready on cluster %s", clusterName)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CalicoManager", func() {
+	It("should wait 10m by default and take the timeout when one is set", func() {
+		calicoManager := NewCalicoManager(nil, nil)
+		Expect(calicoManager.timeout).To(Equal(config.DefaultCNIReadinessTimeout))
+
+		calicoManager.SetReadinessTimeout(0)
+		Expect(calicoManager.timeout).To(Equal(config.DefaultCNIReadinessTimeout))
+
+		calicoManager.SetReadinessTimeout(15 * time.Minute)
+		Expect(calicoManager.timeout).To(Equal(15 * time.Minute))
+	})
+})
@@ -26,19 +26,27 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
 	"github.com/day0ops/lok8s/pkg/util/helm"
 )
 
+// ciliumRepoChart is the chart installed unless a local chart is set
+const ciliumRepoChart = "cilium/cilium"
+
 // CiliumManager manages Cilium installation and verification
 type CiliumManager struct {
 	helmManager   *helm.HelmManager
 	binaryManager BinaryManagerInterface
+	timeout       time.Duration // readiness timeout for the chart install and pod waits
+	chart         string        // repo chart ref, or a local chart directory or archive
+	chartVersion  string        // pinned cilium chart version, empty for the latest
+	ipFamily      string        // ipv4 (default), ipv6 or dual
+	clusterMesh   *clusterMesh  // set when the clusters are meshed, see EnableClusterMesh
 }
 
 // BinaryManagerInterface defines the interface for binary management
@@ -51,11 +59,38 @@ func NewCiliumManager(helmManager *helm.HelmManager, binaryManager BinaryManager
 	return &CiliumManager{
 		helmManager:   helmManager,
 		binaryManager: binaryManager,
+		timeout:       config.DefaultCNIReadinessTimeout,
+		chart:         ciliumRepoChart,
+	}
+}
+
+// SetReadinessTimeout overrides how long to wait for Cilium to become ready
+func (cm *CiliumManager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		cm.timeout = timeout
 	}
 }
 
-// InstallCilium installs Cilium using Helm
-func (cm *CiliumManager) InstallCilium(clusterName string) error {
+// SetChartVersion pins the Cilium chart version, an empty version installs the latest chart
+func (cm *CiliumManager) SetChartVersion(version string) {
+	cm.chartVersion = version
+}
+
+// SetChart installs Cilium from a local chart directory or archive, an empty path keeps the repo chart
+func (cm *CiliumManager) SetChart(path string) {
+	if path == "" {
+		path = ciliumRepoChart
+	}
+	cm.chart = path
+}
+
+// SetIPFamily sets the IP family of the clusters Cilium is installed on, IPv6 is enabled for ipv6 and dual
+func (cm *CiliumManager) SetIPFamily(ipFamily string) {
+	cm.ipFamily = ipFamily
+}
+
+// InstallCilium installs Cilium using Helm, the cluster index (1-3) becomes the Cilium cluster ID
+func (cm *CiliumManager) InstallCilium(clusterName string, clusterIndex int) error {
 	status := logger.NewStatus()
 	status.Start(fmt.Sprintf("installing Cilium on cluster %s", clusterName))
 	defer func() {
@@ -64,31 +99,73 @@ func (cm *CiliumManager) InstallCilium(clusterName string) error {
 		}
 	}()
 
-	// add cilium repository
-	if err := cm.helmManager.AddRepository("cilium", "https://helm.cilium.io/"); err != nil {
-		status.End(false)
-		return fmt.Errorf("failed to add cilium repository: %w", err)
+	// add cilium repository, a local chart is installed without it
+	if cm.chart == ciliumRepoChart {
+		if err := cm.helmManager.AddRepository("cilium", "https://helm.cilium.io/"); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to add cilium repository: %w", err)
+		}
 	}
 
 	// install cilium chart
+	if err := cm.helmManager.InstallChart(clusterName, "cilium", cm.chart, cm.chartVersion, "kube-system", cm.ciliumValues(clusterName, clusterIndex), cm.timeout); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to install cilium chart: %w", err)
+	}
+
+	// wait for cilium pods to be ready before running connectivity test
+	if err := cm.WaitForCiliumReady(clusterName); err != nil {
+		status.End(false)
+		return fmt.Errorf("cilium pods not ready: %w", err)
+	}
+
+	return nil
+}
+
+// ciliumValues returns the Helm values Cilium is installed with on a cluster, including its cluster mesh settings.
+// Every cluster of a project gets its own cluster name and ID so their identities don't collide
+func (cm *CiliumManager) ciliumValues(clusterName string, clusterIndex int) map[string]interface{} {
 	values := map[string]interface{}{
 		"kubeProxyReplacement": false,
 		"envoy": map[string]interface{}{
 			"enabled": false,
 		},
+		"cluster": map[string]interface{}{
+			"name": clusterName,
+			"id":   clusterIndex,
+		},
 	}
-
-	if err := cm.helmManager.InstallChart("cilium", "cilium/cilium", "kube-system", values, 5*time.Minute); err != nil {
-		status.End(false)
-		return fmt.Errorf("failed to install cilium chart: %w", err)
+	// the node pod CIDRs handed out by kubernetes carry the IPv6 ranges, cilium's own cluster pool is IPv4 only
+	if cm.ipFamily == config.IPFamilyIPv6 || cm.ipFamily == config.IPFamilyDual {
+		values["ipv6"] = map[string]interface{}{"enabled": true}
+		values["ipam"] = map[string]interface{}{"mode": "kubernetes"}
 	}
+	if cm.ipFamily == config.IPFamilyIPv6 {
+		values["ipv4"] = map[string]interface{}{"enabled": false}
+	}
+	for key, value := range cm.clusterMeshValues(clusterName) {
+		values[key] = value
+	}
+	return values
+}
 
-	// wait for cilium pods to be ready before running connectivity test
-	if err := cm.WaitForCiliumReady(clusterName); err != nil {
+// Uninstall removes the Cilium Helm release from a cluster. Clusters that got Cilium as a manifest
+// (minikube) have no release and are left as they are
+func (cm *CiliumManager) Uninstall(contextName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("uninstalling Cilium from cluster %s", contextName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	if err := uninstallRelease(cm.helmManager, contextName, "cilium", "kube-system"); err != nil {
 		status.End(false)
-		return fmt.Errorf("cilium pods not ready: %w", err)
+		return fmt.Errorf("failed to uninstall cilium chart: %w", err)
 	}
 
+	// Success - status.End(true) will be called by defer
 	return nil
 }
 
@@ -96,14 +173,13 @@ func (cm *CiliumManager) InstallCilium(clusterName string) error {
 func (cm *CiliumManager) WaitForCiliumReady(clusterName string) error {
 	logger.Debugf("waiting for Cilium to be ready on cluster %s", clusterName)
 
-	client, err := cm.helmManager.GetKubernetesClient()
+	client, err := cm.helmManager.GetKubernetesClient(clusterName)
 	if err != nil {
 		return fmt.Errorf("failed to get kubernetes client: %w", err)
 	}
 
 	ctx := context.Background()
-	timeout := 10 * time.Minute
-	deadline := time.Now().Add(timeout)
+	deadline := time.Now().Add(cm.timeout)
 
 	logger.Debugf("waiting for Cilium DaemonSet and operator to be ready...")
 
@@ -189,26 +265,20 @@ func (cm *CiliumManager) WaitForCiliumReady(clusterName string) error {
 
 // GenerateCiliumManifest generates a Cilium manifest file from the helm chart
 // returns the path to the generated manifest file
-func (cm *CiliumManager) GenerateCiliumManifest(clusterName string) (string, error) {
+func (cm *CiliumManager) GenerateCiliumManifest(clusterName string, clusterIndex int) (string, error) {
 	logger.Debugf("generating Cilium manifest for cluster %s", clusterName)
 
-	// cilium values matching the InstallCilium function
-	values := map[string]interface{}{
-		"kubeProxyReplacement": false,
-		"envoy": map[string]interface{}{
-			"enabled": false,
-		},
-	}
-
 	// render the helm chart to manifests
-	manifestYAML, err := cm.helmManager.TemplateChart("cilium", "cilium/cilium", "kube-system", values)
+	manifestYAML, err := cm.helmManager.TemplateChart("cilium", cm.chart, cm.chartVersion, "kube-system", cm.ciliumValues(clusterName, clusterIndex))
 	if err != nil {
 		return "", fmt.Errorf("failed to template Cilium chart: %w", err)
 	}
 
-	// create temporary file for the manifest
-	tmpDir := os.TempDir()
-	manifestPath := filepath.Join(tmpDir, fmt.Sprintf("cilium-%s-manifest.yaml", clusterName))
+	// create temporary file for the manifest, or keep it in the artifacts dir
+	manifestPath, err := config.ArtifactPath(fmt.Sprintf("cilium-%s-manifest.yaml", clusterName))
+	if err != nil {
+		return "", err
+	}
 
 	// write manifest to file
 	if err := os.WriteFile(manifestPath, manifestYAML, 0644); err != nil {
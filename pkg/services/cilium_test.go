@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CiliumManager", func() {
+	It("should give every cluster of a project its own cluster name and ID", func() {
+		ciliumManager := NewCiliumManager(nil, nil)
+
+		ids := map[interface{}]bool{}
+		for i := 1; i <= 3; i++ {
+			contextName := fmt.Sprintf("myproject-%d", i)
+			values := ciliumManager.ciliumValues(contextName, i)
+			Expect(values).To(HaveKeyWithValue("cluster", map[string]interface{}{"name": contextName, "id": i}))
+			ids[values["cluster"].(map[string]interface{})["id"]] = true
+		}
+		Expect(ids).To(HaveLen(3))
+	})
+
+	It("should enable IPv6 for ipv6 and dual-stack clusters", func() {
+		ciliumManager := NewCiliumManager(nil, nil)
+		values := ciliumManager.ciliumValues("myproject", 1)
+		Expect(values).NotTo(HaveKey("ipv6"))
+		Expect(values).NotTo(HaveKey("ipv4"))
+
+		ciliumManager.SetIPFamily(config.IPFamilyDual)
+		values = ciliumManager.ciliumValues("myproject", 1)
+		Expect(values).To(HaveKeyWithValue("ipv6", map[string]interface{}{"enabled": true}))
+		Expect(values).To(HaveKeyWithValue("ipam", map[string]interface{}{"mode": "kubernetes"}))
+		Expect(values).NotTo(HaveKey("ipv4"))
+
+		ciliumManager.SetIPFamily(config.IPFamilyIPv6)
+		values = ciliumManager.ciliumValues("myproject", 1)
+		Expect(values).To(HaveKeyWithValue("ipv6", map[string]interface{}{"enabled": true}))
+		Expect(values).To(HaveKeyWithValue("ipv4", map[string]interface{}{"enabled": false}))
+	})
+
+	It("should wait 10m by default and take a shorter timeout when one is set", func() {
+		ciliumManager := NewCiliumManager(nil, nil)
+		Expect(ciliumManager.timeout).To(Equal(config.DefaultCNIReadinessTimeout))
+		Expect(ciliumManager.timeout).To(Equal(10 * time.Minute))
+
+		ciliumManager.SetReadinessTimeout(0)
+		Expect(ciliumManager.timeout).To(Equal(10 * time.Minute))
+
+		ciliumManager.SetReadinessTimeout(90 * time.Second)
+		Expect(ciliumManager.timeout).To(Equal(90 * time.Second))
+	})
+
+	It("should install the repo chart unless a local chart is set", func() {
+		ciliumManager := NewCiliumManager(nil, nil)
+		Expect(ciliumManager.chart).To(Equal("cilium/cilium"))
+
+		ciliumManager.SetChart("/charts/cilium-1.16.5.tgz")
+		Expect(ciliumManager.chart).To(Equal("/charts/cilium-1.16.5.tgz"))
+
+		ciliumManager.SetChart("")
+		Expect(ciliumManager.chart).To(Equal("cilium/cilium"))
+	})
+})
@@ -25,18 +25,19 @@ package services
 import (
 	"archive/tar"
 	"compress/gzip"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/day0ops/lok8s/pkg/config"
 	"github.com/day0ops/lok8s/pkg/logger"
@@ -44,11 +45,18 @@ import (
 	"github.com/day0ops/lok8s/pkg/util/k8s"
 )
 
+// cloudProviderLogFile is the file in the log directory that captures the process output
+const cloudProviderLogFile = "cloud-provider-kind.log"
+
 // CloudProviderKindManager manages cloud-provider-kind installation and operation
 type CloudProviderKindManager struct {
 	githubClient *github.GitHubClient
 	processCache *ProcessCache
-	testVersion  string // for testing purposes
+	version      string            // pinned release, empty for the latest
+	binDir       string            // where the verified binaries are cached between runs
+	installMu    sync.Mutex        // serializes installs so parallel cluster creation doesn't race on the process cache
+	extraArgs    []string          // flags appended to the built-in cloud-provider-kind flags
+	extraEnv     map[string]string // environment variables set on top of KUBECONFIG
 }
 
 // CloudProviderProcess represents a running cloud-provider-kind process
@@ -59,6 +67,18 @@ type CloudProviderProcess struct {
 	LogDir      string `json:"log_dir"`
 	BinaryPath  string `json:"binary_path"`
 	StartTime   string `json:"start_time"`
+	// the extra flags and environment the process was started with, so a restart can reuse them
+	ExtraArgs []string          `json:"extra_args,omitempty"`
+	ExtraEnv  map[string]string `json:"extra_env,omitempty"`
+}
+
+// Uptime returns how long the process has been running relative to now
+func (p CloudProviderProcess) Uptime(now time.Time) (time.Duration, error) {
+	started, err := time.Parse(time.RFC3339, p.StartTime)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start time %q for context %s: %w", p.StartTime, p.ContextName, err)
+	}
+	return now.Sub(started), nil
 }
 
 // ProcessCache manages cloud-provider-kind process tracking
@@ -72,14 +92,30 @@ func NewCloudProviderKindManager() *CloudProviderKindManager {
 	return &CloudProviderKindManager{
 		githubClient: github.NewGitHubClient(),
 		processCache: newProcessCache(),
-		testVersion:  "", // empty means use latest
+		version:      "", // empty means use latest
+		binDir:       filepath.Join(os.Getenv("HOME"), ".lok8", "bin"),
 	}
 }
 
-// SetTestVersion sets a specific version for testing purposes
-func (cpkm *CloudProviderKindManager) SetTestVersion(version string) {
-	cpkm.testVersion = version
-	logger.Debugf("set test version to: %s", version)
+// SetVersion pins the cloud-provider-kind release to download, e.g. 0.6.0, an empty version or "latest" uses the
+// latest release
+func (cpkm *CloudProviderKindManager) SetVersion(version string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "latest" {
+		version = ""
+	}
+	cpkm.version = version
+	logger.Debugf("set cloud-provider-kind version to: %s", version)
+}
+
+// SetExtraArgs sets the flags passed to cloud-provider-kind after the built-in ones
+func (cpkm *CloudProviderKindManager) SetExtraArgs(args []string) {
+	cpkm.extraArgs = args
+}
+
+// SetExtraEnv sets the environment variables cloud-provider-kind is started with, e.g. proxy settings
+func (cpkm *CloudProviderKindManager) SetExtraEnv(env map[string]string) {
+	cpkm.extraEnv = env
 }
 
 // newProcessCache creates a new process cache
@@ -150,6 +186,48 @@ func (pc *ProcessCache) getProcess(contextName string) (CloudProviderProcess, bo
 	return process, exists
 }
 
+// Prune removes cache entries whose process is no longer alive (e.g. after a reboot)
+// and returns the removed entries
+func (pc *ProcessCache) Prune() ([]CloudProviderProcess, error) {
+	if err := pc.loadProcessCache(); err != nil {
+		return nil, err
+	}
+
+	var pruned []CloudProviderProcess
+	for contextName, process := range pc.Processes {
+		if isProcessAlive(process.PID) {
+			continue
+		}
+
+		logger.Debugf("pruning stale cloud-provider-kind entry for context %s (PID: %d)", contextName, process.PID)
+		if process.TempDir != "" {
+			if err := os.RemoveAll(process.TempDir); err != nil {
+				logger.Warnf("failed to remove temp directory %s: %v", process.TempDir, err)
+			}
+		}
+		delete(pc.Processes, contextName)
+		pruned = append(pruned, process)
+	}
+
+	if len(pruned) > 0 {
+		if err := pc.saveProcessCache(); err != nil {
+			return nil, err
+		}
+	}
+
+	return pruned, nil
+}
+
+// isProcessAlive reports whether a process with the given pid exists by sending it signal 0
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	// EPERM means the process exists but belongs to another user (e.g. started with sudo)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
 // terminateProcess safely terminates a cloud-provider-kind process
 func (pc *ProcessCache) terminateProcess(contextName string) error {
 	process, exists := pc.getProcess(contextName)
@@ -199,6 +277,9 @@ func (cpkm *CloudProviderKindManager) Install(contextName string, skipOsCheck bo
 		return nil
 	}
 
+	cpkm.installMu.Lock()
+	defer cpkm.installMu.Unlock()
+
 	status := logger.NewStatus()
 	status.Start(fmt.Sprintf("installing cloud-provider-kind for context %s", contextName))
 	defer func() {
@@ -207,30 +288,15 @@ func (cpkm *CloudProviderKindManager) Install(contextName string, skipOsCheck bo
 		}
 	}()
 
-	// create temp directory for cloud-provider-kind
-	tempDir, err := os.MkdirTemp("", "cloud-provider-kind-*")
+	// reuse the cached cloud-provider-kind binary, downloading it when missing or stale
+	binaryPath, err := cpkm.ensureBinary()
 	if err != nil {
 		status.End(false)
-		return fmt.Errorf("failed to create temp directory: %w", err)
-	}
-
-	// download cloud-provider-kind binary
-	binaryPath := filepath.Join(tempDir, "cloud-provider-kind")
-	if err := cpkm.downloadBinary(binaryPath); err != nil {
-		status.End(false)
-		os.RemoveAll(tempDir) // cleanup on failure
 		return fmt.Errorf("failed to download cloud-provider-kind: %w", err)
 	}
 
-	// make binary executable
-	if err := os.Chmod(binaryPath, 0755); err != nil {
-		status.End(false)
-		os.RemoveAll(tempDir) // cleanup on failure
-		return fmt.Errorf("failed to make cloud-provider-kind executable: %w", err)
-	}
-
-	// start cloud-provider-kind as background process
-	if err := cpkm.startProcess(binaryPath, contextName, tempDir); err != nil {
+	// start cloud-provider-kind as background process, the cached binary is shared so there is no temp directory to clean up
+	if err := cpkm.startProcess(binaryPath, contextName, ""); err != nil {
 		status.End(false)
 		return fmt.Errorf("failed to start cloud-provider-kind: %w", err)
 	}
@@ -239,24 +305,119 @@ func (cpkm *CloudProviderKindManager) Install(contextName string, skipOsCheck bo
 	return nil
 }
 
-// downloadBinary downloads the cloud-provider-kind binary with checksum verification
-func (cpkm *CloudProviderKindManager) downloadBinary(binaryPath string) error {
-	logger.Debugf("downloading cloud-provider-kind binary to %s", binaryPath)
+// resolveVersion returns the version to install, the pinned version if set and otherwise the latest release. When
+// the latest release can't be looked up (e.g. offline) the newest cached binary is used before the default version
+func (cpkm *CloudProviderKindManager) resolveVersion() string {
+	if cpkm.version != "" {
+		logger.Debugf("using pinned version: %s", cpkm.version)
+		return cpkm.version
+	}
+
+	version, err := cpkm.LatestVersion()
+	if err == nil {
+		return version
+	}
+	if cached := cpkm.newestCachedVersion(); cached != "" {
+		logger.Warnf("failed to get latest cloud-provider-kind version, using cached version %s: %v", cached, err)
+		return cached
+	}
+	logger.Warnf("failed to get latest cloud-provider-kind version, using default: %v", err)
+	return config.CloudProviderKindMinSupportedVersion // fallback to known working version
+}
 
-	// get version (use test version if set, otherwise get latest)
-	var version string
-	var err error
+// LatestVersion returns the latest cloud-provider-kind release
+func (cpkm *CloudProviderKindManager) LatestVersion() (string, error) {
+	return cpkm.githubClient.GetLatestVersion("kubernetes-sigs", "cloud-provider-kind")
+}
 
-	if cpkm.testVersion != "" {
-		version = cpkm.testVersion
-		logger.Debugf("using test version: %s", version)
-	} else {
-		version, err = cpkm.githubClient.GetLatestVersion("kubernetes-sigs", "cloud-provider-kind")
-		if err != nil {
-			logger.Warnf("failed to get latest cloud-provider-kind version, using default: %v", err)
-			version = config.CloudProviderKindMinSupportedVersion // fallback to known working version
+// CachedVersion returns the version of the newest cached cloud-provider-kind binary, empty when none is cached
+func (cpkm *CloudProviderKindManager) CachedVersion() string {
+	return cpkm.newestCachedVersion()
+}
+
+// cachedBinaryPath returns where the binary of the version is cached
+func (cpkm *CloudProviderKindManager) cachedBinaryPath(version string) string {
+	return filepath.Join(cpkm.binDir, fmt.Sprintf("cloud-provider-kind-%s", version))
+}
+
+// newestCachedVersion returns the version of the most recently cached binary that is still intact, empty for none
+func (cpkm *CloudProviderKindManager) newestCachedVersion() string {
+	matches, err := filepath.Glob(filepath.Join(cpkm.binDir, "cloud-provider-kind-*.sha256"))
+	if err != nil {
+		return ""
+	}
+
+	var newest string
+	var newestTime time.Time
+	for _, checksumPath := range matches {
+		binaryPath := strings.TrimSuffix(checksumPath, ".sha256")
+		info, err := os.Stat(binaryPath)
+		if err != nil || !cachedBinaryValid(binaryPath) {
+			continue
+		}
+		if newest == "" || info.ModTime().After(newestTime) {
+			newest = strings.TrimPrefix(filepath.Base(binaryPath), "cloud-provider-kind-")
+			newestTime = info.ModTime()
 		}
 	}
+	return newest
+}
+
+// cachedBinaryValid reports whether the cached binary still matches the checksum recorded when it was verified
+func cachedBinaryValid(binaryPath string) bool {
+	expected, err := os.ReadFile(binaryPath + ".sha256")
+	if err != nil {
+		return false
+	}
+	actual, err := github.FileSHA256(binaryPath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(expected)) == actual
+}
+
+// ensureBinary returns the cached cloud-provider-kind binary, downloading and verifying it when it is missing or no
+// longer matches its recorded checksum
+func (cpkm *CloudProviderKindManager) ensureBinary() (string, error) {
+	version := cpkm.resolveVersion()
+	binaryPath := cpkm.cachedBinaryPath(version)
+	if cachedBinaryValid(binaryPath) {
+		logger.Debugf("using cached cloud-provider-kind binary %s", binaryPath)
+		return binaryPath, nil
+	}
+
+	if err := os.MkdirAll(cpkm.binDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create binary cache directory %s: %w", cpkm.binDir, err)
+	}
+
+	// download next to the cached binary so a failed download never replaces a working one
+	downloadPath := binaryPath + ".download"
+	defer os.Remove(downloadPath)
+	if err := cpkm.downloadBinary(downloadPath, version); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(downloadPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to make cloud-provider-kind executable: %w", err)
+	}
+
+	checksum, err := github.FileSHA256(downloadPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to checksum cloud-provider-kind: %w", err)
+	}
+	if err := os.Rename(downloadPath, binaryPath); err != nil {
+		return "", fmt.Errorf("failed to cache cloud-provider-kind binary: %w", err)
+	}
+	if err := os.WriteFile(binaryPath+".sha256", []byte(checksum+"\n"), 0644); err != nil {
+		logger.Warnf("failed to record the checksum of %s, it will be downloaded again next time: %v", binaryPath, err)
+	}
+
+	logger.Debugf("cached cloud-provider-kind %s at %s", version, binaryPath)
+	return binaryPath, nil
+}
+
+// downloadBinary downloads the cloud-provider-kind binary of the version with checksum verification
+func (cpkm *CloudProviderKindManager) downloadBinary(binaryPath, version string) error {
+	logger.Debugf("downloading cloud-provider-kind binary to %s", binaryPath)
 
 	// construct binary name
 	binaryName := getBinaryName(version)
@@ -355,18 +516,23 @@ func (cpkm *CloudProviderKindManager) startProcess(binaryPath, contextName, temp
 		return fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	cmd := exec.Command(binaryPath, "-enable-lb-port-mapping", "-enable-log-dumping", "-logs-dir", logDir)
+	cmd := exec.Command(binaryPath, cpkm.processArgs(logDir)...)
 
 	// set environment variables
 	path, err := k8s.GetKubeConfigPath()
 	if err != nil {
 		return err
 	}
-	cmd.Env = append(os.Environ(), fmt.Sprintf("KUBECONFIG=%s", path))
+	cmd.Env = append(os.Environ(), cpkm.processEnv(path)...)
 
-	// redirect stdout and stderr to /dev/null to avoid zombie processes
-	//cmd.Stderr = os.Stderr
-	//cmd.Stdout = os.Stdout
+	// capture the process output alongside the dumped load balancer logs
+	logFile, err := os.Create(filepath.Join(logDir, cloudProviderLogFile))
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	defer logFile.Close() // the child keeps its own descriptor
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
 	cmd.Stdin = os.Stdin
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -385,7 +551,9 @@ func (cpkm *CloudProviderKindManager) startProcess(binaryPath, contextName, temp
 		TempDir:     tempDir,
 		LogDir:      logDir,
 		BinaryPath:  binaryPath,
-		StartTime:   fmt.Sprintf("%d", cmd.Process.Pid), // simple timestamp placeholder
+		StartTime:   time.Now().Format(time.RFC3339),
+		ExtraArgs:   cpkm.extraArgs,
+		ExtraEnv:    cpkm.extraEnv,
 	}
 	if err := cpkm.processCache.addProcess(contextName, process); err != nil {
 		logger.Warnf("failed to add process to cache: %v", err)
@@ -401,20 +569,95 @@ func (cpkm *CloudProviderKindManager) startProcess(binaryPath, contextName, temp
 	return nil
 }
 
+// processArgs returns the cloud-provider-kind flags, the extra flags come last so they can override the built-in ones
+func (cpkm *CloudProviderKindManager) processArgs(logDir string) []string {
+	args := []string{"-enable-lb-port-mapping", "-enable-log-dumping", "-logs-dir", logDir}
+	return append(args, cpkm.extraArgs...)
+}
+
+// processEnv returns the KEY=VALUE pairs added to the environment of cloud-provider-kind, sorted by key
+func (cpkm *CloudProviderKindManager) processEnv(kubeconfigPath string) []string {
+	env := []string{fmt.Sprintf("KUBECONFIG=%s", kubeconfigPath)}
+	keys := make([]string, 0, len(cpkm.extraEnv))
+	for key := range cpkm.extraEnv {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", key, cpkm.extraEnv[key]))
+	}
+	return env
+}
+
 // verifyProcessRunning checks if a process is actually running
 func (cpkm *CloudProviderKindManager) verifyProcessRunning(pid int) error {
-	_, err := os.FindProcess(pid)
-	if err != nil {
-		return fmt.Errorf("process %d is not running: %w", pid, err)
+	if !isProcessAlive(pid) {
+		return fmt.Errorf("process %d is not running", pid)
 	}
 	logger.Debugf("verified process %d is running", pid)
 	return nil
 }
 
-// HasExistingProcesses checks if there are any existing cloud-provider-kind processes in the cache
-func (cpkm *CloudProviderKindManager) HasExistingProcesses() (bool, []CloudProviderProcess, error) {
+// LogFiles returns the log files written for the cloud-provider-kind process of the given context,
+// including its own output and the load balancer logs it dumps
+func (cpkm *CloudProviderKindManager) LogFiles(contextName string) ([]string, error) {
+	process, exists := cpkm.processCache.getProcess(contextName)
+	if !exists {
+		return nil, fmt.Errorf("no cloud-provider-kind process found for context %s", contextName)
+	}
+
+	if process.LogDir == "" {
+		return nil, fmt.Errorf("no log directory recorded for context %s", contextName)
+	}
+	if _, err := os.Stat(process.LogDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("log directory %s for context %s no longer exists", process.LogDir, contextName)
+	}
+
+	var files []string
+	err := filepath.WalkDir(process.LogDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log directory %s: %w", process.LogDir, err)
+	}
+
+	return files, nil
+}
+
+// PruneStaleProcesses removes cached processes that are no longer running
+func (cpkm *CloudProviderKindManager) PruneStaleProcesses() ([]CloudProviderProcess, error) {
+	return cpkm.processCache.Prune()
+}
+
+// StaleProcesses returns the cached processes that are no longer running without removing them
+func (cpkm *CloudProviderKindManager) StaleProcesses() ([]CloudProviderProcess, error) {
 	if err := cpkm.processCache.loadProcessCache(); err != nil {
-		logger.Debugf("failed to load process cache: %v", err)
+		return nil, err
+	}
+
+	var stale []CloudProviderProcess
+	for _, process := range cpkm.processCache.Processes {
+		if !isProcessAlive(process.PID) {
+			stale = append(stale, process)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].ContextName < stale[j].ContextName
+	})
+	return stale, nil
+}
+
+// HasExistingProcesses checks if there are any existing cloud-provider-kind processes in the cache,
+// ignoring entries whose process has already exited
+func (cpkm *CloudProviderKindManager) HasExistingProcesses() (bool, []CloudProviderProcess, error) {
+	if _, err := cpkm.processCache.Prune(); err != nil {
+		logger.Debugf("failed to prune process cache: %v", err)
 		return false, nil, nil
 	}
 
@@ -424,6 +667,11 @@ func (cpkm *CloudProviderKindManager) HasExistingProcesses() (bool, []CloudProvi
 		logger.Debugf("found cloud-provider-kind process entry for context %s (PID: %d)", contextName, process.PID)
 	}
 
+	// keep the listing stable across runs
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ContextName < processes[j].ContextName
+	})
+
 	return len(processes) > 0, processes, nil
 }
 
@@ -441,98 +689,17 @@ func (cpkm *CloudProviderKindManager) Terminate(contextName string, skipOsCheck
 
 // verifyChecksum verifies the SHA256 checksum of the downloaded binary
 func (cpkm *CloudProviderKindManager) verifyChecksum(binaryPath, version, binaryName string) error {
-	logger.Debugf("verifying checksum for %s", binaryPath)
-
-	// fetch checksums from GitHub
-	expectedChecksum, err := cpkm.fetchExpectedChecksum(version)
-	if err != nil {
-		return fmt.Errorf("failed to fetch expected checksum: %w", err)
-	}
-
-	// calculate actual checksum
-	actualChecksum, err := cpkm.calculateFileChecksum(binaryPath)
-	if err != nil {
-		return fmt.Errorf("failed to calculate file checksum: %w", err)
-	}
-
-	// compare checksums
-	if actualChecksum != expectedChecksum {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, actualChecksum)
-	}
-
-	logger.Debugf("checksum verification passed")
-	return nil
+	return cpkm.githubClient.VerifyChecksum(checksumsURL(version), expectedArchiveName(version), binaryPath)
 }
 
-// fetchExpectedChecksum fetches the expected SHA256 checksum from GitHub releases
-func (cpkm *CloudProviderKindManager) fetchExpectedChecksum(version string) (string, error) {
-	// construct checksums URL
-	checksumsURL := fmt.Sprintf("https://github.com/kubernetes-sigs/cloud-provider-kind/releases/download/v%s/cloud-provider-kind_%s_checksums.txt", version, version)
-
-	logger.Debugf("fetching checksums from: %s", checksumsURL)
-
-	// fetch checksums file
-	resp, err := http.Get(checksumsURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch checksums file: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to fetch checksums file, status: %d", resp.StatusCode)
-	}
-
-	// read checksums content
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read checksums content: %w", err)
-	}
-
-	// parse checksums and find the one for our binary
-	checksums := string(body)
-	lines := strings.Split(checksums, "\n")
-
-	// construct expected filename for checksum lookup
-	expectedFilename := fmt.Sprintf("cloud-provider-kind_%s_%s_%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// checksum format: "hash filename"
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			checksum := parts[0]
-			filename := parts[1]
-
-			if filename == expectedFilename {
-				logger.Debugf("found expected checksum for %s: %s", filename, checksum)
-				return checksum, nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("checksum not found for binary %s", expectedFilename)
+// checksumsURL returns the URL of the checksums file published with a cloud-provider-kind release
+func checksumsURL(version string) string {
+	return fmt.Sprintf("https://github.com/kubernetes-sigs/cloud-provider-kind/releases/download/v%s/cloud-provider-kind_%s_checksums.txt", version, version)
 }
 
-// calculateFileChecksum calculates the SHA256 checksum of a file
-func (cpkm *CloudProviderKindManager) calculateFileChecksum(filePath string) (string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", fmt.Errorf("failed to calculate hash: %w", err)
-	}
-
-	checksum := hex.EncodeToString(hash.Sum(nil))
-	logger.Debugf("calculated checksum for %s: %s", filePath, checksum)
-	return checksum, nil
+// expectedArchiveName returns the release archive name for the current platform
+func expectedArchiveName(version string) string {
+	return fmt.Sprintf("cloud-provider-kind_%s_%s_%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
 }
 
 // getBinaryName constructs the appropriate binary name for the current platform
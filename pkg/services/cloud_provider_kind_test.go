@@ -2,10 +2,14 @@ package services
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/day0ops/lok8s/pkg/util/github"
 )
 
 var _ = Describe("CloudProviderKindManager", func() {
@@ -26,15 +30,23 @@ var _ = Describe("CloudProviderKindManager", func() {
 	})
 
 	Describe("Version Management", func() {
-		Context("Test version setting", func() {
-			It("should allow setting a test version", func() {
-				testVersion := "0.8.0"
-				manager.SetTestVersion(testVersion)
-				Expect(manager.testVersion).To(Equal(testVersion))
+		Context("Version pinning", func() {
+			It("should allow pinning a version", func() {
+				pinnedVersion := "0.8.0"
+				manager.SetVersion(pinnedVersion)
+				Expect(manager.version).To(Equal(pinnedVersion))
 			})
 
-			It("should use latest version when no test version is set", func() {
-				Expect(manager.testVersion).To(Equal(""))
+			It("should use latest version when no version is pinned", func() {
+				Expect(manager.version).To(Equal(""))
+			})
+
+			It("should normalize the pinned version", func() {
+				manager.SetVersion("v0.6.0")
+				Expect(manager.version).To(Equal("0.6.0"))
+
+				manager.SetVersion("latest")
+				Expect(manager.version).To(Equal(""))
 			})
 		})
 	})
@@ -50,15 +62,14 @@ var _ = Describe("CloudProviderKindManager", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// calculate checksum
-				checksum, err := manager.calculateFileChecksum(testFile)
+				checksum, err := github.FileSHA256(testFile)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(checksum).To(HaveLen(64)) // SHA256 hex string length
-				Expect(checksum).To(MatchRegexp("^[a-f0-9]{64}$"))
+				Expect(checksum).To(Equal("dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f"))
 			})
 
 			It("should return error for non-existent file", func() {
 				nonExistentFile := filepath.Join(tempDir, "nonexistent.txt")
-				_, err := manager.calculateFileChecksum(nonExistentFile)
+				_, err := github.FileSHA256(nonExistentFile)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to open file"))
 			})
@@ -67,7 +78,7 @@ var _ = Describe("CloudProviderKindManager", func() {
 		Context("Checksum fetching", func() {
 			It("should fetch checksums for current platform", func() {
 				// Test fetching checksum for current platform
-				checksum, err := manager.fetchExpectedChecksum("0.8.0")
+				checksum, err := manager.githubClient.FetchChecksum(checksumsURL("0.8.0"), expectedArchiveName("0.8.0"))
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(checksum).To(HaveLen(64)) // SHA256 hex string length
@@ -75,14 +86,14 @@ var _ = Describe("CloudProviderKindManager", func() {
 			})
 
 			It("should return error for non-existent version", func() {
-				_, err := manager.fetchExpectedChecksum("999.999.999")
+				_, err := manager.githubClient.FetchChecksum(checksumsURL("999.999.999"), expectedArchiveName("999.999.999"))
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to fetch checksums file"))
 			})
 
 			// It("should return error for non-existent binary", func() {
 			// 	expectedFilename := "cloud-provider-kind_0.8.0_completely_nonexistent_platform.tar.gz"
-			// 	_, err := manager.fetchExpectedChecksum("0.8.0", expectedFilename)
+			// 	_, err := manager.githubClient.FetchChecksum(checksumsURL("0.8.0"), expectedFilename)
 			// 	Expect(err).To(HaveOccurred())
 			// 	Expect(err.Error()).To(ContainSubstring("checksum not found"))
 			// })
@@ -100,7 +111,6 @@ var _ = Describe("CloudProviderKindManager", func() {
 				// verify checksum (this will fail because we're not using the actual checksum from the checksums file)
 				err = manager.verifyChecksum(testFile, "0.8.0", "test.txt")
 				// This will fail because we're not using the actual checksum from the checksums file
-				// But we can test the logic by mocking the fetchExpectedChecksum method
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -134,6 +144,202 @@ var _ = Describe("CloudProviderKindManager", func() {
 		})
 	})
 
+	Describe("Process Logs", func() {
+		BeforeEach(func() {
+			manager.processCache.CacheFile = filepath.Join(tempDir, "cloud-provider-processes.json")
+		})
+
+		It("should list the log files for a tracked process", func() {
+			logDir := filepath.Join(tempDir, "logs")
+			Expect(os.MkdirAll(filepath.Join(logDir, "lb"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(logDir, cloudProviderLogFile), []byte("started\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(logDir, "lb", "envoy.log"), []byte("envoy\n"), 0644)).To(Succeed())
+
+			err := manager.processCache.addProcess("demo", CloudProviderProcess{PID: 1, ContextName: "demo", LogDir: logDir})
+			Expect(err).NotTo(HaveOccurred())
+
+			files, err := manager.LogFiles("demo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(ConsistOf(
+				filepath.Join(logDir, cloudProviderLogFile),
+				filepath.Join(logDir, "lb", "envoy.log"),
+			))
+		})
+
+		It("should return an error when no process is tracked", func() {
+			_, err := manager.LogFiles("missing")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no cloud-provider-kind process found"))
+		})
+
+		It("should return an error when the log directory was removed", func() {
+			logDir := filepath.Join(tempDir, "gone")
+			err := manager.processCache.addProcess("demo", CloudProviderProcess{PID: 1, ContextName: "demo", LogDir: logDir})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = manager.LogFiles("demo")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no longer exists"))
+		})
+	})
+
+	Describe("Binary Cache", func() {
+		cacheBinary := func(version, content string) string {
+			binaryPath := manager.cachedBinaryPath(version)
+			Expect(os.WriteFile(binaryPath, []byte(content), 0755)).To(Succeed())
+			checksum, err := github.FileSHA256(binaryPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(binaryPath+".sha256", []byte(checksum+"\n"), 0644)).To(Succeed())
+			return binaryPath
+		}
+
+		BeforeEach(func() {
+			manager.binDir = tempDir
+		})
+
+		It("should reuse a cached binary that matches its checksum", func() {
+			binaryPath := cacheBinary("0.8.0", "binary")
+			manager.SetVersion("0.8.0")
+
+			cached, err := manager.ensureBinary()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(Equal(binaryPath))
+		})
+
+		It("should treat a modified or unrecorded binary as stale", func() {
+			binaryPath := cacheBinary("0.8.0", "binary")
+			Expect(cachedBinaryValid(binaryPath)).To(BeTrue())
+
+			Expect(os.WriteFile(binaryPath, []byte("tampered"), 0755)).To(Succeed())
+			Expect(cachedBinaryValid(binaryPath)).To(BeFalse())
+
+			Expect(os.Remove(binaryPath + ".sha256")).To(Succeed())
+			Expect(cachedBinaryValid(binaryPath)).To(BeFalse())
+		})
+
+		It("should find the newest intact cached version", func() {
+			Expect(manager.newestCachedVersion()).To(BeEmpty())
+
+			older := cacheBinary("0.7.0", "older")
+			Expect(os.Chtimes(older, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))).To(Succeed())
+			cacheBinary("0.8.0", "newer")
+			Expect(manager.newestCachedVersion()).To(Equal("0.8.0"))
+
+			Expect(os.WriteFile(manager.cachedBinaryPath("0.8.0"), []byte("tampered"), 0755)).To(Succeed())
+			Expect(manager.newestCachedVersion()).To(Equal("0.7.0"))
+		})
+	})
+
+	Describe("Process Configuration", func() {
+		It("should append the extra flags after the built-in ones", func() {
+			manager.SetExtraArgs([]string{"-gateway-channel=disabled"})
+
+			Expect(manager.processArgs("/tmp/logs")).To(Equal([]string{
+				"-enable-lb-port-mapping", "-enable-log-dumping", "-logs-dir", "/tmp/logs", "-gateway-channel=disabled",
+			}))
+		})
+
+		It("should add the extra environment sorted by key", func() {
+			manager.SetExtraEnv(map[string]string{"LB_MODE": "tunnel", "HTTPS_PROXY": "http://proxy:3128"})
+
+			Expect(manager.processEnv("/home/dev/.kube/config")).To(Equal([]string{
+				"KUBECONFIG=/home/dev/.kube/config", "HTTPS_PROXY=http://proxy:3128", "LB_MODE=tunnel",
+			}))
+		})
+
+		It("should keep the extra flags and environment in the process cache", func() {
+			manager.processCache.CacheFile = filepath.Join(tempDir, "cloud-provider-processes.json")
+			process := CloudProviderProcess{PID: 1, ContextName: "demo", ExtraArgs: []string{"-v=2"}, ExtraEnv: map[string]string{"LB_MODE": "tunnel"}}
+			Expect(manager.processCache.addProcess("demo", process)).To(Succeed())
+
+			cache := &ProcessCache{CacheFile: manager.processCache.CacheFile}
+			Expect(cache.loadProcessCache()).To(Succeed())
+			loaded, exists := cache.getProcess("demo")
+			Expect(exists).To(BeTrue())
+			Expect(loaded.ExtraArgs).To(Equal(process.ExtraArgs))
+			Expect(loaded.ExtraEnv).To(Equal(process.ExtraEnv))
+		})
+	})
+
+	Describe("Process Uptime", func() {
+		It("should compute the uptime from the recorded start time", func() {
+			now := time.Now()
+			process := CloudProviderProcess{ContextName: "demo", StartTime: now.Add(-90 * time.Minute).Format(time.RFC3339)}
+
+			uptime, err := process.Uptime(now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uptime).To(BeNumerically("~", 90*time.Minute, time.Second))
+		})
+
+		It("should return an error for entries without a timestamp", func() {
+			process := CloudProviderProcess{ContextName: "demo", StartTime: "12345"}
+
+			_, err := process.Uptime(time.Now())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid start time"))
+		})
+
+		It("should list tracked processes sorted by context", func() {
+			manager.processCache.CacheFile = filepath.Join(tempDir, "cloud-provider-processes.json")
+			Expect(manager.processCache.addProcess("demo-2", CloudProviderProcess{PID: os.Getpid(), ContextName: "demo-2"})).To(Succeed())
+			Expect(manager.processCache.addProcess("demo-1", CloudProviderProcess{PID: os.Getpid(), ContextName: "demo-1"})).To(Succeed())
+
+			hasExisting, processes, err := manager.HasExistingProcesses()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasExisting).To(BeTrue())
+			Expect(processes).To(HaveLen(2))
+			Expect(processes[0].ContextName).To(Equal("demo-1"))
+			Expect(processes[1].ContextName).To(Equal("demo-2"))
+		})
+	})
+
+	Describe("Process Pruning", func() {
+		var deadPID int
+
+		BeforeEach(func() {
+			manager.processCache.CacheFile = filepath.Join(tempDir, "cloud-provider-processes.json")
+
+			// a process that has already exited and been reaped
+			cmd := exec.Command("true")
+			Expect(cmd.Run()).To(Succeed())
+			deadPID = cmd.Process.Pid
+		})
+
+		It("should detect whether a process is alive", func() {
+			Expect(isProcessAlive(os.Getpid())).To(BeTrue())
+			Expect(isProcessAlive(deadPID)).To(BeFalse())
+			Expect(isProcessAlive(0)).To(BeFalse())
+		})
+
+		It("should remove entries whose process is gone", func() {
+			staleDir := filepath.Join(tempDir, "stale")
+			Expect(os.MkdirAll(staleDir, 0755)).To(Succeed())
+
+			Expect(manager.processCache.addProcess("alive", CloudProviderProcess{PID: os.Getpid(), ContextName: "alive"})).To(Succeed())
+			Expect(manager.processCache.addProcess("stale", CloudProviderProcess{PID: deadPID, ContextName: "stale", TempDir: staleDir})).To(Succeed())
+
+			pruned, err := manager.PruneStaleProcesses()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pruned).To(HaveLen(1))
+			Expect(pruned[0].ContextName).To(Equal("stale"))
+			Expect(staleDir).NotTo(BeADirectory())
+
+			_, exists := manager.processCache.getProcess("stale")
+			Expect(exists).To(BeFalse())
+			_, exists = manager.processCache.getProcess("alive")
+			Expect(exists).To(BeTrue())
+		})
+
+		It("should not report stale entries as existing processes", func() {
+			Expect(manager.processCache.addProcess("stale", CloudProviderProcess{PID: deadPID, ContextName: "stale"})).To(Succeed())
+
+			hasExisting, processes, err := manager.HasExistingProcesses()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasExisting).To(BeFalse())
+			Expect(processes).To(BeEmpty())
+		})
+	})
+
 	Describe("Integration Tests", func() {
 		Context("End-to-end checksum verification", func() {
 			It("should verify checksum for actual cloud-provider-kind binary", func() {
@@ -141,10 +347,10 @@ var _ = Describe("CloudProviderKindManager", func() {
 				// For now, we'll skip it to avoid network dependencies in unit tests
 				Skip("Skipping integration test to avoid network dependencies")
 
-				manager.SetTestVersion("0.8.0")
+				manager.SetVersion("0.8.0")
 
 				binaryPath := filepath.Join(tempDir, "cloud-provider-kind")
-				err := manager.downloadBinary(binaryPath)
+				err := manager.downloadBinary(binaryPath, "0.8.0")
 				Expect(err).NotTo(HaveOccurred())
 
 				// Verify file exists
@@ -0,0 +1,241 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+const (
+	// ClusterMeshAPIServerNodePort is the NodePort the clustermesh-apiserver of every meshed cluster listens on
+	ClusterMeshAPIServerNodePort = 32379
+	// maxClusterMeshID is the highest cluster ID handed out, every ID maps to its own 10.(200+ID).0.0/16 pod pool
+	maxClusterMeshID = 55
+)
+
+// ClusterMeshCluster is a cluster taking part in the Cilium cluster mesh
+type ClusterMeshCluster struct {
+	Name    string // kubeconfig context, also used as the Cilium cluster name
+	ID      int    // unique Cilium cluster ID
+	Address string // node IP the clustermesh-apiserver NodePort is reached on
+}
+
+// clusterMesh holds the settings shared by the Cilium installs of the meshed clusters
+type clusterMesh struct {
+	ids    map[string]int // cluster name -> cluster ID
+	caCert []byte         // PEM encoded CA shared by all clusters so they trust each other
+	caKey  []byte
+}
+
+// AssignClusterMeshIDs gives every cluster a unique Cilium cluster ID, keeping the IDs already assigned
+func AssignClusterMeshIDs(clusterNames []string, assigned map[string]int) (map[string]int, error) {
+	ids := make(map[string]int, len(clusterNames))
+	used := make(map[int]bool)
+	for _, name := range clusterNames {
+		if id, ok := assigned[name]; ok && id > 0 && !used[id] {
+			ids[name] = id
+			used[id] = true
+		}
+	}
+
+	nextID := 1
+	for _, name := range clusterNames {
+		if _, ok := ids[name]; ok {
+			continue
+		}
+		for used[nextID] {
+			nextID++
+		}
+		if nextID > maxClusterMeshID {
+			return nil, fmt.Errorf("no free cilium cluster ID left for %s", name)
+		}
+		ids[name] = nextID
+		used[nextID] = true
+	}
+	return ids, nil
+}
+
+// EnableClusterMesh makes the following Cilium installs join a cluster mesh under the given cluster IDs.
+// A CA shared by all clusters is generated so their clustermesh-apiservers trust each other
+func (cm *CiliumManager) EnableClusterMesh(clusterIDs map[string]int) error {
+	caCert, caKey, err := generateClusterMeshCA()
+	if err != nil {
+		return fmt.Errorf("failed to generate cluster mesh CA: %w", err)
+	}
+
+	cm.clusterMesh = &clusterMesh{
+		ids:    clusterIDs,
+		caCert: caCert,
+		caKey:  caKey,
+	}
+	return nil
+}
+
+// ConnectClusterMesh points the Cilium install of every cluster at the clustermesh-apiservers of the others.
+// The clusters have to be installed after EnableClusterMesh and their Cilium has to be ready
+func (cm *CiliumManager) ConnectClusterMesh(clusters []ClusterMeshCluster) error {
+	if cm.clusterMesh == nil {
+		return fmt.Errorf("cluster mesh is not enabled")
+	}
+
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("connecting %d clusters into a Cilium cluster mesh", len(clusters)))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	for _, cluster := range clusters {
+		values := cm.ciliumValues(cluster.Name, cluster.ID)
+		clusterMeshValues, ok := values["clustermesh"].(map[string]interface{})
+		if !ok {
+			status.End(false)
+			return fmt.Errorf("cluster %s has no cilium cluster ID", cluster.Name)
+		}
+		clusterMeshValues["config"] = map[string]interface{}{
+			"enabled":  true,
+			"clusters": clusterMeshPeers(cluster.Name, clusters),
+		}
+
+		if err := cm.helmManager.UpgradeChart(cluster.Name, "cilium", cm.chart, cm.chartVersion, "kube-system", values, cm.timeout); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to connect %s to the cluster mesh: %w", cluster.Name, err)
+		}
+		if err := cm.WaitForCiliumReady(cluster.Name); err != nil {
+			status.End(false)
+			return fmt.Errorf("cilium pods not ready on %s: %w", cluster.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// clusterMeshValues returns the Helm values making a cluster a member of the mesh, nil when it isn't one
+func (cm *CiliumManager) clusterMeshValues(clusterName string) map[string]interface{} {
+	if cm.clusterMesh == nil {
+		return nil
+	}
+	id, ok := cm.clusterMesh.ids[clusterName]
+	if !ok {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name": clusterName,
+			"id":   id,
+		},
+		// the pod CIDRs of meshed clusters must not overlap, so every cluster gets its own pool
+		"ipam": map[string]interface{}{
+			"operator": map[string]interface{}{
+				"clusterPoolIPv4PodCIDRList": []interface{}{fmt.Sprintf("10.%d.0.0/16", 200+id)},
+			},
+		},
+		"tls": map[string]interface{}{
+			"ca": map[string]interface{}{
+				"cert": base64.StdEncoding.EncodeToString(cm.clusterMesh.caCert),
+				"key":  base64.StdEncoding.EncodeToString(cm.clusterMesh.caKey),
+			},
+		},
+		"clustermesh": map[string]interface{}{
+			"useAPIServer": true,
+			"apiserver": map[string]interface{}{
+				"service": map[string]interface{}{
+					"type":     "NodePort",
+					"nodePort": ClusterMeshAPIServerNodePort,
+				},
+				"tls": map[string]interface{}{
+					"auto": map[string]interface{}{
+						"method": "helm",
+					},
+				},
+			},
+		},
+	}
+}
+
+// clusterMeshPeers lists the clusters a cluster connects to, every mesh member but itself
+func clusterMeshPeers(clusterName string, clusters []ClusterMeshCluster) []interface{} {
+	sorted := append([]ClusterMeshCluster(nil), clusters...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var peers []interface{}
+	for _, peer := range sorted {
+		if peer.Name == clusterName {
+			continue
+		}
+		peers = append(peers, map[string]interface{}{
+			"name": peer.Name,
+			"port": ClusterMeshAPIServerNodePort,
+			"ips":  []interface{}{peer.Address},
+		})
+	}
+	return peers
+}
+
+// generateClusterMeshCA creates a self-signed CA for the clustermesh certificates, returned PEM encoded
+func generateClusterMeshCA() ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Cilium CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(3, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
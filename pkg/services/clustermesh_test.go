@@ -0,0 +1,74 @@
+package services
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cilium cluster mesh", func() {
+	Describe("AssignClusterMeshIDs", func() {
+		It("should give every cluster a unique ID", func() {
+			ids, err := AssignClusterMeshIDs([]string{"myproject-1", "myproject-2", "myproject-3"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal(map[string]int{"myproject-1": 1, "myproject-2": 2, "myproject-3": 3}))
+		})
+
+		It("should keep the IDs already assigned", func() {
+			ids, err := AssignClusterMeshIDs([]string{"myproject-1", "myproject-2", "myproject-3"}, map[string]int{"myproject-2": 1, "old-1": 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal(map[string]int{"myproject-1": 2, "myproject-2": 1, "myproject-3": 3}))
+		})
+	})
+
+	Describe("Helm values", func() {
+		var ciliumManager *CiliumManager
+
+		BeforeEach(func() {
+			ciliumManager = NewCiliumManager(nil, nil)
+			Expect(ciliumManager.EnableClusterMesh(map[string]int{"myproject-1": 1, "myproject-2": 2})).To(Succeed())
+		})
+
+		It("should set the cluster name, ID and pod pool of mesh members", func() {
+			values := ciliumManager.ciliumValues("myproject-2", 2)
+			Expect(values).To(HaveKeyWithValue("cluster", map[string]interface{}{"name": "myproject-2", "id": 2}))
+			Expect(values).To(HaveKeyWithValue("kubeProxyReplacement", false))
+			Expect(values["ipam"]).To(HaveKeyWithValue("operator", HaveKeyWithValue("clusterPoolIPv4PodCIDRList", ConsistOf("10.202.0.0/16"))))
+			Expect(values["clustermesh"]).To(HaveKeyWithValue("useAPIServer", true))
+		})
+
+		It("should share one CA between the clusters", func() {
+			ca := ciliumManager.ciliumValues("myproject-1", 1)["tls"]
+			Expect(ciliumManager.ciliumValues("myproject-2", 2)["tls"]).To(Equal(ca))
+
+			certPEM, err := base64.StdEncoding.DecodeString(ca.(map[string]interface{})["ca"].(map[string]interface{})["cert"].(string))
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode(certPEM)
+			Expect(block).NotTo(BeNil())
+			cert, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert.IsCA).To(BeTrue())
+		})
+
+		It("should leave other clusters alone", func() {
+			Expect(ciliumManager.ciliumValues("other", 3)).NotTo(HaveKey("clustermesh"))
+			Expect(NewCiliumManager(nil, nil).ciliumValues("myproject-1", 1)).NotTo(HaveKey("clustermesh"))
+		})
+	})
+
+	It("should connect every cluster to all the others", func() {
+		clusters := []ClusterMeshCluster{
+			{Name: "myproject-2", ID: 2, Address: "10.89.0.3"},
+			{Name: "myproject-1", ID: 1, Address: "10.89.0.2"},
+			{Name: "myproject-3", ID: 3, Address: "10.89.0.4"},
+		}
+
+		Expect(clusterMeshPeers("myproject-2", clusters)).To(Equal([]interface{}{
+			map[string]interface{}{"name": "myproject-1", "port": ClusterMeshAPIServerNodePort, "ips": []interface{}{"10.89.0.2"}},
+			map[string]interface{}{"name": "myproject-3", "port": ClusterMeshAPIServerNodePort, "ips": []interface{}{"10.89.0.4"}},
+		}))
+	})
+})
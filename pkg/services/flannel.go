@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/github"
+	"github.com/day0ops/lok8s/pkg/util/k8s"
+)
+
+const (
+	// flannelManifestURL is the upstream flannel manifest
+	flannelManifestURL = "https://github.com/flannel-io/flannel/releases/latest/download/kube-flannel.yml"
+	// flannelDefaultNetwork is the pod network hardcoded in the upstream manifest
+	flannelDefaultNetwork = "10.244.0.0/16"
+	// flannelNamespace and flannelDaemonSet identify the flannel agents
+	flannelNamespace = "kube-flannel"
+	flannelDaemonSet = "kube-flannel-ds"
+)
+
+// FlannelManager manages Flannel installation and verification
+type FlannelManager struct {
+	githubClient *github.GitHubClient
+	timeout      time.Duration // readiness timeout for the flannel agents
+}
+
+// NewFlannelManager creates a new Flannel manager
+func NewFlannelManager() *FlannelManager {
+	return &FlannelManager{
+		githubClient: github.NewGitHubClient(),
+		timeout:      config.DefaultReadinessTimeout,
+	}
+}
+
+// SetReadinessTimeout overrides how long to wait for Flannel to become ready
+func (fm *FlannelManager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		fm.timeout = timeout
+	}
+}
+
+// InstallFlannel applies the flannel manifest to a Kind cluster using the given pod subnet
+func (fm *FlannelManager) InstallFlannel(contextName, podSubnet string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("installing Flannel on cluster %s", contextName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	manifest, err := fm.fetchManifest()
+	if err != nil {
+		status.End(false)
+		return err
+	}
+
+	// kindest/node images don't ship the bridge CNI plugin, so delegate to ptp instead
+	manifest, err = renderFlannelManifest(manifest, podSubnet, true)
+	if err != nil {
+		status.End(false)
+		return err
+	}
+
+	clientManager, err := k8s.NewClientManagerForContext(contextName)
+	if err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+	}
+
+	if err := clientManager.ApplyManifest(manifest); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to apply flannel manifest: %w", err)
+	}
+
+	if err := fm.waitForFlannelReady(clientManager, contextName); err != nil {
+		status.End(false)
+		return fmt.Errorf("flannel pods not ready: %w", err)
+	}
+
+	return nil
+}
+
+// waitForFlannelReady waits for the flannel DaemonSet to be ready
+func (fm *FlannelManager) waitForFlannelReady(clientManager *k8s.ClientManager, contextName string) error {
+	logger.Debugf("waiting for Flannel to be ready on cluster %s", contextName)
+
+	deadline := time.Now().Add(fm.timeout)
+	for time.Now().Before(deadline) {
+		err := clientManager.CheckDaemonSetReady(flannelNamespace, flannelDaemonSet)
+		if err == nil {
+			return nil
+		}
+		logger.Debugf("Flannel status: %v", err)
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timeout waiting for Flannel to be ready on cluster %s", contextName)
+}
+
+// GenerateFlannelManifest writes the flannel manifest for the given pod subnet to a file
+// returns the path to the generated manifest file
+func (fm *FlannelManager) GenerateFlannelManifest(clusterName, podSubnet string) (string, error) {
+	logger.Debugf("generating Flannel manifest for cluster %s", clusterName)
+
+	manifest, err := fm.fetchManifest()
+	if err != nil {
+		return "", err
+	}
+
+	manifest, err = renderFlannelManifest(manifest, podSubnet, false)
+	if err != nil {
+		return "", err
+	}
+
+	manifestPath, err := config.ArtifactPath(fmt.Sprintf("flannel-%s-manifest.yaml", clusterName))
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		return "", fmt.Errorf("failed to write Flannel manifest to file: %w", err)
+	}
+
+	logger.Debugf("generated Flannel manifest file: %s", manifestPath)
+	return manifestPath, nil
+}
+
+// fetchManifest downloads the upstream flannel manifest
+func (fm *FlannelManager) fetchManifest() (string, error) {
+	resp, err := fm.githubClient.Get(flannelManifestURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch flannel manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch flannel manifest: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flannel manifest: %w", err)
+	}
+	return string(body), nil
+}
+
+// renderFlannelManifest points the flannel network at the pod subnet and, when ptpDelegate is set,
+// makes the flannel CNI plugin delegate to ptp rather than bridge
+func renderFlannelManifest(manifest, podSubnet string, ptpDelegate bool) (string, error) {
+	if podSubnet != "" && podSubnet != flannelDefaultNetwork {
+		network := fmt.Sprintf(`"Network": "%s"`, flannelDefaultNetwork)
+		if !strings.Contains(manifest, network) {
+			return "", fmt.Errorf("flannel manifest does not define the default network %s", flannelDefaultNetwork)
+		}
+		manifest = strings.ReplaceAll(manifest, network, fmt.Sprintf(`"Network": "%s"`, podSubnet))
+	}
+
+	if ptpDelegate {
+		delegate := `"delegate": {`
+		if strings.Count(manifest, delegate) != 1 {
+			return "", fmt.Errorf("flannel manifest has an unexpected CNI delegate configuration")
+		}
+		manifest = strings.Replace(manifest, delegate, delegate+"\n            \"type\": \"ptp\",", 1)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlannelManager", func() {
+	const manifest = `kind: ConfigMap
+data:
+  cni-conf.json: |
+    {
+      "name": "cbr0",
+      "plugins": [
+        {
+          "type": "flannel",
+          "delegate": {
+            "hairpinMode": true,
+            "isDefaultGateway": true
+          }
+        }
+      ]
+    }
+  net-conf.json: |
+    {
+      "Network": "10.244.0.0/16",
+      "Backend": {
+        "Type": "vxlan"
+      }
+    }
+`
+
+	Describe("Manifest rendering", func() {
+		It("should point the flannel network at the pod subnet", func() {
+			rendered, err := renderFlannelManifest(manifest, "10.100.0.0/16", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(ContainSubstring(`"Network": "10.100.0.0/16"`))
+			Expect(rendered).NotTo(ContainSubstring(flannelDefaultNetwork))
+			Expect(rendered).NotTo(ContainSubstring(`"type": "ptp"`))
+		})
+
+		It("should leave the manifest untouched for the default network", func() {
+			rendered, err := renderFlannelManifest(manifest, "", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(Equal(manifest))
+		})
+
+		It("should delegate to ptp when requested", func() {
+			rendered, err := renderFlannelManifest(manifest, flannelDefaultNetwork, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(ContainSubstring("\"delegate\": {\n            \"type\": \"ptp\","))
+		})
+
+		It("should fail when the manifest layout changed", func() {
+			_, err := renderFlannelManifest("kind: ConfigMap\n", "10.100.0.0/16", false)
+			Expect(err).To(HaveOccurred())
+
+			_, err = renderFlannelManifest("kind: ConfigMap\n", "", true)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
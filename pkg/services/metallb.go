@@ -26,8 +26,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,21 +40,38 @@ import (
 	"github.com/day0ops/lok8s/pkg/util/k8s"
 )
 
+// metallbRepoChart is the chart installed unless a local chart is set
+const metallbRepoChart = "metallb/metallb"
+
 // MetalLBManager manages MetalLB installation and configuration
 type MetalLBManager struct {
 	helmManager   *helm.HelmManager
 	minOctetRange int
 	maxOctetRange int
-	configManager *config.ConfigManager
-	ipAllocations map[string]*config.MetalLBAllocation // in-memory tracking during cluster creation
-	usedRanges    map[string]bool                      // tracks used IP ranges (ipPrefix.start-end)
-	allNodeIPs    map[int]bool                         // tracks all node IPs across clusters
+	ipsPerCluster int
+	timeout       time.Duration   // readiness timeout for the chart install and pod waits
+	chart         string          // repo chart ref, or a local chart directory or archive
+	chartVersion  string          // pinned metallb chart version, empty for the latest
+	bgpPeer       *MetalLBBGPPeer // router to advertise the pools to over BGP, nil for L2 advertisement
+	ipRanges      []string        // exact pool of each cluster by cluster number, generated when empty
+	subnet        string          // CIDR of the cluster network the given ranges have to be in
+	project       string          // project being created, set by InitializeTracking
+	// project of each cluster whose allocation was loaded from the saved configs
+	allocationOwners map[string]string
+	configManager    *config.ConfigManager
+	ipAllocations    map[string]*config.MetalLBAllocation // in-memory tracking during cluster creation
+	usedRanges       map[string]bool                      // tracks used IP ranges (ipPrefix.start-end)
+	allNodeIPs       map[int]bool                         // tracks all node IPs across clusters
+	mu               sync.Mutex                           // guards ipsPerCluster, the tracking maps and the allocation config writes
 }
 
 // NewMetalLBManager creates a new MetalLB manager
 func NewMetalLBManager(helmManager *helm.HelmManager) *MetalLBManager {
 	return &MetalLBManager{
 		helmManager:   helmManager,
+		ipsPerCluster: config.MetalLBDefaultIPsPerCluster,
+		timeout:       config.DefaultReadinessTimeout,
+		chart:         metallbRepoChart,
 		configManager: config.NewConfigManager(),
 		ipAllocations: make(map[string]*config.MetalLBAllocation),
 		usedRanges:    make(map[string]bool),
@@ -60,11 +79,18 @@ func NewMetalLBManager(helmManager *helm.HelmManager) *MetalLBManager {
 	}
 }
 
-func NewMetalLBManagerWithOptions(helmManager *helm.HelmManager, minOctetRange, maxOctetRange int) *MetalLBManager {
+// NewMetalLBManagerWithOptions creates a new MetalLB manager with a custom octet range and pool size
+func NewMetalLBManagerWithOptions(helmManager *helm.HelmManager, minOctetRange, maxOctetRange, ipsPerCluster int) *MetalLBManager {
+	if ipsPerCluster <= 0 {
+		ipsPerCluster = config.MetalLBDefaultIPsPerCluster
+	}
 	return &MetalLBManager{
 		helmManager:   helmManager,
 		minOctetRange: minOctetRange,
 		maxOctetRange: maxOctetRange,
+		ipsPerCluster: ipsPerCluster,
+		timeout:       config.DefaultReadinessTimeout,
+		chart:         metallbRepoChart,
 		configManager: config.NewConfigManager(),
 		ipAllocations: make(map[string]*config.MetalLBAllocation),
 		usedRanges:    make(map[string]bool),
@@ -72,11 +98,95 @@ func NewMetalLBManagerWithOptions(helmManager *helm.HelmManager, minOctetRange,
 	}
 }
 
+// SetIPsPerCluster overrides the number of IPs allocated to each cluster's pool
+func (mm *MetalLBManager) SetIPsPerCluster(ipsPerCluster int) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	if ipsPerCluster > 0 {
+		mm.ipsPerCluster = ipsPerCluster
+	}
+}
+
+// SetReadinessTimeout overrides how long to wait for MetalLB to become ready
+func (mm *MetalLBManager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		mm.timeout = timeout
+	}
+}
+
+// SetChartVersion pins the MetalLB chart version, an empty version installs the latest chart
+func (mm *MetalLBManager) SetChartVersion(version string) {
+	mm.chartVersion = version
+}
+
+// SetChart installs MetalLB from a local chart directory or archive, an empty path keeps the repo chart
+func (mm *MetalLBManager) SetChart(path string) {
+	if path == "" {
+		path = metallbRepoChart
+	}
+	mm.chart = path
+}
+
+// SetSubnet sets the CIDR of the docker or libvirt network the clusters are on, the IP ranges given with
+// SetIPRanges have to be inside it. Without one they have to be in the /24 of the cluster IP
+func (mm *MetalLBManager) SetSubnet(subnetCIDR string) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.subnet = subnetCIDR
+}
+
+// SetIPRanges pins the pool of each cluster to the given x.x.x.start-x.x.x.end range instead of generating one,
+// the first range goes to cluster number 1
+func (mm *MetalLBManager) SetIPRanges(ranges []string) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.ipRanges = ranges
+}
+
+// explicitIPRange returns the range given for the cluster number, empty when it should be generated.
+// The caller must hold mm.mu
+func (mm *MetalLBManager) explicitIPRange(clusterNumber int) string {
+	if clusterNumber < 1 || clusterNumber > len(mm.ipRanges) {
+		return ""
+	}
+	return mm.ipRanges[clusterNumber-1]
+}
+
+// MetalLBBGPPeer is the router MetalLB peers with to advertise the service IPs in BGP mode
+type MetalLBBGPPeer struct {
+	Address string
+	ASN     int // the peer's ASN
+	MyASN   int // the ASN MetalLB speaks as
+}
+
+// SetBGPPeer switches MetalLB to BGP advertisement to the peer, a nil peer keeps the default L2 advertisement.
+// ASNs left at zero use the defaults
+func (mm *MetalLBManager) SetBGPPeer(peer *MetalLBBGPPeer) {
+	if peer != nil {
+		peer = &MetalLBBGPPeer{Address: peer.Address, ASN: peer.ASN, MyASN: peer.MyASN}
+		if peer.ASN <= 0 {
+			peer.ASN = config.MetalLBDefaultPeerASN
+		}
+		if peer.MyASN <= 0 {
+			peer.MyASN = config.MetalLBDefaultASN
+		}
+	}
+	mm.bgpPeer = peer
+}
+
 // InitializeTracking initializes IP tracking from saved config or starts fresh
 // Loads allocations from ALL projects to avoid IP range overlaps across projects
 func (mm *MetalLBManager) InitializeTracking(project string) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
 	// clear existing tracking
+	mm.project = project
 	mm.ipAllocations = make(map[string]*config.MetalLBAllocation)
+	mm.allocationOwners = make(map[string]string)
 	mm.usedRanges = make(map[string]bool)
 	mm.allNodeIPs = make(map[int]bool)
 
@@ -99,6 +209,7 @@ func (mm *MetalLBManager) InitializeTracking(project string) error {
 		if projectConfig != nil && len(projectConfig.MetalLBAllocations) > 0 {
 			for _, alloc := range projectConfig.MetalLBAllocations {
 				mm.ipAllocations[alloc.ClusterName] = &alloc
+				mm.allocationOwners[alloc.ClusterName] = proj
 				// track used ranges (format: ipPrefix.start-end)
 				rangeKey := fmt.Sprintf("%s.%d-%d", alloc.IPPrefix, alloc.StartOctet, alloc.EndOctet)
 				mm.usedRanges[rangeKey] = true
@@ -115,8 +226,12 @@ func (mm *MetalLBManager) InitializeTracking(project string) error {
 	return nil
 }
 
-// SaveAllocation saves the IP allocation for a cluster to the project config
+// SaveAllocation saves the IP allocation for a cluster to the project config. The load and save of the
+// config happen under the lock so concurrent saves for the same project don't drop each other's allocations
 func (mm *MetalLBManager) SaveAllocation(project string, allocation *config.MetalLBAllocation) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
 	// load existing config
 	projectConfig, err := mm.configManager.LoadConfig(project)
 	if err != nil {
@@ -148,16 +263,67 @@ func (mm *MetalLBManager) SaveAllocation(project string, allocation *config.Meta
 		return fmt.Errorf("failed to save project config: %w", err)
 	}
 
-	// update in-memory tracking
+	mm.trackAllocation(allocation)
+
+	logger.Debugf("saved MetalLB allocation for cluster %s: %s", allocation.ClusterName, allocation.IPRange)
+	return nil
+}
+
+// ReleaseAllocation removes the IP allocation of a cluster from the project config and frees its range, so the
+// range can be handed out again when the cluster is recreated
+func (mm *MetalLBManager) ReleaseAllocation(project, clusterName string) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	projectConfig, err := mm.configManager.LoadConfig(project)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	if projectConfig != nil {
+		allocations := projectConfig.MetalLBAllocations[:0]
+		for _, existing := range projectConfig.MetalLBAllocations {
+			if existing.ClusterName != clusterName {
+				allocations = append(allocations, existing)
+			}
+		}
+
+		if len(allocations) != len(projectConfig.MetalLBAllocations) {
+			projectConfig.MetalLBAllocations = allocations
+			if err := mm.configManager.SaveConfig(project, projectConfig); err != nil {
+				return fmt.Errorf("failed to save project config: %w", err)
+			}
+			logger.Debugf("released MetalLB allocation for cluster %s", clusterName)
+		}
+	}
+
+	mm.untrackAllocation(clusterName)
+	return nil
+}
+
+// untrackAllocation drops a cluster's allocation from the in-memory tracking, rebuilding the used ranges and
+// node IPs from the remaining allocations since those can be shared. The caller must hold mm.mu
+func (mm *MetalLBManager) untrackAllocation(clusterName string) {
+	if _, ok := mm.ipAllocations[clusterName]; !ok {
+		return
+	}
+	delete(mm.ipAllocations, clusterName)
+
+	mm.usedRanges = make(map[string]bool)
+	mm.allNodeIPs = make(map[int]bool)
+	for _, allocation := range mm.ipAllocations {
+		mm.trackAllocation(allocation)
+	}
+}
+
+// trackAllocation records an allocation in the in-memory tracking, the caller must hold mm.mu
+func (mm *MetalLBManager) trackAllocation(allocation *config.MetalLBAllocation) {
 	mm.ipAllocations[allocation.ClusterName] = allocation
 	rangeKey := fmt.Sprintf("%s.%d-%d", allocation.IPPrefix, allocation.StartOctet, allocation.EndOctet)
 	mm.usedRanges[rangeKey] = true
 	for _, nodeIP := range allocation.NodeIPs {
 		mm.allNodeIPs[nodeIP] = true
 	}
-
-	logger.Debugf("saved MetalLB allocation for cluster %s: %s", allocation.ClusterName, allocation.IPRange)
-	return nil
 }
 
 // InstallMetalLB installs MetalLB using Helm
@@ -170,10 +336,12 @@ func (mm *MetalLBManager) InstallMetalLB(clusterName string) error {
 		}
 	}()
 
-	// add metallb repository
-	if err := mm.helmManager.AddRepository("metallb", "https://metallb.github.io/metallb"); err != nil {
-		status.End(false)
-		return fmt.Errorf("failed to add metallb repository: %w", err)
+	// add metallb repository, a local chart is installed without it
+	if mm.chart == metallbRepoChart {
+		if err := mm.helmManager.AddRepository("metallb", "https://metallb.github.io/metallb"); err != nil {
+			status.End(false)
+			return fmt.Errorf("failed to add metallb repository: %w", err)
+		}
 	}
 
 	// install metallb chart
@@ -212,7 +380,14 @@ func (mm *MetalLBManager) InstallMetalLB(clusterName string) error {
 		},
 	}
 
-	if err := mm.helmManager.InstallChart("metallb", "metallb/metallb", "metallb-system", values, 5*time.Minute); err != nil {
+	// BGP sessions are run by the FRR container of the speaker
+	if mm.bgpPeer != nil {
+		values["speaker"].(map[string]interface{})["frr"] = map[string]interface{}{
+			"enabled": true,
+		}
+	}
+
+	if err := mm.helmManager.InstallChart(clusterName, "metallb", mm.chart, mm.chartVersion, "metallb-system", values, mm.timeout); err != nil {
 		status.End(false)
 		return fmt.Errorf("failed to install metallb chart: %w", err)
 	}
@@ -227,6 +402,65 @@ func (mm *MetalLBManager) InstallMetalLB(clusterName string) error {
 	return nil
 }
 
+// metallbPoolTemplate is the IP address pool applied to each cluster, filled in with the IP range
+const metallbPoolTemplate = `
+apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: default-pool
+  namespace: metallb-system
+spec:
+  addresses:
+  - %s
+`
+
+// metallbL2Template advertises the pool over L2 (ARP/NDP)
+const metallbL2Template = `
+apiVersion: metallb.io/v1beta1
+kind: L2Advertisement
+metadata:
+  name: default-l2
+  namespace: metallb-system
+spec:
+  ipAddressPools:
+  - default-pool
+`
+
+// metallbBGPTemplate peers with a router and advertises the pool to it over BGP, filled in with MetalLB's ASN,
+// the peer ASN and the peer address
+const metallbBGPTemplate = `
+apiVersion: metallb.io/v1beta2
+kind: BGPPeer
+metadata:
+  name: default-peer
+  namespace: metallb-system
+spec:
+  myASN: %d
+  peerASN: %d
+  peerAddress: %s
+---
+apiVersion: metallb.io/v1beta1
+kind: BGPAdvertisement
+metadata:
+  name: default-bgp
+  namespace: metallb-system
+spec:
+  ipAddressPools:
+  - default-pool
+`
+
+// metallbManifests returns the manifest of the pool and the advertisement of the configured mode, and the
+// advertisement of the other mode so it can be removed when a cluster switches modes
+func (mm *MetalLBManager) metallbManifests(ipRange string) (string, string) {
+	pool := fmt.Sprintf(metallbPoolTemplate, ipRange)
+	if mm.bgpPeer != nil {
+		bgp := fmt.Sprintf(metallbBGPTemplate, mm.bgpPeer.MyASN, mm.bgpPeer.ASN, mm.bgpPeer.Address)
+		return pool + "---" + bgp, metallbL2Template
+	}
+	// without a peer the BGP resources are only named, which is all deleting them needs
+	return pool + "---" + metallbL2Template, fmt.Sprintf(metallbBGPTemplate, 0, 0, "")
+}
+
 // ConfigureMetalLB configures MetalLB with IP address pool
 func (mm *MetalLBManager) ConfigureMetalLB(clusterName, minikubeIp string, clusterNumber int, totalClusters int, project string) error {
 	status := logger.NewStatus()
@@ -244,8 +478,20 @@ func (mm *MetalLBManager) ConfigureMetalLB(clusterName, minikubeIp string, clust
 		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
 	}
 
-	// generate dynamic IP range based on cluster network and number
-	ipRange, allocation, err := mm.generateMetalLBIPRange(clusterName, minikubeIp, clusterNumber, totalClusters, clientManager)
+	// generate dynamic IP range based on cluster network and number, or take the one given for the cluster,
+	// tracking it straight away so a cluster configured in parallel can't be handed the same range
+	mm.mu.Lock()
+	var ipRange string
+	var allocation *config.MetalLBAllocation
+	if explicit := mm.explicitIPRange(clusterNumber); explicit != "" {
+		ipRange, allocation, err = mm.explicitMetalLBIPRange(clusterName, minikubeIp, explicit, clientManager)
+	} else {
+		ipRange, allocation, err = mm.generateMetalLBIPRange(clusterName, minikubeIp, clusterNumber, totalClusters, clientManager)
+	}
+	if err == nil {
+		mm.trackAllocation(allocation)
+	}
+	mm.mu.Unlock()
 	if err != nil {
 		status.End(false)
 		return fmt.Errorf("failed to generate MetalLB IP range: %w", err)
@@ -253,32 +499,17 @@ func (mm *MetalLBManager) ConfigureMetalLB(clusterName, minikubeIp string, clust
 
 	logger.Debugf("using MetalLB IP range: %s", ipRange)
 
-	// create IP address pool
-	ipPool := fmt.Sprintf(`
-apiVersion: metallb.io/v1beta1
-kind: IPAddressPool
-metadata:
-  name: default-pool
-  namespace: metallb-system
-spec:
-  addresses:
-  - %s
----
-apiVersion: metallb.io/v1beta1
-kind: L2Advertisement
-metadata:
-  name: default-l2
-  namespace: metallb-system
-spec:
-  ipAddressPools:
-  - default-pool
-`, ipRange)
+	// create IP address pool and its advertisement
+	manifest, stale := mm.metallbManifests(ipRange)
 
-	// apply the configuration using client manager
-	if err := clientManager.ApplyManifest(ipPool); err != nil {
+	// server-side apply so reconfiguring a cluster updates its pool and advertisement in place
+	if err := clientManager.ApplyManifestSSA(manifest); err != nil {
 		status.End(false)
 		return fmt.Errorf("failed to apply metallb configuration: %w", err)
 	}
+	if err := clientManager.DeleteManifest(stale); err != nil {
+		logger.Warnf("failed to remove the MetalLB advertisement of the other mode: %v", err)
+	}
 
 	// save allocation to config
 	if project != "" {
@@ -291,77 +522,150 @@ spec:
 	return nil
 }
 
-// WaitForMetalLBReady waits for MetalLB to be ready
-func (mm *MetalLBManager) WaitForMetalLBReady(clusterName string) error {
-	client, err := mm.helmManager.GetKubernetesClient()
+// ReconcileNodeIPs records a cluster's current node IPs in its MetalLB allocation after its node count changed.
+// When a new node took an IP inside the address pool, the pool is moved to a free range. Clusters without an
+// allocation are left alone
+func (mm *MetalLBManager) ReconcileNodeIPs(clusterName, minikubeIp string, clusterNumber int, totalClusters int, project string) error {
+	mm.mu.Lock()
+	allocation, ok := mm.ipAllocations[clusterName]
+	mm.mu.Unlock()
+	if !ok {
+		logger.Debugf("no MetalLB allocation for cluster %s, nothing to reconcile", clusterName)
+		return nil
+	}
+
+	clientManager, err := k8s.NewClientManagerForContext(clusterName)
 	if err != nil {
-		return fmt.Errorf("failed to get kubernetes client: %w", err)
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
 	}
 
-	ctx := context.Background()
-	timeout := 5 * time.Minute
-	deadline := time.Now().Add(timeout)
+	nodeIPs, err := mm.getNodeIPs(clientManager, allocation.IPPrefix)
+	if err != nil {
+		return err
+	}
 
-	logger.Debugf("waiting for MetalLB controller and speaker pods to be ready...")
+	if !rangeHasNodeIP(allocation, nodeIPs) {
+		updated := *allocation
+		updated.NodeIPs = sortedOctets(nodeIPs)
+		return mm.SaveAllocation(project, &updated)
+	}
 
-	for time.Now().Before(deadline) {
-		// check metallb controller deployment
-		deployments, err := client.AppsV1().Deployments("metallb-system").List(ctx, metav1.ListOptions{
-			LabelSelector: "app.kubernetes.io/name=metallb,app.kubernetes.io/component=controller",
-		})
-		if err != nil {
-			logger.Debugf("failed to list metallb controller deployments: %v", err)
-			time.Sleep(10 * time.Second)
-			continue
-		}
+	// a range the user gave is kept, they chose it
+	mm.mu.Lock()
+	explicit := mm.explicitIPRange(clusterNumber)
+	mm.mu.Unlock()
+	if explicit != "" {
+		logger.Warnf("⚠️ a node of cluster %s has an IP inside its MetalLB range %s, services can be handed an address already in use", clusterName, explicit)
+		return nil
+	}
 
-		// check metallb speaker daemonset
-		daemonsets, err := client.AppsV1().DaemonSets("metallb-system").List(ctx, metav1.ListOptions{
-			LabelSelector: "app.kubernetes.io/name=metallb,app.kubernetes.io/component=speaker",
-		})
-		if err != nil {
-			logger.Debugf("failed to list metallb speaker daemonsets: %v", err)
-			time.Sleep(10 * time.Second)
-			continue
-		}
+	// addresses already handed out from the old pool keep working until their services are recreated
+	logger.Warnf("⚠️ a node of cluster %s has an IP inside its MetalLB range %s, moving the address pool", clusterName, allocation.IPRange)
+	mm.mu.Lock()
+	mm.untrackAllocation(clusterName)
+	mm.mu.Unlock()
 
-		// check controller readiness
-		controllerReady := false
-		if len(deployments.Items) > 0 {
-			deployment := deployments.Items[0]
-			if deployment.Status.ReadyReplicas > 0 && deployment.Status.ReadyReplicas == *deployment.Spec.Replicas {
-				controllerReady = true
-			}
-		}
+	return mm.ConfigureMetalLB(clusterName, minikubeIp, clusterNumber, totalClusters, project)
+}
 
-		// check speaker readiness
-		speakerReady := false
-		if len(daemonsets.Items) > 0 {
-			ds := daemonsets.Items[0]
-			if ds.Status.DesiredNumberScheduled > 0 && ds.Status.NumberReady == ds.Status.DesiredNumberScheduled {
-				speakerReady = true
-			}
+// rangeHasNodeIP reports whether any of the node IP last octets falls inside the allocation's range
+func rangeHasNodeIP(allocation *config.MetalLBAllocation, nodeIPs map[int]bool) bool {
+	for octet := range nodeIPs {
+		if octet >= allocation.StartOctet && octet <= allocation.EndOctet {
+			return true
 		}
+	}
+	return false
+}
 
-		logger.Debugf("MetalLB status - controller: %v, speaker: %v",
-			controllerReady, speakerReady)
+// sortedOctets returns the octets of the set in ascending order
+func sortedOctets(octets map[int]bool) []int {
+	sorted := make([]int, 0, len(octets))
+	for octet := range octets {
+		sorted = append(sorted, octet)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
 
-		// all components ready
-		if controllerReady && speakerReady {
-			logger.Debugf("MetalLB is ready on cluster %s", clusterName)
-			return nil
+// Uninstall removes the MetalLB address pool, L2 advertisement and Helm release from a cluster
+func (mm *MetalLBManager) Uninstall(contextName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("uninstalling MetalLB from cluster %s", contextName))
+	defer func() {
+		if status != nil {
+			status.End(true)
 		}
+	}()
+
+	// the pool and advertisement go first, they can't be deleted once the MetalLB webhook is gone
+	clientManager, err := k8s.NewClientManagerForContext(contextName)
+	if err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to create kubernetes client manager: %w", err)
+	}
+	// whichever mode the cluster was configured with
+	manifest, stale := mm.metallbManifests("")
+	if err := clientManager.DeleteManifest(manifest + "---" + stale); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to delete metallb configuration: %w", err)
+	}
+
+	if err := uninstallRelease(mm.helmManager, contextName, "metallb", "metallb-system"); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to uninstall metallb chart: %w", err)
+	}
+
+	// Success - status.End(true) will be called by defer
+	return nil
+}
+
+// uninstallRelease uninstalls a Helm release from the cluster of the given context, doing nothing when it isn't installed
+func uninstallRelease(helmManager *helm.HelmManager, contextName, releaseName, namespace string) error {
+	exists, err := helmManager.ReleaseExists(contextName, releaseName, namespace)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logger.Debugf("Helm release %s not found in namespace %s, skipping", releaseName, namespace)
+		return nil
+	}
+	return helmManager.UninstallChart(contextName, releaseName, namespace)
+}
+
+// WaitForMetalLBReady waits for MetalLB to be ready
+func (mm *MetalLBManager) WaitForMetalLBReady(clusterName string) error {
+	client, err := mm.helmManager.GetKubernetesClient(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+
+	// the controller and speaker share the timeout
+	deadline := time.Now().Add(mm.timeout)
 
-		time.Sleep(10 * time.Second)
+	logger.Debugf("waiting for MetalLB controller and speaker pods to be ready...")
+
+	if err := k8s.WaitForDeploymentReady(client, "metallb-system", "app.kubernetes.io/name=metallb,app.kubernetes.io/component=controller", mm.timeout); err != nil {
+		return fmt.Errorf("MetalLB controller is not ready on cluster %s: %w", clusterName, err)
+	}
+	if err := k8s.WaitForDaemonSetReady(client, "metallb-system", "app.kubernetes.io/name=metallb,app.kubernetes.io/component=speaker", time.Until(deadline)); err != nil {
+		return fmt.Errorf("MetalLB speaker is not ready on cluster %s: %w", clusterName, err)
 	}
 
-	return fmt.Errorf("timeout waiting for MetalLB to be ready on cluster %s", clusterName)
+	logger.Debugf("MetalLB is ready on cluster %s", clusterName)
+	return nil
 }
 
 // generateMetalLBIPRange generates a dynamic IP range for MetalLB based on cluster network and number
 // Uses the first 3 octets from minikubeIP and splits the last octet range between clusters
-// Allocates 20 IPs per cluster and avoids overlap with node IPs and previously used ranges
+// Allocates ipsPerCluster IPs per cluster and avoids overlap with node IPs and previously used ranges
+// The caller must hold mm.mu
 func (mm *MetalLBManager) generateMetalLBIPRange(clusterName, minikubeIP string, clusterNumber, totalClusters int, clientManager *k8s.ClientManager) (string, *config.MetalLBAllocation, error) {
+	// pools are allocated by last octet, so only IPv4 networks are supported
+	if ip := net.ParseIP(minikubeIP); ip != nil && ip.To4() == nil {
+		return "", nil, fmt.Errorf("MetalLB IP ranges can only be generated from an IPv4 address, got %s", minikubeIP)
+	}
+
 	// extract first 3 octets from minikubeIP (x.x.x)
 	ipParts := strings.Split(minikubeIP, ".")
 	if len(ipParts) < 3 {
@@ -370,12 +674,19 @@ func (mm *MetalLBManager) generateMetalLBIPRange(clusterName, minikubeIP string,
 	ipPrefix := fmt.Sprintf("%s.%s.%s", ipParts[0], ipParts[1], ipParts[2])
 
 	// get node IPs from current cluster
-	currentNodeIPs, err := mm.getNodeIPs(clientManager)
+	currentNodeIPs, err := mm.getNodeIPs(clientManager, ipPrefix)
 	if err != nil {
 		logger.Warnf("failed to get node IPs, continuing without overlap check: %v", err)
 		currentNodeIPs = make(map[int]bool)
 	}
 
+	return mm.allocateMetalLBIPRange(clusterName, ipPrefix, clusterNumber, totalClusters, currentNodeIPs)
+}
+
+// allocateMetalLBIPRange picks the range of the cluster in the ipPrefix network, away from the node IPs and from
+// the ranges of every tracked allocation, including those of other projects on the same network.
+// The caller must hold mm.mu
+func (mm *MetalLBManager) allocateMetalLBIPRange(clusterName, ipPrefix string, clusterNumber, totalClusters int, currentNodeIPs map[int]bool) (string, *config.MetalLBAllocation, error) {
 	// merge with all previously tracked node IPs
 	combinedNodeIPs := make(map[int]bool)
 	for octet := range mm.allNodeIPs {
@@ -388,12 +699,15 @@ func (mm *MetalLBManager) generateMetalLBIPRange(clusterName, minikubeIP string,
 	// calculate available IP range
 	// use minOctetRange to maxOctetRange (e.g., 200-254 = 55 IPs)
 	totalAvailableIPs := mm.maxOctetRange - mm.minOctetRange + 1
-	ipsPerCluster := 20
+	ipsPerCluster := mm.ipsPerCluster
+	if ipsPerCluster <= 0 {
+		ipsPerCluster = config.MetalLBDefaultIPsPerCluster
+	}
 
 	// calculate how many clusters we can fit
 	maxClusters := totalAvailableIPs / ipsPerCluster
 	if totalClusters > maxClusters {
-		return "", nil, fmt.Errorf("not enough IPs available: need %d clusters but only %d can fit in range %d-%d (20 IPs per cluster)", totalClusters, maxClusters, mm.minOctetRange, mm.maxOctetRange)
+		return "", nil, fmt.Errorf("not enough IPs available: need %d clusters but only %d can fit in range %d-%d (%d IPs per cluster)", totalClusters, maxClusters, mm.minOctetRange, mm.maxOctetRange, ipsPerCluster)
 	}
 
 	// calculate start octet for this cluster
@@ -405,14 +719,9 @@ func (mm *MetalLBManager) generateMetalLBIPRange(clusterName, minikubeIP string,
 		endOctet = mm.maxOctetRange
 	}
 
-	// check if this range overlaps with any existing ranges for the same IP prefix
-	if mm.hasRangeOverlap(ipPrefix, startOctet, endOctet) {
-		// find next available range
-		startOctet, endOctet = mm.findNextAvailableRange(startOctet, endOctet, ipsPerCluster, combinedNodeIPs, ipPrefix)
-	}
-
-	// filter out node IPs from the range
-	startOctet, endOctet = mm.adjustRangeForNodeIPs(startOctet, endOctet, combinedNodeIPs, ipPrefix)
+	// move the range off existing ranges for the same IP prefix and node IPs together, so stepping over a
+	// node IP can't land it on another cluster's range
+	startOctet, endOctet = mm.findNextAvailableRange(startOctet, endOctet, ipsPerCluster, combinedNodeIPs, ipPrefix)
 
 	// build IP range string (recalculate rangeKey after adjustments)
 	ipRange := fmt.Sprintf("%s.%d-%s.%d", ipPrefix, startOctet, ipPrefix, endOctet)
@@ -437,8 +746,71 @@ func (mm *MetalLBManager) generateMetalLBIPRange(clusterName, minikubeIP string,
 	return ipRange, allocation, nil
 }
 
+// explicitMetalLBIPRange records an allocation for a range given by the user rather than generated. The range has
+// to be in the network of the cluster, see clusterSubnet. Overlaps with node IPs or other pools are only warned
+// about, the user asked for this exact range. The caller must hold mm.mu
+func (mm *MetalLBManager) explicitMetalLBIPRange(clusterName, minikubeIP, ipRange string, clientManager *k8s.ClientManager) (string, *config.MetalLBAllocation, error) {
+	prefix, startOctet, endOctet, err := config.ParseMetalLBIPRange(ipRange)
+	if err != nil {
+		return "", nil, err
+	}
+	ip := net.ParseIP(minikubeIP).To4()
+	if ip == nil {
+		return "", nil, fmt.Errorf("MetalLB IP ranges need an IPv4 cluster network, got %s", minikubeIP)
+	}
+	subnet := clusterSubnet(mm.subnet, ip)
+	first, last, _ := strings.Cut(ipRange, "-")
+	if !subnet.Contains(net.ParseIP(first)) || !subnet.Contains(net.ParseIP(last)) {
+		return "", nil, fmt.Errorf("MetalLB IP range %s is outside the network %s of cluster %s", ipRange, subnet, clusterName)
+	}
+
+	nodeIPs, err := mm.getNodeIPs(clientManager, prefix)
+	if err != nil {
+		logger.Warnf("failed to get node IPs, continuing without overlap check: %v", err)
+		nodeIPs = make(map[int]bool)
+	}
+	allocation := &config.MetalLBAllocation{
+		ClusterName: clusterName,
+		IPPrefix:    prefix,
+		StartOctet:  startOctet,
+		EndOctet:    endOctet,
+		NodeIPs:     sortedOctets(nodeIPs),
+		IPRange:     ipRange,
+	}
+
+	if rangeHasNodeIP(allocation, nodeIPs) {
+		logger.Warnf("⚠️ MetalLB IP range %s of cluster %s includes a node IP, services can be handed an address already in use", ipRange, clusterName)
+	}
+	// the cluster's own earlier allocation is being replaced
+	mm.untrackAllocation(clusterName)
+	// the pools of another project on the same network would hand out the same addresses
+	if other := mm.overlappingAllocation(prefix, startOctet, endOctet); other != nil {
+		if owner := mm.allocationOwners[other.ClusterName]; owner != "" && owner != mm.project {
+			return "", nil, fmt.Errorf("MetalLB IP range %s overlaps the range %s of cluster %s in project %s", ipRange, other.IPRange, other.ClusterName, owner)
+		}
+		logger.Warnf("⚠️ MetalLB IP range %s of cluster %s overlaps the pool of cluster %s", ipRange, clusterName, other.ClusterName)
+	}
+
+	logger.Debugf("using the given MetalLB IP range for cluster %s: %s", clusterName, ipRange)
+	return ipRange, allocation, nil
+}
+
+// clusterSubnet returns the network of the cluster IP, the subnet of the cluster network when it holds the IP and
+// the /24 of the IP otherwise
+func clusterSubnet(subnetCIDR string, clusterIP net.IP) *net.IPNet {
+	if _, subnet, err := net.ParseCIDR(subnetCIDR); err == nil && subnet.Contains(clusterIP) {
+		return subnet
+	}
+	return &net.IPNet{IP: clusterIP.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+}
+
 // hasRangeOverlap checks if the given range overlaps with any existing ranges for the same IP prefix
 func (mm *MetalLBManager) hasRangeOverlap(ipPrefix string, startOctet, endOctet int) bool {
+	return mm.overlappingAllocation(ipPrefix, startOctet, endOctet) != nil
+}
+
+// overlappingAllocation returns a tracked allocation the range overlaps, nil when there is none
+func (mm *MetalLBManager) overlappingAllocation(ipPrefix string, startOctet, endOctet int) *config.MetalLBAllocation {
 	// iterate through all allocations to check for overlaps
 	for _, alloc := range mm.ipAllocations {
 		// only check ranges with the same IP prefix
@@ -450,10 +822,10 @@ func (mm *MetalLBManager) hasRangeOverlap(ipPrefix string, startOctet, endOctet
 		// Two ranges overlap if: start1 <= end2 && start2 <= end1
 		if alloc.StartOctet <= endOctet && startOctet <= alloc.EndOctet {
 			logger.Debugf("range overlap detected: new range %d-%d overlaps with existing range %d-%d (cluster %s)", startOctet, endOctet, alloc.StartOctet, alloc.EndOctet, alloc.ClusterName)
-			return true
+			return alloc
 		}
 	}
-	return false
+	return nil
 }
 
 // findNextAvailableRange finds the next available IP range that doesn't conflict with used ranges
@@ -505,8 +877,9 @@ func (mm *MetalLBManager) findNextAvailableRange(startOctet, endOctet, rangeSize
 	return startOctet, endOctet
 }
 
-// getNodeIPs retrieves all node IP addresses from the cluster
-func (mm *MetalLBManager) getNodeIPs(clientManager *k8s.ClientManager) (map[int]bool, error) {
+// getNodeIPs retrieves the last octets of the node IP addresses of the cluster in the x.x.x ipPrefix, the pools
+// are allocated by last octet so nodes in another /24 can't take their addresses
+func (mm *MetalLBManager) getNodeIPs(clientManager *k8s.ClientManager, ipPrefix string) (map[int]bool, error) {
 	nodeIPs := make(map[int]bool)
 
 	client := clientManager.GetClientset()
@@ -522,7 +895,7 @@ func (mm *MetalLBManager) getNodeIPs(clientManager *k8s.ClientManager) (map[int]
 				if ip != nil && ip.To4() != nil {
 					// extract last octet
 					ipParts := strings.Split(addr.Address, ".")
-					if len(ipParts) == 4 {
+					if len(ipParts) == 4 && strings.Join(ipParts[:3], ".") == ipPrefix {
 						if lastOctet, err := strconv.Atoi(ipParts[3]); err == nil {
 							nodeIPs[lastOctet] = true
 							logger.Debugf("found node IP: %s (last octet: %d)", addr.Address, lastOctet)
@@ -535,55 +908,3 @@ func (mm *MetalLBManager) getNodeIPs(clientManager *k8s.ClientManager) (map[int]
 
 	return nodeIPs, nil
 }
-
-// adjustRangeForNodeIPs adjusts the IP range to avoid node IPs
-// if node IPs are found in the range, it shifts the range up
-func (mm *MetalLBManager) adjustRangeForNodeIPs(startOctet, endOctet int, nodeIPs map[int]bool, ipPrefix string) (int, int) {
-	// check if any node IPs are in our range
-	hasOverlap := false
-	for octet := startOctet; octet <= endOctet; octet++ {
-		if nodeIPs[octet] {
-			hasOverlap = true
-			logger.Debugf("node IP found at %s.%d, adjusting range", ipPrefix, octet)
-			break
-		}
-	}
-
-	// if overlap found, try to shift range up
-	if hasOverlap {
-		newStart := startOctet
-		newEnd := endOctet
-		rangeSize := endOctet - startOctet + 1
-
-		// try to find a contiguous range without node IPs
-		for attempt := 0; attempt < 10; attempt++ {
-			// check if this range is free
-			free := true
-			for octet := newStart; octet <= newEnd; octet++ {
-				if nodeIPs[octet] || octet > mm.maxOctetRange {
-					free = false
-					break
-				}
-			}
-
-			if free {
-				return newStart, newEnd
-			}
-
-			// shift up by 1
-			newStart++
-			newEnd = newStart + rangeSize - 1
-
-			// if we exceed max, wrap around from min
-			if newEnd > mm.maxOctetRange {
-				newStart = mm.minOctetRange
-				newEnd = newStart + rangeSize - 1
-			}
-		}
-
-		// if we couldn't find a free range, log warning and use original
-		logger.Warnf("could not find completely free range, using original range with potential overlap")
-	}
-
-	return startOctet, endOctet
-}
@@ -23,6 +23,10 @@
 package services
 
 import (
+	"fmt"
+	"net"
+	"sync"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -45,7 +49,7 @@ var _ = Describe("MetalLBManager", func() {
 
 		// create a minimal helm manager for testing - use empty kubeconfig path for unit tests
 		helmManager = helm.NewHelmManager("")
-		metallbManager = NewMetalLBManagerWithOptions(helmManager, 200, 254)
+		metallbManager = NewMetalLBManagerWithOptions(helmManager, 200, 254, 20)
 		metallbManager.configManager = configManager
 
 		// ensure clean state for each test
@@ -286,6 +290,102 @@ var _ = Describe("MetalLBManager", func() {
 				// verify config file was created
 				Expect(configPath).To(BeAnExistingFile())
 			})
+
+			It("should not lose allocations saved concurrently", func() {
+				project := "test-project-concurrent"
+				const numClusters = 10
+
+				var wg sync.WaitGroup
+				errs := make([]error, numClusters)
+				for i := 0; i < numClusters; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						errs[i] = metallbManager.SaveAllocation(project, &config.MetalLBAllocation{
+							ClusterName: fmt.Sprintf("%s-%d", project, i+1),
+							IPPrefix:    "192.168.102",
+							StartOctet:  200 + i*5,
+							EndOctet:    204 + i*5,
+							NodeIPs:     []int{100 + i},
+							IPRange:     fmt.Sprintf("192.168.102.%d-192.168.102.%d", 200+i*5, 204+i*5),
+						})
+					}()
+				}
+				wg.Wait()
+
+				for _, err := range errs {
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				// every allocation should have made it to the config file
+				projectConfig, err := configManager.LoadConfig(project)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.MetalLBAllocations).To(HaveLen(numClusters))
+
+				// and into the in-memory tracking
+				Expect(metallbManager.ipAllocations).To(HaveLen(numClusters))
+				Expect(metallbManager.usedRanges).To(HaveLen(numClusters))
+				Expect(metallbManager.allNodeIPs).To(HaveLen(numClusters))
+			})
+
+			It("should release the allocation of a deleted cluster", func() {
+				project := "test-project-release"
+				for i := 1; i <= 2; i++ {
+					Expect(metallbManager.SaveAllocation(project, &config.MetalLBAllocation{
+						ClusterName: fmt.Sprintf("%s-%d", project, i),
+						IPPrefix:    "192.168.102",
+						StartOctet:  180 + i*20,
+						EndOctet:    199 + i*20,
+						NodeIPs:     []int{100 + i},
+						IPRange:     fmt.Sprintf("192.168.102.%d-192.168.102.%d", 180+i*20, 199+i*20),
+					})).To(Succeed())
+				}
+
+				Expect(metallbManager.ReleaseAllocation(project, project+"-1")).To(Succeed())
+
+				projectConfig, err := configManager.LoadConfig(project)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projectConfig.MetalLBAllocations).To(HaveLen(1))
+				Expect(projectConfig.MetalLBAllocations[0].ClusterName).To(Equal(project + "-2"))
+
+				Expect(metallbManager.ipAllocations).NotTo(HaveKey(project + "-1"))
+				Expect(metallbManager.hasRangeOverlap("192.168.102", 200, 219)).To(BeFalse())
+				Expect(metallbManager.hasRangeOverlap("192.168.102", 220, 239)).To(BeTrue())
+				Expect(metallbManager.allNodeIPs).To(Equal(map[int]bool{102: true}))
+
+				// releasing it again is a no-op
+				Expect(metallbManager.ReleaseAllocation(project, project+"-1")).To(Succeed())
+			})
+
+			It("should be safe to reinitialize tracking while allocations are saved", func() {
+				project := "test-project-reinit"
+
+				var wg sync.WaitGroup
+				for i := 0; i < 5; i++ {
+					wg.Add(2)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						Expect(metallbManager.SaveAllocation(project, &config.MetalLBAllocation{
+							ClusterName: fmt.Sprintf("%s-%d", project, i+1),
+							IPPrefix:    "192.168.102",
+							StartOctet:  200 + i*5,
+							EndOctet:    204 + i*5,
+							IPRange:     fmt.Sprintf("192.168.102.%d-192.168.102.%d", 200+i*5, 204+i*5),
+						})).To(Succeed())
+					}()
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						Expect(metallbManager.InitializeTracking(project)).To(Succeed())
+					}()
+				}
+				wg.Wait()
+
+				// reloading from the config file should find every allocation
+				Expect(metallbManager.InitializeTracking(project)).To(Succeed())
+				Expect(metallbManager.ipAllocations).To(HaveLen(5))
+			})
 		})
 	})
 
@@ -296,6 +396,75 @@ var _ = Describe("MetalLBManager", func() {
 				// for now, we'll test the logic indirectly through integration tests
 				Skip("Requires k8s client mocking - covered by e2e tests")
 			})
+
+			It("should reject IPv6 node addresses", func() {
+				_, _, err := metallbManager.generateMetalLBIPRange("kind1", "fc00:f853:ccd:e793::2", 1, 1, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("IPv4"))
+			})
+		})
+
+		Context("Projects sharing a subnet", func() {
+			saveProject := func(project, cluster, prefix string, start, end int) {
+				Expect(configManager.SaveConfig(project, &config.ProjectConfig{
+					Project: project,
+					MetalLBAllocations: []config.MetalLBAllocation{{
+						ClusterName: cluster,
+						IPPrefix:    prefix,
+						StartOctet:  start,
+						EndOctet:    end,
+						IPRange:     fmt.Sprintf("%s.%d-%s.%d", prefix, start, prefix, end),
+					}},
+				})).To(Succeed())
+			}
+
+			It("should allocate around the range of another project on the same subnet", func() {
+				saveProject("alpha", "alpha", "172.18.0", 200, 219)
+				Expect(metallbManager.InitializeTracking("beta")).To(Succeed())
+
+				ipRange, _, err := metallbManager.allocateMetalLBIPRange("beta", "172.18.0", 1, 1, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipRange).To(Equal("172.18.0.220-172.18.0.239"))
+			})
+
+			It("should not be pushed onto another project's range by a node IP", func() {
+				saveProject("alpha", "alpha", "172.18.0", 210, 229)
+				Expect(metallbManager.InitializeTracking("beta")).To(Succeed())
+				metallbManager.SetIPsPerCluster(10)
+
+				ipRange, _, err := metallbManager.allocateMetalLBIPRange("beta", "172.18.0", 1, 1, map[int]bool{201: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipRange).To(Equal("172.18.0.230-172.18.0.239"))
+			})
+
+			It("should ignore the ranges of projects on other subnets", func() {
+				saveProject("alpha", "alpha", "172.19.0", 200, 219)
+				Expect(metallbManager.InitializeTracking("beta")).To(Succeed())
+
+				ipRange, _, err := metallbManager.allocateMetalLBIPRange("beta", "172.18.0", 1, 1, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipRange).To(Equal("172.18.0.200-172.18.0.219"))
+			})
+		})
+
+		Context("rangeHasNodeIP", func() {
+			allocation := &config.MetalLBAllocation{ClusterName: "demo", IPPrefix: "192.168.49", StartOctet: 200, EndOctet: 209}
+
+			It("should find a node IP inside the range", func() {
+				Expect(rangeHasNodeIP(allocation, map[int]bool{2: true, 200: true})).To(BeTrue())
+				Expect(rangeHasNodeIP(allocation, map[int]bool{209: true})).To(BeTrue())
+			})
+
+			It("should ignore node IPs outside the range", func() {
+				Expect(rangeHasNodeIP(allocation, map[int]bool{2: true, 3: true, 210: true})).To(BeFalse())
+				Expect(rangeHasNodeIP(allocation, map[int]bool{})).To(BeFalse())
+			})
+		})
+
+		Context("ReconcileNodeIPs", func() {
+			It("should leave a cluster without an allocation alone", func() {
+				Expect(metallbManager.ReconcileNodeIPs("unknown", "192.168.49.2", 1, 1, "test-project")).To(Succeed())
+			})
 		})
 	})
 
@@ -305,6 +474,7 @@ var _ = Describe("MetalLBManager", func() {
 				manager := NewMetalLBManager(helmManager)
 				Expect(manager).NotTo(BeNil())
 				Expect(manager.helmManager).To(Equal(helmManager))
+				Expect(manager.ipsPerCluster).To(Equal(config.MetalLBDefaultIPsPerCluster))
 				Expect(manager.configManager).NotTo(BeNil())
 				Expect(manager.ipAllocations).NotTo(BeNil())
 				Expect(manager.usedRanges).NotTo(BeNil())
@@ -314,15 +484,92 @@ var _ = Describe("MetalLBManager", func() {
 
 		Context("NewMetalLBManagerWithOptions", func() {
 			It("should create manager with custom octet ranges", func() {
-				manager := NewMetalLBManagerWithOptions(helmManager, 200, 254)
+				manager := NewMetalLBManagerWithOptions(helmManager, 200, 254, 20)
 				Expect(manager).NotTo(BeNil())
 				Expect(manager.minOctetRange).To(Equal(200))
 				Expect(manager.maxOctetRange).To(Equal(254))
+				Expect(manager.ipsPerCluster).To(Equal(20))
 				Expect(manager.configManager).NotTo(BeNil())
 				Expect(manager.ipAllocations).NotTo(BeNil())
 				Expect(manager.usedRanges).NotTo(BeNil())
 				Expect(manager.allNodeIPs).NotTo(BeNil())
 			})
+
+			It("should fall back to the default pool size when none is given", func() {
+				manager := NewMetalLBManagerWithOptions(helmManager, 200, 254, 0)
+				Expect(manager.ipsPerCluster).To(Equal(config.MetalLBDefaultIPsPerCluster))
+			})
+		})
+
+		Context("SetIPsPerCluster", func() {
+			It("should override the pool size", func() {
+				manager := NewMetalLBManagerWithOptions(helmManager, 200, 254, 20)
+				manager.SetIPsPerCluster(10)
+				Expect(manager.ipsPerCluster).To(Equal(10))
+			})
+
+			It("should ignore non-positive values", func() {
+				manager := NewMetalLBManagerWithOptions(helmManager, 200, 254, 20)
+				manager.SetIPsPerCluster(0)
+				Expect(manager.ipsPerCluster).To(Equal(20))
+			})
+		})
+	})
+
+	Describe("Explicit IP ranges", func() {
+		It("should hand each cluster the range at its number", func() {
+			metallbManager.SetIPRanges([]string{"192.168.99.240-192.168.99.245", "192.168.99.246-192.168.99.250"})
+			Expect(metallbManager.explicitIPRange(1)).To(Equal("192.168.99.240-192.168.99.245"))
+			Expect(metallbManager.explicitIPRange(2)).To(Equal("192.168.99.246-192.168.99.250"))
+			Expect(metallbManager.explicitIPRange(3)).To(BeEmpty())
+		})
+
+		It("should reject a range outside the cluster network", func() {
+			_, _, err := metallbManager.explicitMetalLBIPRange("test-1", "192.168.100.10", "192.168.99.240-192.168.99.250", nil)
+			Expect(err).To(MatchError(ContainSubstring("outside the network 192.168.100.0/24 of cluster test-1")))
+
+			metallbManager.SetSubnet("172.18.0.0/16")
+			_, _, err = metallbManager.explicitMetalLBIPRange("test-1", "172.18.0.2", "172.19.255.200-172.19.255.250", nil)
+			Expect(err).To(MatchError(ContainSubstring("outside the network 172.18.0.0/16 of cluster test-1")))
+		})
+
+		It("should take the ranges from the whole cluster network", func() {
+			subnet := clusterSubnet("172.18.0.0/16", net.ParseIP("172.18.0.2").To4())
+			Expect(subnet.String()).To(Equal("172.18.0.0/16"))
+			Expect(subnet.Contains(net.ParseIP("172.18.255.200"))).To(BeTrue())
+			Expect(subnet.Contains(net.ParseIP("172.18.255.250"))).To(BeTrue())
+
+			// without the network, or when the cluster isn't on it, only the /24 of the cluster IP is known
+			Expect(clusterSubnet("", net.ParseIP("172.18.0.2").To4()).String()).To(Equal("172.18.0.0/24"))
+			Expect(clusterSubnet("10.89.0.0/16", net.ParseIP("172.18.0.2").To4()).String()).To(Equal("172.18.0.0/24"))
+		})
+	})
+
+	Describe("Advertisement mode", func() {
+		It("should advertise the pool over L2 by default and remove the BGP resources", func() {
+			manifest, stale := metallbManager.metallbManifests("172.18.255.200-172.18.255.219")
+			Expect(manifest).To(ContainSubstring("- 172.18.255.200-172.18.255.219"))
+			Expect(manifest).To(ContainSubstring("kind: L2Advertisement"))
+			Expect(manifest).NotTo(ContainSubstring("kind: BGPPeer"))
+			Expect(stale).To(ContainSubstring("kind: BGPPeer"))
+			Expect(stale).To(ContainSubstring("kind: BGPAdvertisement"))
+		})
+
+		It("should peer with the configured router in BGP mode", func() {
+			metallbManager.SetBGPPeer(&MetalLBBGPPeer{Address: "172.18.0.1", ASN: 65001})
+			manifest, stale := metallbManager.metallbManifests("172.18.255.200-172.18.255.219")
+
+			Expect(manifest).To(ContainSubstring("myASN: 64500\n  peerASN: 65001\n  peerAddress: 172.18.0.1\n"))
+			Expect(manifest).To(ContainSubstring("kind: BGPAdvertisement"))
+			Expect(manifest).NotTo(ContainSubstring("kind: L2Advertisement"))
+			Expect(stale).To(ContainSubstring("kind: L2Advertisement"))
+		})
+
+		It("should go back to L2 without a peer", func() {
+			metallbManager.SetBGPPeer(&MetalLBBGPPeer{Address: "172.18.0.1"})
+			metallbManager.SetBGPPeer(nil)
+			manifest, _ := metallbManager.metallbManifests("172.18.255.200-172.18.255.219")
+			Expect(manifest).To(ContainSubstring("kind: L2Advertisement"))
 		})
 	})
 })
@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	"github.com/day0ops/lok8s/pkg/util/helm"
+)
+
+// MetricsServerManager manages metrics-server installation
+type MetricsServerManager struct {
+	helmManager *helm.HelmManager
+	timeout     time.Duration // readiness timeout for the chart install
+}
+
+// NewMetricsServerManager creates a new metrics-server manager
+func NewMetricsServerManager(helmManager *helm.HelmManager) *MetricsServerManager {
+	return &MetricsServerManager{
+		helmManager: helmManager,
+		timeout:     config.DefaultReadinessTimeout,
+	}
+}
+
+// SetReadinessTimeout overrides how long to wait for metrics-server to become ready
+func (msm *MetricsServerManager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		msm.timeout = timeout
+	}
+}
+
+// metricsServerValues returns the metrics-server chart values
+func metricsServerValues() map[string]interface{} {
+	return map[string]interface{}{
+		// kubelet serving certificates are self-signed on local clusters
+		"args": []interface{}{"--kubelet-insecure-tls"},
+	}
+}
+
+// InstallMetricsServer installs metrics-server using Helm
+func (msm *MetricsServerManager) InstallMetricsServer(clusterName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("installing metrics-server on cluster %s", clusterName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	// add metrics-server repository
+	if err := msm.helmManager.AddRepository("metrics-server", "https://kubernetes-sigs.github.io/metrics-server/"); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to add metrics-server repository: %w", err)
+	}
+
+	// install metrics-server chart, helm waits for the deployment to become ready
+	if err := msm.helmManager.InstallChart(clusterName, "metrics-server", "metrics-server/metrics-server", "", "kube-system", metricsServerValues(), msm.timeout); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to install metrics-server chart: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the metrics-server Helm release from a cluster. Minikube clusters run it as a minikube addon,
+// which has no release and is left as it is
+func (msm *MetricsServerManager) Uninstall(contextName string) error {
+	status := logger.NewStatus()
+	status.Start(fmt.Sprintf("uninstalling metrics-server from cluster %s", contextName))
+	defer func() {
+		if status != nil {
+			status.End(true)
+		}
+	}()
+
+	if err := uninstallRelease(msm.helmManager, contextName, "metrics-server", "kube-system"); err != nil {
+		status.End(false)
+		return fmt.Errorf("failed to uninstall metrics-server chart: %w", err)
+	}
+
+	// Success - status.End(true) will be called by defer
+	return nil
+}
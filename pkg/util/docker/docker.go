@@ -25,69 +25,102 @@ package docker
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/day0ops/lok8s/pkg/logger"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
 )
 
-// GetContainerRuntime detects and returns the available container runtime
-func GetContainerRuntime() (string, error) {
-	// check for Docker
-	if err := exec.Command("docker", "version").Run(); err == nil {
-		return "docker", nil
-	}
+// defaultRuntimes is the order container runtimes are detected in when no preference is given
+var defaultRuntimes = []string{"docker", "podman"}
 
-	// check for Podman
-	if err := exec.Command("podman", "version").Run(); err == nil {
-		return "podman", nil
+// ResolveContainerRuntime returns the container engine when one is given, otherwise the first available one from
+// the preference list. The resolved runtime is what the container and network helpers of this package run
+func ResolveContainerRuntime(engine string, preference []string) (string, error) {
+	if engine != "" {
+		return engine, nil
 	}
-
-	return "", fmt.Errorf("neither Docker nor Podman is available")
+	return DetectContainerRuntime(preference)
 }
 
-// CreateNetwork creates a Docker/Podman network
-func CreateNetwork(networkName, gatewayIP, subnetCIDR string) error {
-	runtime, err := GetContainerRuntime()
-	if err != nil {
-		return err
+// DetectContainerRuntime returns the first available container runtime from the preference list,
+// falling back to docker then podman when the list is empty
+func DetectContainerRuntime(preference []string) (string, error) {
+	if len(preference) == 0 {
+		preference = defaultRuntimes
+	}
+
+	for _, runtime := range preference {
+		if err := utilexec.Run(runtime, "version"); err == nil {
+			return runtime, nil
+		}
+		logger.Debugf("container runtime %s is not available", runtime)
 	}
 
+	return "", fmt.Errorf("none of the container runtimes %s is available. %s", strings.Join(preference, ", "), RuntimeStartHint(preference[0]))
+}
+
+// CreateNetwork creates a Docker/Podman network with the labels, enabling IPv6 when ipv6SubnetCIDR is set
+func CreateNetwork(runtime, networkName, gatewayIP, subnetCIDR, ipv6SubnetCIDR string, labels map[string]string) error {
 	// check if network already exists
-	cmd := exec.Command(runtime, "network", "ls", "--format", "{{.Name}}")
-	output, err := cmd.Output()
+	exists, err := NetworkExists(runtime, networkName)
 	if err != nil {
-		return fmt.Errorf("failed to list networks: %w", err)
+		return err
 	}
-
-	networks := strings.Split(string(output), "\n")
-	for _, network := range networks {
-		if strings.TrimSpace(network) == networkName {
-			logger.Infof("network %s already exists", networkName)
-			return nil
-		}
+	if exists {
+		logger.Infof("network %s already exists", networkName)
+		return nil
 	}
 
 	// create network
-	cmd = exec.Command(runtime, "network", "create", networkName,
-		"--gateway="+gatewayIP,
-		"--subnet="+subnetCIDR)
+	args := []string{"network", "create", networkName,
+		"--gateway=" + gatewayIP,
+		"--subnet=" + subnetCIDR}
+	if ipv6SubnetCIDR != "" {
+		// the gateway applies to the first (IPv4) subnet, the IPv6 one gets its default gateway
+		args = append(args, "--ipv6", "--subnet="+ipv6SubnetCIDR)
+	}
+	args = append(args, labelArgs(labels)...)
+	cmd := exec.Command(runtime, args...)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create network %s: %w", networkName, err)
 	}
 
+	if ipv6SubnetCIDR != "" {
+		logger.Infof("📡 Network '%s' created with gateway %s and subnets %s, %s", networkName, gatewayIP, subnetCIDR, ipv6SubnetCIDR)
+		return nil
+	}
 	logger.Infof("📡 Network '%s' created with gateway %s and subnet %s", networkName, gatewayIP, subnetCIDR)
 	return nil
 }
 
+// labelArgs returns the --label arguments for the labels, sorted by key
+func labelArgs(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, key := range keys {
+		args = append(args, "--label", key+"="+labels[key])
+	}
+	return args
+}
+
 // GetNetworkGateway gets the gateway IP of a Docker network
-func GetNetworkGateway(networkName string) (string, error) {
-	cmd := exec.Command("docker", "network", "inspect", networkName, "--format", "json")
-	output, err := cmd.Output()
+func GetNetworkGateway(runtime, networkName string) (string, error) {
+	output, err := utilexec.Output(runtime, "network", "inspect", networkName, "--format", "json")
 	if err != nil {
 		return "", fmt.Errorf("failed to inspect network %s: %w", networkName, err)
 	}
@@ -118,59 +151,140 @@ func GetNetworkGateway(networkName string) (string, error) {
 	return "", fmt.Errorf("gateway not found for network %s", networkName)
 }
 
-// CreateRegistryContainer creates and starts the main registry container
-func CreateRegistryContainer(regName, networkName, regPort, registryPort string) error {
-	// Check container runtime - only proceed if it's Docker
-	containerRuntime, err := GetContainerRuntime()
-	if err != nil {
-		return fmt.Errorf("failed to get container runtime: %w", err)
+// registryImage is the fully qualified registry image, podman doesn't resolve short names non-interactively
+const registryImage = "docker.io/library/registry:2"
+
+// psContainer is the part of a runtime's ps output the registry setup uses
+type psContainer struct {
+	Names  []string
+	State  string
+	Status string
+}
+
+// dockerPsContainer is a line of docker ps --format json output, the names are comma separated
+type dockerPsContainer struct {
+	Names  string `json:"Names"`
+	State  string `json:"State"`
+	Status string `json:"Status"`
+}
+
+// podmanPsContainer is an entry of podman ps --format json output
+type podmanPsContainer struct {
+	Names  []string `json:"Names"`
+	State  string   `json:"State"`
+	Status string   `json:"Status"`
+}
+
+// parsePsOutput parses ps --format json output, docker prints a JSON object per line while podman prints a
+// JSON array
+func parsePsOutput(runtime string, output []byte) ([]psContainer, error) {
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return nil, nil
 	}
 
-	if containerRuntime != "docker" {
-		logger.Debugf("skipping registry container setup (container runtime is %s, not docker)", containerRuntime)
-		return nil
+	var containers []psContainer
+	if runtime == "podman" {
+		var podmanContainers []podmanPsContainer
+		if err := json.Unmarshal(output, &podmanContainers); err != nil {
+			return nil, fmt.Errorf("failed to parse podman ps output: %w", err)
+		}
+		for _, pc := range podmanContainers {
+			containers = append(containers, psContainer{Names: pc.Names, State: pc.State, Status: pc.Status})
+		}
+		return containers, nil
 	}
 
-	// Check if container already exists
-	cmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", regName), "--format", "json")
-	output, err := cmd.Output()
-	if err == nil && len(output) > 0 {
-		var containers []map[string]interface{}
-		// docker ps can return multiple lines (one per container) or a single object
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-			var containerInfo map[string]interface{}
-			if err := json.Unmarshal([]byte(line), &containerInfo); err == nil && len(containerInfo) > 0 {
-				containers = append(containers, containerInfo)
-			}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
 		}
+		var dc dockerPsContainer
+		if err := json.Unmarshal([]byte(line), &dc); err != nil {
+			return nil, fmt.Errorf("failed to parse docker ps output: %w", err)
+		}
+		containers = append(containers, psContainer{Names: strings.Split(dc.Names, ","), State: dc.State, Status: dc.Status})
+	}
+	return containers, nil
+}
 
-		if len(containers) > 0 {
-			// Container exists - just skip it, don't try to start or recreate
-			containerInfo := containers[0]
-			if status, ok := containerInfo["Status"].(string); ok {
-				if strings.Contains(status, "Up") || strings.Contains(status, "running") {
-					logger.Debugf("registry container %s already exists and is running, skipping", regName)
-				} else {
-					logger.Debugf("registry container %s already exists (status: %s), skipping", regName, status)
-				}
-			} else {
-				logger.Debugf("registry container %s already exists, skipping", regName)
+// findContainer returns the container with exactly the given name, nil when there is none. The ps name filter
+// also matches substrings
+func findContainer(runtime, containerName string) (*psContainer, error) {
+	output, err := utilexec.Output(runtime, "ps", "-a", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	containers, err := parsePsOutput(runtime, output)
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range containers {
+		for _, name := range container.Names {
+			if strings.TrimPrefix(name, "/") == containerName {
+				return &container, nil
 			}
+		}
+	}
+	return nil, nil
+}
+
+// registryVolume returns a volume mounted into a registry container, relabeled for SELinux hosts with podman
+func registryVolume(runtime, hostPath, containerPath string) string {
+	volume := fmt.Sprintf("%s:%s", hostPath, containerPath)
+	if runtime == "podman" {
+		volume += ":z"
+	}
+	return volume
+}
+
+// registryContainerExists reports whether a registry container was already created, logging its state
+func registryContainerExists(runtime, containerName string) bool {
+	container, err := findContainer(runtime, containerName)
+	if err != nil {
+		logger.Debugf("failed to check for container %s: %v", containerName, err)
+		return false
+	}
+	if container == nil {
+		return false
+	}
+
+	// Container exists - just skip it, don't try to start or recreate
+	if container.State == "running" {
+		logger.Debugf("registry container %s already exists and is running, skipping", containerName)
+	} else {
+		logger.Debugf("registry container %s already exists (status: %s), skipping", containerName, container.Status)
+	}
+	return true
+}
+
+// CreateRegistryContainer creates and starts the main registry container with docker or podman
+func CreateRegistryContainer(containerRuntime, regName, networkName, regPort, registryPort, certDir string) error {
+	if registryContainerExists(containerRuntime, regName) {
+		// the registry is shared, an existing one only has to be recreated when it serves the other scheme
+		env, err := utilexec.Output(containerRuntime, "inspect", "-f", "{{range .Config.Env}}{{println .}}{{end}}", regName)
+		if err != nil {
+			logger.Warnf("⚠️ failed to inspect registry %s, keeping it as it is: %v", regName, err)
+			return nil
+		}
+		if registryServesTLS(string(env)) == (certDir != "") {
 			return nil
 		}
+		logger.Infof("recreating registry %s to switch it to %s", regName, registryScheme(certDir))
+		if err := DeleteRegistryContainers(containerRuntime, []string{regName}); err != nil {
+			return fmt.Errorf("failed to recreate registry %s: %w", regName, err)
+		}
 	}
 
 	// create and start new registry container
-	cmd = exec.Command("docker", "run", "-d",
+	args := []string{"run", "-d",
 		"--name", regName,
 		"--network", networkName,
 		"--restart", "always",
 		"-p", fmt.Sprintf("0.0.0.0:%s:%s", regPort, registryPort),
-		"registry:2")
+	}
+	args = append(args, registryTLSArgs(containerRuntime, certDir)...)
+	cmd := exec.Command(containerRuntime, append(args, registryImage)...)
 
 	// capture stderr for better error messages
 	var stderr bytes.Buffer
@@ -191,74 +305,70 @@ func CreateRegistryContainer(regName, networkName, regPort, registryPort string)
 	return nil
 }
 
-// CreateRegistryMirror creates and starts a registry mirror container
-func CreateRegistryMirror(cacheName, cacheURL, networkName, registryPort string) error {
-	// Check container runtime - only proceed if it's Docker
-	containerRuntime, err := GetContainerRuntime()
-	if err != nil {
-		return fmt.Errorf("failed to get container runtime: %w", err)
-	}
-
-	if containerRuntime != "docker" {
-		logger.Debugf("skipping registry mirror setup for %s (container runtime is %s, not docker)", cacheName, containerRuntime)
+// registryTLSArgs returns the run arguments serving the registry over https with the tls.crt and tls.key of the
+// cert directory, none for plain http when there is no directory
+func registryTLSArgs(runtime, certDir string) []string {
+	if certDir == "" {
 		return nil
 	}
+	return []string{
+		"-v", registryVolume(runtime, certDir, "/certs"),
+		"-e", "REGISTRY_HTTP_TLS_CERTIFICATE=/certs/tls.crt",
+		"-e", "REGISTRY_HTTP_TLS_KEY=/certs/tls.key",
+	}
+}
 
-	// Check if container already exists
-	cmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", cacheName), "--format", "json")
-	output, err := cmd.Output()
-	if err == nil && len(output) > 0 {
-		var containers []map[string]interface{}
-		// docker ps can return multiple lines (one per container) or a single object
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-			var containerInfo map[string]interface{}
-			if err := json.Unmarshal([]byte(line), &containerInfo); err == nil && len(containerInfo) > 0 {
-				containers = append(containers, containerInfo)
-			}
+// registryServesTLS reports whether a registry container with the given environment, one variable per line,
+// serves https
+func registryServesTLS(env string) bool {
+	for _, line := range strings.Split(env, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "REGISTRY_HTTP_TLS_CERTIFICATE=") {
+			return true
 		}
+	}
+	return false
+}
 
-		if len(containers) > 0 {
-			// Container exists - just skip it, don't try to start or recreate
-			containerInfo := containers[0]
-			if status, ok := containerInfo["Status"].(string); ok {
-				if strings.Contains(status, "Up") || strings.Contains(status, "running") {
-					logger.Debugf("registry mirror %s already exists and is running, skipping", cacheName)
-				} else {
-					logger.Debugf("registry mirror %s already exists (status: %s), skipping", cacheName, status)
-				}
-			} else {
-				logger.Debugf("registry mirror %s already exists, skipping", cacheName)
-			}
+// registryScheme returns the scheme a registry created with the cert directory serves
+func registryScheme(certDir string) string {
+	if certDir == "" {
+		return "http"
+	}
+	return "https"
+}
+
+// registryMirrorConfigPath is where the registry image reads its config from
+const registryMirrorConfigPath = "/etc/docker/registry/config.yml"
+
+// RegistryCredentials authenticate a registry mirror against its upstream
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// CreateRegistryMirror creates and starts a registry mirror container with docker or podman, credentials are
+// optional and only written to the mirror config
+func CreateRegistryMirror(containerRuntime, cacheName, cacheURL, networkName, registryPort string, credentials *RegistryCredentials) error {
+	if registryContainerExists(containerRuntime, cacheName) {
+		// mirrors are shared, an existing one only has to be recreated when it doesn't use the credentials yet
+		if credentials == nil {
 			return nil
 		}
+		current, err := utilexec.Output(containerRuntime, "exec", cacheName, "cat", registryMirrorConfigPath)
+		if err != nil {
+			logger.Warnf("⚠️ failed to read the config of registry mirror %s, the registry auth was not applied to it: %v", cacheName, err)
+			return nil
+		}
+		if strings.Contains(string(current), registryMirrorCredentials(credentials)) {
+			return nil
+		}
+		logger.Infof("recreating registry mirror %s to apply the registry auth", cacheName)
+		if err := DeleteRegistryContainers(containerRuntime, []string{cacheName}); err != nil {
+			return fmt.Errorf("failed to recreate registry mirror %s: %w", cacheName, err)
+		}
 	}
 
-	// Create registry config
-	configContent := fmt.Sprintf(`version: 0.1
-proxy:
-  remoteurl: %s
-log:
-  fields:
-    service: registry
-storage:
-  cache:
-    blobdescriptor: inmemory
-  filesystem:
-    rootdirectory: /var/lib/registry
-http:
-  addr: :%s
-  headers:
-    X-Content-Type-Options: [nosniff]
-health:
-  storagedriver:
-    enabled: true
-    interval: 10s
-    threshold: 3
-`, cacheURL, registryPort)
+	configContent := registryMirrorConfig(cacheURL, registryPort, credentials)
 
 	// Write config to temporary file
 	tmpDir := os.TempDir()
@@ -277,19 +387,29 @@ health:
 		}
 	}
 
-	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+	// keep the credentials readable by the current user only
+	perm := os.FileMode(0644)
+	if credentials != nil {
+		perm = 0600
+	}
+	if err := os.WriteFile(configPath, []byte(configContent), perm); err != nil {
 		return fmt.Errorf("failed to write registry config: %w", err)
 	}
 
 	// Create and start new registry mirror container
-	cmd = exec.Command("docker", "run", "-d",
+	cmd := exec.Command(containerRuntime, "run", "-d",
 		"--name", cacheName,
 		"--network", networkName,
 		"--restart", "always",
-		"-v", fmt.Sprintf("%s:/etc/docker/registry/config.yml", configPath),
-		"registry:2")
+		"-v", registryVolume(containerRuntime, configPath, registryMirrorConfigPath),
+		registryImage)
 
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
+		if errorMsg := strings.TrimSpace(stderr.String()); errorMsg != "" {
+			return fmt.Errorf("failed to create registry mirror container %s: %s: %w", cacheName, errorMsg, err)
+		}
 		return fmt.Errorf("failed to create registry mirror container %s: %w", cacheName, err)
 	}
 
@@ -297,59 +417,201 @@ health:
 	return nil
 }
 
-// DeleteRegistryContainers deletes registry containers
-func DeleteRegistryContainers(containerNames []string) error {
-	// Check container runtime - only proceed if it's Docker
-	containerRuntime, err := GetContainerRuntime()
-	if err != nil {
-		return fmt.Errorf("failed to get container runtime: %w", err)
-	}
+// registryMirrorCredentials renders the proxy credentials of a registry mirror config
+func registryMirrorCredentials(credentials *RegistryCredentials) string {
+	return fmt.Sprintf("  username: %q\n  password: %q\n", credentials.Username, credentials.Password)
+}
 
-	if containerRuntime != "docker" {
-		logger.Debugf("skipping registry container deletion (container runtime is %s, not docker)", containerRuntime)
-		return nil
+// registryMirrorConfig renders the config.yml of a pull-through registry mirror
+func registryMirrorConfig(cacheURL, registryPort string, credentials *RegistryCredentials) string {
+	proxy := fmt.Sprintf("  remoteurl: %s\n", cacheURL)
+	if credentials != nil {
+		proxy += registryMirrorCredentials(credentials)
 	}
 
+	return fmt.Sprintf(`version: 0.1
+proxy:
+%slog:
+  fields:
+    service: registry
+storage:
+  cache:
+    blobdescriptor: inmemory
+  filesystem:
+    rootdirectory: /var/lib/registry
+http:
+  addr: :%s
+  headers:
+    X-Content-Type-Options: [nosniff]
+health:
+  storagedriver:
+    enabled: true
+    interval: 10s
+    threshold: 3
+`, proxy, registryPort)
+}
+
+// DeleteRegistryContainers deletes registry containers with docker or podman
+func DeleteRegistryContainers(containerRuntime string, containerNames []string) error {
 	for _, containerName := range containerNames {
-		// Check if container exists - docker filter name= matches substrings, so we need to check exact match
-		cmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.Names}}")
-		output, err := cmd.Output()
+		container, err := findContainer(containerRuntime, containerName)
 		if err != nil {
 			logger.Debugf("failed to check for container %s: %v", containerName, err)
 			continue
 		}
+		if container == nil {
+			logger.Debugf("container %s doesn't exist", containerName)
+			continue
+		}
 
-		if len(output) > 0 {
-			// Parse all lines and check for exact match (docker filter can match substrings)
-			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-			found := false
-			for _, line := range lines {
-				name := strings.TrimSpace(line)
-				if name == containerName {
-					found = true
-					// Container exists with exact name match, delete it
-					cmd = exec.Command("docker", "rm", "-f", containerName)
-					var stderr bytes.Buffer
-					cmd.Stderr = &stderr
-					if err := cmd.Run(); err != nil {
-						errorMsg := stderr.String()
-						if errorMsg != "" {
-							logger.Warnf("failed to delete registry container %s: %s", containerName, strings.TrimSpace(errorMsg))
-						} else {
-							logger.Warnf("failed to delete registry container %s: %v", containerName, err)
-						}
-					} else {
-						logger.Infof("deleted registry container %s", containerName)
-					}
-					break // Found and deleted, move to next container
-				}
-			}
-			if !found {
-				logger.Debugf("container %s not found (filter matched but name didn't match exactly)", containerName)
-			}
+		if err := utilexec.Run(containerRuntime, "rm", "-f", containerName); err != nil {
+			logger.Warnf("failed to delete registry container %s: %v", containerName, err)
 		} else {
-			logger.Debugf("container %s doesn't exist", containerName)
+			logger.Infof("deleted registry container %s", containerName)
+		}
+	}
+
+	return nil
+}
+
+// IsImageArchive reports whether the given reference points at an image tarball on disk
+func IsImageArchive(ref string) bool {
+	if !strings.HasSuffix(ref, ".tar") {
+		return false
+	}
+	info, err := os.Stat(ref)
+	return err == nil && !info.IsDir()
+}
+
+// PullImage pulls an image into the local image store with docker or podman, unless it's there already
+func PullImage(containerRuntime, image string) error {
+	if err := utilexec.Run(containerRuntime, "image", "inspect", image); err == nil {
+		logger.Debugf("image %s is already present, skipping pull", image)
+		return nil
+	}
+
+	// pulls can take longer than the query timeout
+	output, err := exec.Command(containerRuntime, "pull", image).CombinedOutput()
+	if err != nil {
+		if errorMsg := strings.TrimSpace(string(output)); errorMsg != "" {
+			return fmt.Errorf("failed to pull image %s: %s: %w", image, errorMsg, err)
+		}
+		return fmt.Errorf("failed to pull image %s: %w", image, err)
+	}
+
+	logger.Debugf("pulled image %s", image)
+	return nil
+}
+
+// StopContainers stops the given containers using the container runtime
+func StopContainers(runtime string, containerNames []string) error {
+	return runContainerAction(runtime, "stop", containerNames)
+}
+
+// StartContainers starts the given containers using the container runtime
+func StartContainers(runtime string, containerNames []string) error {
+	return runContainerAction(runtime, "start", containerNames)
+}
+
+// UpdateContainerResources limits the CPUs and memory of the given containers using the container runtime,
+// an empty value (or max/no-limit) leaves that resource unlimited
+func UpdateContainerResources(runtime string, containerNames []string, cpus, memory string) error {
+	flags, err := resourceLimitFlags(cpus, memory)
+	if err != nil {
+		return err
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+	return runContainerAction(runtime, "update", containerNames, flags...)
+}
+
+// memoryLimitRegex matches the sizes the container runtimes accept (e.g. 8g, 8GB, 8GiB, 1.5g)
+var memoryLimitRegex = regexp.MustCompile(`^\d+(\.\d+)?\s?[kKmMgGtT]?[iI]?[bB]?$`)
+
+// resourceLimitFlags converts minikube style CPU and memory values to container runtime update flags
+func resourceLimitFlags(cpus, memory string) ([]string, error) {
+	var flags []string
+
+	if cpus != "" && cpus != "max" {
+		if count, err := strconv.ParseFloat(cpus, 64); err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid CPU count: %s", cpus)
+		}
+		flags = append(flags, "--cpus", cpus)
+	}
+
+	if memory != "" && memory != "max" && memory != "no-limit" {
+		if !memoryLimitRegex.MatchString(memory) {
+			return nil, fmt.Errorf("invalid memory size: %s", memory)
+		}
+		// minikube reads a plain number as megabytes, the container runtimes as bytes
+		if _, err := strconv.Atoi(memory); err == nil {
+			memory += "m"
+		}
+		flags = append(flags, "--memory", strings.ReplaceAll(memory, " ", ""))
+	}
+
+	return flags, nil
+}
+
+// ContainerState describes the state of a container as reported by the container runtime
+type ContainerState struct {
+	Name    string
+	Exists  bool
+	Running bool
+	Status  string
+}
+
+// InspectContainer returns the state of the given container, reporting a missing container as not existing
+func InspectContainer(runtime, containerName string) (*ContainerState, error) {
+	output, err := utilexec.Output(runtime, "inspect", "--format", "{{.State.Status}}", containerName)
+	if err != nil {
+		var execErr *utilexec.Error
+		if errors.As(err, &execErr) && strings.Contains(strings.ToLower(execErr.Stderr), "no such") {
+			return &ContainerState{Name: containerName, Status: "not found"}, nil
+		}
+		return nil, fmt.Errorf("failed to inspect container %s: %w", containerName, err)
+	}
+
+	status := strings.TrimSpace(string(output))
+	return &ContainerState{
+		Name:    containerName,
+		Exists:  true,
+		Running: status == "running",
+		Status:  status,
+	}, nil
+}
+
+// IsContainerRunning reports whether the given container is currently running
+func IsContainerRunning(runtime, containerName string) (bool, error) {
+	state, err := InspectContainer(runtime, containerName)
+	if err != nil {
+		return false, err
+	}
+	if !state.Exists {
+		return false, fmt.Errorf("container %s not found", containerName)
+	}
+
+	return state.Running, nil
+}
+
+// runContainerAction runs a start/stop/update action against the given containers
+func runContainerAction(runtime, action string, containerNames []string, flags ...string) error {
+	if len(containerNames) == 0 {
+		return nil
+	}
+
+	args := append(append([]string{action}, flags...), containerNames...)
+	cmd := exec.Command(runtime, args...)
+
+	// capture stderr for better error messages
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errorMsg := strings.TrimSpace(stderr.String()); errorMsg != "" {
+			return fmt.Errorf("failed to %s containers %s: %s: %w", action, strings.Join(containerNames, ", "), errorMsg, err)
 		}
+		return fmt.Errorf("failed to %s containers %s: %w", action, strings.Join(containerNames, ", "), err)
 	}
 
 	return nil
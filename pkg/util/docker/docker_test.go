@@ -0,0 +1,99 @@
+package docker
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resourceLimitFlags", func() {
+	It("should pass through the values the runtimes accept", func() {
+		Expect(resourceLimitFlags("2", "8GiB")).To(Equal([]string{"--cpus", "2", "--memory", "8GiB"}))
+		Expect(resourceLimitFlags("1.5", "1.5g")).To(Equal([]string{"--cpus", "1.5", "--memory", "1.5g"}))
+	})
+
+	It("should read a plain memory number as megabytes like minikube does", func() {
+		Expect(resourceLimitFlags("", "8192")).To(Equal([]string{"--memory", "8192m"}))
+	})
+
+	It("should leave unset and unlimited values out", func() {
+		Expect(resourceLimitFlags("", "")).To(BeEmpty())
+		Expect(resourceLimitFlags("max", "no-limit")).To(BeEmpty())
+	})
+
+	It("should reject invalid values", func() {
+		_, err := resourceLimitFlags("four", "")
+		Expect(err).To(MatchError("invalid CPU count: four"))
+
+		_, err = resourceLimitFlags("", "lots")
+		Expect(err).To(MatchError("invalid memory size: lots"))
+	})
+})
+
+var _ = Describe("labelArgs", func() {
+	It("should return the labels sorted by key", func() {
+		Expect(labelArgs(map[string]string{"lok8s.managed": "true", "a": "b"})).To(Equal([]string{"--label", "a=b", "--label", "lok8s.managed=true"}))
+		Expect(labelArgs(nil)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("parsePsOutput", func() {
+	It("should parse the JSON lines of docker ps", func() {
+		output := []byte(`{"Names":"kind-registry","State":"running","Status":"Up 2 hours"}
+{"Names":"docker-io,docker-io-alias","State":"exited","Status":"Exited (0) 1 hour ago"}
+`)
+		containers, err := parsePsOutput("docker", output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containers).To(Equal([]psContainer{
+			{Names: []string{"kind-registry"}, State: "running", Status: "Up 2 hours"},
+			{Names: []string{"docker-io", "docker-io-alias"}, State: "exited", Status: "Exited (0) 1 hour ago"},
+		}))
+	})
+
+	It("should parse the JSON array of podman ps", func() {
+		output := []byte(`[{"Names":["kind-registry"],"State":"running","Status":"Up 2 hours"}]`)
+		containers, err := parsePsOutput("podman", output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containers).To(Equal([]psContainer{{Names: []string{"kind-registry"}, State: "running", Status: "Up 2 hours"}}))
+	})
+
+	It("should treat empty output as no containers", func() {
+		Expect(parsePsOutput("docker", []byte("\n"))).To(BeEmpty())
+		Expect(parsePsOutput("podman", nil)).To(BeEmpty())
+	})
+
+	It("should only add credentials to the mirror config when given", func() {
+		Expect(registryMirrorConfig("https://registry-1.docker.io", "5000", nil)).To(ContainSubstring("proxy:\n  remoteurl: https://registry-1.docker.io\nlog:"))
+
+		rendered := registryMirrorConfig("https://registry-1.docker.io", "5000", &RegistryCredentials{Username: "user", Password: `p"ss`})
+		Expect(rendered).To(ContainSubstring("  remoteurl: https://registry-1.docker.io\n  username: \"user\"\n  password: \"p\\\"ss\"\n"))
+	})
+
+	It("should tell whether a mirror config already uses the credentials", func() {
+		rendered := registryMirrorConfig("https://registry-1.docker.io", "5000", &RegistryCredentials{Username: "user", Password: "old"})
+		Expect(rendered).To(ContainSubstring(registryMirrorCredentials(&RegistryCredentials{Username: "user", Password: "old"})))
+		Expect(rendered).NotTo(ContainSubstring(registryMirrorCredentials(&RegistryCredentials{Username: "user", Password: "new"})))
+		Expect(registryMirrorConfig("https://registry-1.docker.io", "5000", nil)).NotTo(ContainSubstring(registryMirrorCredentials(&RegistryCredentials{Username: "user", Password: "old"})))
+	})
+
+	It("should relabel the registry config volume for podman", func() {
+		Expect(registryVolume("docker", "/tmp/config.yml", "/etc/docker/registry/config.yml")).To(Equal("/tmp/config.yml:/etc/docker/registry/config.yml"))
+		Expect(registryVolume("podman", "/tmp/config.yml", "/etc/docker/registry/config.yml")).To(Equal("/tmp/config.yml:/etc/docker/registry/config.yml:z"))
+	})
+
+	It("should only serve the registry over https given a cert directory", func() {
+		Expect(registryTLSArgs("docker", "")).To(BeEmpty())
+		Expect(registryTLSArgs("podman", "/home/user/.lok8s/registry-certs")).To(Equal([]string{
+			"-v", "/home/user/.lok8s/registry-certs:/certs:z",
+			"-e", "REGISTRY_HTTP_TLS_CERTIFICATE=/certs/tls.crt",
+			"-e", "REGISTRY_HTTP_TLS_KEY=/certs/tls.key",
+		}))
+	})
+
+	It("should tell whether an existing registry serves https from its environment", func() {
+		plain := "PATH=/usr/local/sbin:/usr/bin\nOTEL_TRACES_EXPORTER=none\n"
+		Expect(registryServesTLS(plain)).To(BeFalse())
+		Expect(registryServesTLS(plain + "REGISTRY_HTTP_TLS_CERTIFICATE=/certs/tls.crt\nREGISTRY_HTTP_TLS_KEY=/certs/tls.key\n")).To(BeTrue())
+		Expect(registryScheme("")).To(Equal("http"))
+		Expect(registryScheme("/certs")).To(Equal("https"))
+	})
+})
@@ -0,0 +1,92 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/day0ops/lok8s/pkg/config"
+	"github.com/day0ops/lok8s/pkg/logger"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
+)
+
+// macOS apps that provide a Docker daemon
+var darwinDockerApps = map[string]string{
+	"Docker Desktop":  "/Applications/Docker.app",
+	"Rancher Desktop": "/Applications/Rancher Desktop.app",
+	"OrbStack":        "/Applications/OrbStack.app",
+}
+
+// VerifyRuntimeRunning verifies that the container runtime daemon is actually running, with a hint on
+// how to start it when it isn't
+func VerifyRuntimeRunning(runtime string) error {
+	logger.Debugf("verifying %s daemon is running", runtime)
+
+	// 'info' fails if the daemon is not running
+	if err := utilexec.Run(runtime, "info"); err != nil {
+		return fmt.Errorf("%s daemon is not running: %w. %s", runtime, err, RuntimeStartHint(runtime))
+	}
+
+	logger.Debugf("%s daemon is running", runtime)
+	return nil
+}
+
+// RuntimeStartHint returns guidance on starting the given container runtime on this OS
+func RuntimeStartHint(runtime string) string {
+	return runtimeStartHint(runtime, config.IsDarwin(), isInstalled)
+}
+
+// runtimeStartHint builds the start hint, installed reports whether a command or app path exists
+func runtimeStartHint(runtime string, darwin bool, installed func(string) bool) string {
+	if runtime == "podman" {
+		if darwin {
+			return "Start the Podman machine with `podman machine start`"
+		}
+		return "Check that Podman works with `podman info`"
+	}
+
+	if !darwin {
+		return "Start the Docker daemon, e.g. with `sudo systemctl start docker`"
+	}
+
+	if installed("colima") {
+		return "Start your container engine with `colima start`"
+	}
+	for _, name := range []string{"Docker Desktop", "Rancher Desktop", "OrbStack"} {
+		if installed(darwinDockerApps[name]) {
+			return fmt.Sprintf("Start %s and wait for it to be ready", name)
+		}
+	}
+	return "Install and start a container engine such as Colima (`brew install colima && colima start`) or Docker Desktop"
+}
+
+// isInstalled reports whether name is a command on the PATH or an existing path
+func isInstalled(name string) bool {
+	if _, err := exec.LookPath(name); err == nil {
+		return true
+	}
+	_, err := os.Stat(name)
+	return err == nil
+}
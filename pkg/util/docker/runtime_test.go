@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeRuntimes puts container runtimes answering 'version' on a PATH holding nothing else
+func fakeRuntimes(names ...string) {
+	dir := GinkgoT().TempDir()
+	for _, name := range names {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 0\n"), 0o755)).To(Succeed())
+	}
+	GinkgoT().Setenv("PATH", dir)
+}
+
+var _ = Describe("ResolveContainerRuntime", func() {
+	It("should use the given engine without probing", func() {
+		fakeRuntimes()
+		Expect(ResolveContainerRuntime("podman", []string{"docker"})).To(Equal("podman"))
+	})
+
+	It("should respect the engine preference when both are available", func() {
+		fakeRuntimes("docker", "podman")
+		Expect(ResolveContainerRuntime("", []string{"podman", "docker"})).To(Equal("podman"))
+		Expect(ResolveContainerRuntime("", []string{"docker", "podman"})).To(Equal("docker"))
+	})
+
+	It("should skip unavailable engines and default to docker first", func() {
+		fakeRuntimes("podman")
+		Expect(ResolveContainerRuntime("", []string{"docker", "podman"})).To(Equal("podman"))
+
+		fakeRuntimes("docker", "podman")
+		Expect(ResolveContainerRuntime("", nil)).To(Equal("docker"))
+	})
+})
+
+var _ = Describe("RuntimeStartHint", func() {
+	installed := func(names ...string) func(string) bool {
+		return func(name string) bool {
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	It("should point at colima on macOS when it is installed", func() {
+		Expect(runtimeStartHint("docker", true, installed("colima", "/Applications/Docker.app"))).To(ContainSubstring("colima start"))
+	})
+
+	It("should name the installed desktop app on macOS", func() {
+		Expect(runtimeStartHint("docker", true, installed("/Applications/Rancher Desktop.app"))).To(ContainSubstring("Start Rancher Desktop"))
+		Expect(runtimeStartHint("docker", true, installed())).To(ContainSubstring("brew install colima"))
+	})
+
+	It("should give the Linux and Podman instructions", func() {
+		Expect(runtimeStartHint("docker", false, installed("colima"))).To(ContainSubstring("systemctl start docker"))
+		Expect(runtimeStartHint("podman", true, installed())).To(ContainSubstring("podman machine start"))
+	})
+})
@@ -0,0 +1,298 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+	utilexec "github.com/day0ops/lok8s/pkg/util/exec"
+)
+
+// networkInspect holds the subnets of a network as reported by `network inspect`. Docker lists them under
+// IPAM.Config while Podman lists them under subnets, the JSON decoder matches both regardless of case
+type networkInspect struct {
+	Name        string
+	Labels      map[string]string
+	EnableIPv6  bool
+	IPv6Enabled bool `json:"ipv6_enabled"` // podman
+	IPAM        struct {
+		Config []struct {
+			Subnet  string
+			Gateway string
+		}
+	}
+	Subnets []struct {
+		Subnet  string
+		Gateway string
+	}
+}
+
+// ipv4Subnet returns the first IPv4 subnet of the network and its gateway
+func (n networkInspect) ipv4Subnet() (string, string) {
+	for _, config := range n.IPAM.Config {
+		if ip, _, err := net.ParseCIDR(config.Subnet); err == nil && ip.To4() != nil {
+			return config.Subnet, config.Gateway
+		}
+	}
+	for _, subnet := range n.Subnets {
+		if ip, _, err := net.ParseCIDR(subnet.Subnet); err == nil && ip.To4() != nil {
+			return subnet.Subnet, subnet.Gateway
+		}
+	}
+	return "", ""
+}
+
+// Network is a Docker/Podman network with its IPv4 subnet and gateway
+type Network struct {
+	Name    string
+	Subnet  string
+	Gateway string
+	Labels  map[string]string
+}
+
+// network returns the network with its first IPv4 subnet
+func (n networkInspect) network() Network {
+	subnet, gateway := n.ipv4Subnet()
+	return Network{Name: n.Name, Subnet: subnet, Gateway: gateway, Labels: n.Labels}
+}
+
+// ListNetworks returns all Docker/Podman networks with their IPv4 subnet, gateway and labels
+func ListNetworks(runtime string) ([]Network, error) {
+	names, err := listNetworks(runtime)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	inspected, err := inspectNetworks(runtime, names...)
+	if err != nil {
+		return nil, err
+	}
+	networks := make([]Network, 0, len(inspected))
+	for _, network := range inspected {
+		networks = append(networks, network.network())
+	}
+	return networks, nil
+}
+
+// ipv6Subnet returns the first IPv6 subnet of the network, empty when IPv6 isn't enabled on it
+func (n networkInspect) ipv6Subnet() string {
+	if !n.EnableIPv6 && !n.IPv6Enabled {
+		return ""
+	}
+	for _, config := range n.IPAM.Config {
+		if ip, _, err := net.ParseCIDR(config.Subnet); err == nil && ip.To4() == nil {
+			return config.Subnet
+		}
+	}
+	for _, subnet := range n.Subnets {
+		if ip, _, err := net.ParseCIDR(subnet.Subnet); err == nil && ip.To4() == nil {
+			return subnet.Subnet
+		}
+	}
+	return ""
+}
+
+// NetworkExists checks if a Docker/Podman network with the given name exists
+func NetworkExists(runtime, networkName string) (bool, error) {
+	names, err := listNetworks(runtime)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		if name == networkName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteNetwork removes a Docker/Podman network, it fails while containers are still attached to it
+func DeleteNetwork(runtime, networkName string) error {
+	if err := utilexec.Run(runtime, "network", "rm", networkName); err != nil {
+		return fmt.Errorf("failed to delete network %s: %w", networkName, err)
+	}
+	return nil
+}
+
+// GetNetworkSubnet gets the IPv4 subnet and gateway IP of an existing Docker/Podman network
+func GetNetworkSubnet(runtime, networkName string) (string, string, error) {
+	networks, err := inspectNetworks(runtime, networkName)
+	if err != nil {
+		return "", "", err
+	}
+	if len(networks) == 0 {
+		return "", "", fmt.Errorf("network %s not found", networkName)
+	}
+
+	subnet, gateway := networks[0].ipv4Subnet()
+	if subnet == "" {
+		return "", "", fmt.Errorf("IPv4 subnet not found for network %s", networkName)
+	}
+	return subnet, gateway, nil
+}
+
+// GetNetworkIPv6Subnet gets the IPv6 subnet of an existing Docker/Podman network, empty when the network
+// was created without IPv6
+func GetNetworkIPv6Subnet(runtime, networkName string) (string, error) {
+	networks, err := inspectNetworks(runtime, networkName)
+	if err != nil {
+		return "", err
+	}
+	if len(networks) == 0 {
+		return "", fmt.Errorf("network %s not found", networkName)
+	}
+	return networks[0].ipv6Subnet(), nil
+}
+
+// FindFreeDockerSubnet finds a free subnet starting from the given subnet by checking the subnets of existing
+// Docker/Podman networks. Returns the CIDR of the free subnet found, or error if none found
+func FindFreeDockerSubnet(runtime, startSubnet string, step, tries int) (string, error) {
+	taken, err := networkSubnets(runtime)
+	if err != nil {
+		// error checking (e.g., runtime not available), assume subnet is free
+		logger.Debugf("could not check subnet %s, assuming free: %v", startSubnet, err)
+		return startSubnet, nil
+	}
+	return findFreeSubnet(startSubnet, step, tries, taken)
+}
+
+// findFreeSubnet advances startSubnet by step until it no longer overlaps any of the taken subnets
+func findFreeSubnet(startSubnet string, step, tries int, taken []*net.IPNet) (string, error) {
+	currSubnet := startSubnet
+	for try := 0; try < tries; try++ {
+		_, ipNet, err := net.ParseCIDR(currSubnet)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse subnet %s: %w", currSubnet, err)
+		}
+
+		overlap := overlappingSubnet(ipNet, taken)
+		if overlap == nil {
+			logger.Debugf("found free subnet %s", currSubnet)
+			return currSubnet, nil
+		}
+		logger.Debugf("subnet %s is taken: overlaps with %s", currSubnet, overlap)
+
+		// calculate next subnet to try
+		prefix, _ := ipNet.Mask.Size()
+		nextIP := ipNet.IP.To4()
+		if nextIP == nil {
+			return "", fmt.Errorf("invalid IPv4 subnet: %s", currSubnet)
+		}
+
+		// stop rather than wrap the octet around into an unrelated range
+		octet := 2
+		if prefix <= 16 {
+			octet = 1
+		}
+		if int(nextIP[octet])+step > 255 {
+			return "", fmt.Errorf("no free subnet found after %d tries starting from %s, ran out of addresses after %s", try+1, startSubnet, currSubnet)
+		}
+		nextIP[octet] += byte(step)
+
+		currSubnet = fmt.Sprintf("%s/%d", nextIP.String(), prefix)
+	}
+
+	return "", fmt.Errorf("no free subnet found after %d tries starting from %s", tries, startSubnet)
+}
+
+// overlappingSubnet returns the first of the taken subnets that overlaps ipNet, or nil if there is none
+func overlappingSubnet(ipNet *net.IPNet, taken []*net.IPNet) *net.IPNet {
+	for _, other := range taken {
+		if ipNet.Contains(other.IP) || other.Contains(ipNet.IP) {
+			return other
+		}
+	}
+	return nil
+}
+
+// listNetworks returns the names of all Docker/Podman networks
+func listNetworks(runtime string) ([]string, error) {
+	output, err := utilexec.Output(runtime, "network", "ls", "--format", "{{.Name}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var names []string
+	for _, name := range strings.Split(string(output), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// networkSubnets returns the IPv4 subnets used by all Docker/Podman networks
+func networkSubnets(runtime string) ([]*net.IPNet, error) {
+	names, err := listNetworks(runtime)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	networks, err := inspectNetworks(runtime, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnets []*net.IPNet
+	for _, network := range networks {
+		var cidrs []string
+		for _, config := range network.IPAM.Config {
+			cidrs = append(cidrs, config.Subnet)
+		}
+		for _, subnet := range network.Subnets {
+			cidrs = append(cidrs, subnet.Subnet)
+		}
+		for _, cidr := range cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil || ipNet.IP.To4() == nil {
+				continue
+			}
+			subnets = append(subnets, ipNet)
+		}
+	}
+	return subnets, nil
+}
+
+// inspectNetworks returns the inspect output of the given Docker/Podman networks
+func inspectNetworks(runtime string, names ...string) ([]networkInspect, error) {
+	output, err := utilexec.Output(runtime, append([]string{"network", "inspect"}, names...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect networks: %w", err)
+	}
+
+	var networks []networkInspect
+	if err := json.Unmarshal(output, &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse network info: %w", err)
+	}
+	return networks, nil
+}
@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"encoding/json"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Subnet", func() {
+	parseCIDRs := func(cidrs ...string) []*net.IPNet {
+		var ipNets []*net.IPNet
+		for _, cidr := range cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			Expect(err).NotTo(HaveOccurred())
+			ipNets = append(ipNets, ipNet)
+		}
+		return ipNets
+	}
+
+	It("should keep the subnet when nothing overlaps", func() {
+		subnet, err := findFreeSubnet("10.89.0.0/16", 1, 50, parseCIDRs("172.17.0.0/16", "10.88.0.0/16"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnet).To(Equal("10.89.0.0/16"))
+	})
+
+	It("should skip subnets used by other networks", func() {
+		subnet, err := findFreeSubnet("10.89.0.0/16", 1, 50, parseCIDRs("10.89.0.0/16", "10.90.5.0/24"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnet).To(Equal("10.91.0.0/16"))
+	})
+
+	It("should advance the third octet of smaller subnets", func() {
+		subnet, err := findFreeSubnet("192.168.50.0/24", 2, 50, parseCIDRs("192.168.0.0/20"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnet).To(Equal("192.168.50.0/24"))
+
+		subnet, err = findFreeSubnet("192.168.10.0/24", 2, 50, parseCIDRs("192.168.0.0/20"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnet).To(Equal("192.168.16.0/24"))
+	})
+
+	It("should give up once the tries are exhausted", func() {
+		_, err := findFreeSubnet("10.89.0.0/16", 1, 2, parseCIDRs("10.89.0.0/16", "10.90.0.0/16"))
+		Expect(err).To(MatchError("no free subnet found after 2 tries starting from 10.89.0.0/16"))
+	})
+
+	It("should not wrap around into an unrelated range", func() {
+		_, err := findFreeSubnet("10.255.0.0/16", 1, 50, parseCIDRs("10.255.0.0/16"))
+		Expect(err).To(MatchError(ContainSubstring("ran out of addresses")))
+	})
+	It("should read the IPv4 subnet of Docker and Podman networks", func() {
+		var networks []networkInspect
+		Expect(json.Unmarshal([]byte(`[
+			{"Name": "kind", "IPAM": {"Config": [{"Subnet": "fc00:f853:ccd:e793::/64"}, {"Subnet": "10.90.0.0/16", "Gateway": "10.90.0.1"}]}},
+			{"name": "kind", "subnets": [{"subnet": "10.91.0.0/16", "gateway": "10.91.0.1"}]}
+		]`), &networks)).To(Succeed())
+
+		subnet, gateway := networks[0].ipv4Subnet()
+		Expect(subnet).To(Equal("10.90.0.0/16"))
+		Expect(gateway).To(Equal("10.90.0.1"))
+
+		subnet, gateway = networks[1].ipv4Subnet()
+		Expect(subnet).To(Equal("10.91.0.0/16"))
+		Expect(gateway).To(Equal("10.91.0.1"))
+	})
+
+	It("should read the IPv6 subnet of networks with IPv6 enabled", func() {
+		var networks []networkInspect
+		Expect(json.Unmarshal([]byte(`[
+			{"Name": "kind", "EnableIPv6": true, "IPAM": {"Config": [{"Subnet": "10.90.0.0/16"}, {"Subnet": "fc00:f853:ccd:e793::/64"}]}},
+			{"name": "kind", "ipv6_enabled": true, "subnets": [{"subnet": "10.91.0.0/16"}, {"subnet": "fd00:10:90::/64"}]},
+			{"Name": "kind", "EnableIPv6": false, "IPAM": {"Config": [{"Subnet": "10.90.0.0/16"}]}}
+		]`), &networks)).To(Succeed())
+
+		Expect(networks[0].ipv6Subnet()).To(Equal("fc00:f853:ccd:e793::/64"))
+		Expect(networks[1].ipv6Subnet()).To(Equal("fd00:10:90::/64"))
+		Expect(networks[2].ipv6Subnet()).To(BeEmpty())
+	})
+
+	It("should read the labels of Docker and Podman networks", func() {
+		var networks []networkInspect
+		Expect(json.Unmarshal([]byte(`[
+			{"Name": "kind", "Labels": {"lok8s.managed": "true"}, "IPAM": {"Config": [{"Subnet": "10.90.0.0/16", "Gateway": "10.90.0.1"}]}},
+			{"name": "podman", "labels": {"lok8s.managed": "true"}, "subnets": [{"subnet": "10.88.0.0/16", "gateway": "10.88.0.1"}]}
+		]`), &networks)).To(Succeed())
+
+		Expect(networks[0].network()).To(Equal(Network{Name: "kind", Subnet: "10.90.0.0/16", Gateway: "10.90.0.1", Labels: map[string]string{"lok8s.managed": "true"}}))
+		Expect(networks[1].network()).To(Equal(Network{Name: "podman", Subnet: "10.88.0.0/16", Gateway: "10.88.0.1", Labels: map[string]string{"lok8s.managed": "true"}}))
+	})
+})
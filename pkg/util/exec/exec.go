@@ -0,0 +1,96 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is how long a command may run before it is killed, long enough for a loaded daemon to answer
+// but short enough that a wedged one doesn't hang the tool
+const DefaultTimeout = 30 * time.Second
+
+// waitDelay bounds the wait for output pipes held open by children of a killed command
+const waitDelay = 5 * time.Second
+
+// timeout is the timeout applied to the commands, overridden by tests
+var timeout = DefaultTimeout
+
+// ErrTimeout is wrapped by the error of a command killed once the timeout elapsed
+var ErrTimeout = errors.New("command timed out")
+
+// Error is returned by a command that failed, carrying what it wrote to stderr
+type Error struct {
+	Stderr string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Run runs a command, killing it once the default timeout elapses
+func Run(name string, args ...string) error {
+	_, err := OutputWithEnv(nil, name, args...)
+	return err
+}
+
+// Output runs a command, killing it once the default timeout elapses, and returns its stdout
+func Output(name string, args ...string) ([]byte, error) {
+	return OutputWithEnv(nil, name, args...)
+}
+
+// OutputWithEnv runs a command with the given environment like Output, a nil env keeps the current one.
+// The output written before a failure is returned along with the error
+func OutputWithEnv(env []string, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.WaitDelay = waitDelay
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %s: %s %s", ErrTimeout, timeout, name, strings.Join(args, " "))
+		}
+		return output, &Error{Stderr: strings.TrimSpace(stderr.String()), Err: err}
+	}
+	return output, nil
+}
@@ -0,0 +1,49 @@
+package exec
+
+import (
+	"errors"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exec", func() {
+	It("should return the output of a command", func() {
+		output, err := Output("sh", "-c", "echo hello")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal("hello\n"))
+	})
+
+	It("should carry stderr and the exit code of a failed command", func() {
+		output, err := Output("sh", "-c", "echo partial; echo 'no such container' >&2; exit 3")
+		Expect(string(output)).To(Equal("partial\n"))
+		Expect(err).To(MatchError("exit status 3: no such container"))
+
+		var execErr *Error
+		Expect(errors.As(err, &execErr)).To(BeTrue())
+		Expect(execErr.Stderr).To(Equal("no such container"))
+
+		var exitErr *exec.ExitError
+		Expect(errors.As(err, &exitErr)).To(BeTrue())
+		Expect(exitErr.ExitCode()).To(Equal(3))
+	})
+
+	It("should kill a command once the timeout elapses", func() {
+		timeout = 100 * time.Millisecond
+		DeferCleanup(func() { timeout = DefaultTimeout })
+
+		start := time.Now()
+		err := Run("sleep", "10")
+		Expect(err).To(MatchError(ErrTimeout))
+		Expect(err).To(MatchError(ContainSubstring("sleep 10")))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should run a command with the given environment", func() {
+		output, err := OutputWithEnv([]string{"LOK8S_TEST=set"}, "sh", "-c", "echo $LOK8S_TEST")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal("set\n"))
+	})
+})
@@ -0,0 +1,124 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// FetchChecksum fetches a published checksums file and returns the SHA256 checksum for assetName.
+// both "hash  filename" listings and single hash files (e.g. asset.sha256) are supported
+func (gc *GitHubClient) FetchChecksum(checksumsURL, assetName string) (string, error) {
+	logger.Debugf("fetching checksums from: %s", checksumsURL)
+
+	resp, err := gc.Get(checksumsURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksums file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch checksums file, status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksums content: %w", err)
+	}
+
+	return ParseChecksum(string(body), assetName)
+}
+
+// ParseChecksum finds the checksum for assetName in the content of a checksums file
+func ParseChecksum(checksums, assetName string) (string, error) {
+	var entries [][]string
+	for _, line := range strings.Split(checksums, "\n") {
+		if parts := strings.Fields(line); len(parts) > 0 {
+			entries = append(entries, parts)
+		}
+	}
+
+	// a file holding just the hash belongs to the asset it was published alongside
+	if len(entries) == 1 && len(entries[0]) == 1 {
+		logger.Debugf("found expected checksum for %s: %s", assetName, entries[0][0])
+		return entries[0][0], nil
+	}
+
+	// checksum format: "hash filename", where binary mode prefixes the filename with '*'
+	for _, parts := range entries {
+		if len(parts) >= 2 && strings.TrimPrefix(parts[1], "*") == assetName {
+			logger.Debugf("found expected checksum for %s: %s", assetName, parts[0])
+			return parts[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum not found for binary %s", assetName)
+}
+
+// FileSHA256 calculates the SHA256 checksum of a file
+func FileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to calculate hash: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	logger.Debugf("calculated checksum for %s: %s", filePath, checksum)
+	return checksum, nil
+}
+
+// VerifyChecksum verifies filePath against the checksum published for assetName at checksumsURL
+func (gc *GitHubClient) VerifyChecksum(checksumsURL, assetName, filePath string) error {
+	logger.Debugf("verifying checksum for %s", filePath)
+
+	expectedChecksum, err := gc.FetchChecksum(checksumsURL, assetName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch expected checksum: %w", err)
+	}
+
+	actualChecksum, err := FileSHA256(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to calculate file checksum: %w", err)
+	}
+
+	if !strings.EqualFold(actualChecksum, expectedChecksum) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, actualChecksum)
+	}
+
+	logger.Debugf("checksum verification passed")
+	return nil
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checksums", func() {
+	// sha256 of "Hello, World!"
+	const helloChecksum = "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f"
+
+	Describe("ParseChecksum", func() {
+		It("should find the asset in a checksums listing", func() {
+			checksums := "aaa  other.tar.gz\nbbb  asset.tar.gz\n"
+			checksum, err := ParseChecksum(checksums, "asset.tar.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal("bbb"))
+		})
+
+		It("should handle binary mode filenames", func() {
+			checksum, err := ParseChecksum("ccc *asset.tar.gz\n", "asset.tar.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal("ccc"))
+		})
+
+		It("should accept a file containing only the hash", func() {
+			checksum, err := ParseChecksum("ddd\n", "minikube-linux-amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal("ddd"))
+		})
+
+		It("should return an error when the asset is missing", func() {
+			_, err := ParseChecksum("aaa  other.tar.gz\nbbb  another.tar.gz\n", "asset.tar.gz")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("checksum not found"))
+		})
+	})
+
+	Describe("VerifyChecksum", func() {
+		var (
+			client   *GitHubClient
+			server   *httptest.Server
+			filePath string
+		)
+
+		BeforeEach(func() {
+			client = NewGitHubClient()
+			filePath = filepath.Join(GinkgoT().TempDir(), "asset.tar.gz")
+			Expect(os.WriteFile(filePath, []byte("Hello, World!"), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			if server != nil {
+				server.Close()
+			}
+		})
+
+		serve := func(code int, body string) {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+				w.Write([]byte(body))
+			}))
+		}
+
+		It("should calculate the file checksum", func() {
+			checksum, err := FileSHA256(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal(helloChecksum))
+		})
+
+		It("should pass when the checksum matches", func() {
+			serve(http.StatusOK, helloChecksum+"  asset.tar.gz\n")
+			Expect(client.VerifyChecksum(server.URL, "asset.tar.gz", filePath)).To(Succeed())
+		})
+
+		It("should fail when the checksum does not match", func() {
+			serve(http.StatusOK, "0000  asset.tar.gz\n")
+			err := client.VerifyChecksum(server.URL, "asset.tar.gz", filePath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("checksum mismatch"))
+		})
+
+		It("should fail when the checksums file is unavailable", func() {
+			serve(http.StatusNotFound, "")
+			err := client.VerifyChecksum(server.URL, "asset.tar.gz", filePath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to fetch checksums file"))
+		})
+	})
+})
@@ -28,6 +28,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,33 +45,144 @@ type GitHubRelease struct {
 	} `json:"assets"`
 }
 
+const (
+	// defaultMaxAttempts is the number of times a release lookup is attempted before giving up
+	defaultMaxAttempts = 4
+	// defaultInitialBackoff is the delay before the first retry, doubled on each subsequent retry
+	defaultInitialBackoff = 2 * time.Second
+	// defaultMaxBackoff caps the delay between retries, including server requested delays
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// tokenEnvVars are the environment variables checked, in order, for a GitHub token
+var tokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
 // GitHubClient handles GitHub API interactions
 type GitHubClient struct {
-	client  *http.Client
-	baseURL string
+	client         *http.Client
+	baseURL        string
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	token          string
+}
+
+// retryableError is a release lookup failure worth retrying, with an optional server requested delay
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
 }
 
 // NewGitHubClient creates a new GitHub client
 func NewGitHubClient() *GitHubClient {
 	return &GitHubClient{
-		client:  &http.Client{Timeout: 30 * time.Second}, // increased timeout for API calls
-		baseURL: "https://api.github.com",
+		client:         &http.Client{Timeout: 30 * time.Second}, // increased timeout for API calls
+		baseURL:        "https://api.github.com",
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		token:          tokenFromEnv(),
+	}
+}
+
+// tokenFromEnv returns the first GitHub token found in the environment, or an empty string
+func tokenFromEnv() string {
+	for _, envVar := range tokenEnvVars {
+		if token := strings.TrimSpace(os.Getenv(envVar)); token != "" {
+			logger.Debugf("using GitHub token from %s", envVar)
+			return token
+		}
 	}
+	return ""
 }
 
-// GetLatestRelease fetches the latest release for a given repository
+// Get performs a GET request, authenticated with the GitHub token when one is configured
+func (gc *GitHubClient) Get(url string) (*http.Response, error) {
+	return gc.get(gc.client, url)
+}
+
+// get performs a GET request with the given http client, adding the bearer token when set.
+// the header is dropped by net/http when a release download redirects to another host
+func (gc *GitHubClient) get(client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if gc.token != "" {
+		req.Header.Set("Authorization", "Bearer "+gc.token)
+	}
+	return client.Do(req)
+}
+
+// SetMaxAttempts sets how many times release lookups are attempted, values below 1 are ignored
+func (gc *GitHubClient) SetMaxAttempts(attempts int) {
+	if attempts < 1 {
+		return
+	}
+	gc.maxAttempts = attempts
+}
+
+// GetLatestRelease fetches the latest release for a given repository, retrying transient
+// failures and rate limiting with exponential backoff
 func (gc *GitHubClient) GetLatestRelease(owner, repo string) (*GitHubRelease, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", gc.baseURL, owner, repo)
 
+	backoff := gc.initialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= gc.maxAttempts; attempt++ {
+		release, err := gc.fetchLatestRelease(url)
+		if err == nil {
+			return release, nil
+		}
+		lastErr = err
+
+		retryErr, ok := err.(*retryableError)
+		if !ok || attempt == gc.maxAttempts {
+			break
+		}
+
+		wait := backoff
+		if retryErr.retryAfter > 0 {
+			wait = retryErr.retryAfter
+		}
+		if wait > gc.maxBackoff {
+			// no point blocking until a far away rate limit reset, let the caller fall back
+			logger.Debugf("not retrying %s, server requested a wait of %v", url, wait)
+			break
+		}
+
+		logger.Debugf("latest release lookup failed (attempt %d/%d): %v, retrying in %v", attempt, gc.maxAttempts, err, wait)
+		time.Sleep(wait)
+		backoff *= 2
+	}
+
+	return nil, lastErr
+}
+
+// fetchLatestRelease performs a single latest release lookup
+func (gc *GitHubClient) fetchLatestRelease(url string) (*GitHubRelease, error) {
 	logger.Debugf("fetching latest release from: %s", url)
-	resp, err := gc.client.Get(url)
+	resp, err := gc.Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+		return nil, &retryableError{err: fmt.Errorf("failed to fetch latest release: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch latest release: HTTP %d", resp.StatusCode)
+		err := fmt.Errorf("failed to fetch latest release: HTTP %d", resp.StatusCode)
+		if isRetryableStatus(resp) {
+			return nil, &retryableError{err: err, retryAfter: retryAfter(resp, time.Now())}
+		}
+		return nil, err
 	}
 
 	var release GitHubRelease
@@ -82,6 +194,42 @@ func (gc *GitHubClient) GetLatestRelease(owner, repo string) (*GitHubRelease, er
 	return &release, nil
 }
 
+// isRetryableStatus reports whether a response indicates rate limiting or a transient server error
+func isRetryableStatus(resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		// github signals primary rate limiting with a 403 and no remaining requests
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After or X-RateLimit-Reset headers, or zero
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil && date.After(now) {
+			return date.Sub(now)
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if resetAt := time.Unix(reset, 0); resetAt.After(now) {
+				return resetAt.Sub(now)
+			}
+		}
+	}
+
+	return 0
+}
+
 // GetLatestVersion fetches the latest version tag for a given repository
 func (gc *GitHubClient) GetLatestVersion(owner, repo string) (string, error) {
 	release, err := gc.GetLatestRelease(owner, repo)
@@ -116,7 +264,7 @@ func (gc *GitHubClient) DownloadBinary(downloadURL, outputPath string) error {
 			time.Sleep(backoff)
 		}
 
-		resp, err := downloadClient.Get(downloadURL)
+		resp, err := gc.get(downloadClient, downloadURL)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to download binary: %w", err)
 			continue
@@ -0,0 +1,191 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitHubClient", func() {
+	var (
+		client   *GitHubClient
+		server   *httptest.Server
+		requests int32
+	)
+
+	// newServer serves the given handlers in order, repeating the last one
+	newServer := func(handlers ...http.HandlerFunc) {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := int(atomic.AddInt32(&requests, 1))
+			if n > len(handlers) {
+				n = len(handlers)
+			}
+			handlers[n-1](w, r)
+		}))
+		client.baseURL = server.URL
+	}
+
+	release := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.2.3"}`))
+	}
+
+	status := func(code int, headers map[string]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(code)
+		}
+	}
+
+	BeforeEach(func() {
+		requests = 0
+		client = NewGitHubClient()
+		client.initialBackoff = time.Millisecond
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	Describe("GetLatestVersion", func() {
+		It("should return the version without the v prefix", func() {
+			newServer(release)
+
+			version, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("1.2.3"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("should retry transient server errors", func() {
+			newServer(status(http.StatusBadGateway, nil), status(http.StatusServiceUnavailable, nil), release)
+
+			version, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("1.2.3"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+		})
+
+		It("should retry rate limited responses honoring Retry-After", func() {
+			newServer(status(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}), release)
+
+			version, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("1.2.3"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+		})
+
+		It("should not retry non transient errors", func() {
+			newServer(status(http.StatusNotFound, nil))
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("HTTP 404"))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("should give up after the configured number of attempts", func() {
+			client.SetMaxAttempts(2)
+			newServer(status(http.StatusInternalServerError, nil))
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).To(HaveOccurred())
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+		})
+
+		It("should not wait for a rate limit reset beyond the maximum backoff", func() {
+			reset := time.Now().Add(time.Hour).Unix()
+			newServer(status(http.StatusForbidden, map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+			}), release)
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).To(HaveOccurred())
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+	})
+
+	Describe("Authentication", func() {
+		var authHeader string
+
+		capture := func(w http.ResponseWriter, r *http.Request) {
+			authHeader = r.Header.Get("Authorization")
+			release(w, r)
+		}
+
+		BeforeEach(func() {
+			authHeader = ""
+		})
+
+		It("should send a bearer token from GITHUB_TOKEN", func() {
+			GinkgoT().Setenv("GITHUB_TOKEN", "abc123")
+			GinkgoT().Setenv("GH_TOKEN", "ignored")
+			client = NewGitHubClient()
+			newServer(capture)
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authHeader).To(Equal("Bearer abc123"))
+		})
+
+		It("should fall back to GH_TOKEN", func() {
+			GinkgoT().Setenv("GITHUB_TOKEN", "")
+			GinkgoT().Setenv("GH_TOKEN", "def456")
+			client = NewGitHubClient()
+			newServer(capture)
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authHeader).To(Equal("Bearer def456"))
+		})
+
+		It("should not send an Authorization header without a token", func() {
+			GinkgoT().Setenv("GITHUB_TOKEN", "")
+			GinkgoT().Setenv("GH_TOKEN", "")
+			client = NewGitHubClient()
+			newServer(capture)
+
+			_, err := client.GetLatestVersion("owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authHeader).To(BeEmpty())
+		})
+	})
+
+	Describe("SetMaxAttempts", func() {
+		It("should ignore values below 1", func() {
+			client.SetMaxAttempts(0)
+			Expect(client.maxAttempts).To(Equal(defaultMaxAttempts))
+		})
+	})
+
+	Describe("retryAfter", func() {
+		now := time.Unix(1000, 0)
+
+		It("should parse Retry-After seconds", func() {
+			resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+			Expect(retryAfter(resp, now)).To(Equal(5 * time.Second))
+		})
+
+		It("should use X-RateLimit-Reset when the rate limit is exhausted", func() {
+			resp := &http.Response{Header: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{"1010"},
+			}}
+			Expect(retryAfter(resp, now)).To(Equal(10 * time.Second))
+		})
+
+		It("should return zero without rate limit headers", func() {
+			resp := &http.Response{Header: http.Header{}}
+			Expect(retryAfter(resp, now)).To(BeZero())
+		})
+	})
+})
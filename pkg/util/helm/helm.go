@@ -33,13 +33,16 @@ import (
 	"github.com/day0ops/lok8s/pkg/logger"
 	"github.com/day0ops/lok8s/pkg/util"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -47,13 +50,27 @@ import (
 type HelmManager struct {
 	kubeconfigPath string
 	settings       *cli.EnvSettings
+	valueOverrides map[string]map[string]interface{} // release name -> values merged over the built-in ones
+	ctx            context.Context                   // cancels installs and upgrades, nil for none
+}
+
+// SetContext sets the context installs, upgrades and renders of this manager are cancelled with
+func (hm *HelmManager) SetContext(ctx context.Context) {
+	hm.ctx = ctx
+}
+
+// context returns the context Helm actions run with, a background context when none was set
+func (hm *HelmManager) context() context.Context {
+	if hm.ctx == nil {
+		return context.Background()
+	}
+	return hm.ctx
 }
 
 // NewHelmManager creates a new Helm manager
 func NewHelmManager(kubeconfigPath string) *HelmManager {
 	settings := cli.New()
-	// set kubeconfig path via environment variable
-	os.Setenv("KUBECONFIG", kubeconfigPath)
+	settings.KubeConfig = kubeconfigPath
 
 	return &HelmManager{
 		kubeconfigPath: kubeconfigPath,
@@ -61,6 +78,12 @@ func NewHelmManager(kubeconfigPath string) *HelmManager {
 	}
 }
 
+// knownRepositories maps repository names to URLs for charts rendered via TemplateChart
+var knownRepositories = map[string]string{
+	"cilium":        "https://helm.cilium.io/",
+	"projectcalico": "https://docs.tigera.io/calico/charts",
+}
+
 // AddRepository adds a Helm repository
 func (hm *HelmManager) AddRepository(name, url string) error {
 	logger.Debugf("adding Helm repository: %s -> %s", name, url)
@@ -108,23 +131,24 @@ func (hm *HelmManager) ListRepositories() ([]*repo.Entry, error) {
 	return repos, nil
 }
 
-// InstallChart installs a Helm chart
-func (hm *HelmManager) InstallChart(releaseName, chartName, namespace string, values map[string]interface{}, timeout time.Duration) error {
-	logger.Debugf("installing Helm chart: %s/%s in namespace %s", chartName, releaseName, namespace)
+// InstallChart installs a Helm chart into the cluster of the given kubeconfig context, an empty version installs the latest chart
+func (hm *HelmManager) InstallChart(contextName, releaseName, chartName, version, namespace string, values map[string]interface{}, timeout time.Duration) error {
+	logger.Debugf("installing Helm chart: %s/%s in namespace %s of context %s", chartName, releaseName, namespace, contextName)
+	values = hm.withOverrides(releaseName, values)
 
 	// Check if release already exists
-	exists, err := hm.ReleaseExists(releaseName, namespace)
+	exists, err := hm.ReleaseExists(contextName, releaseName, namespace)
 	if err != nil {
 		return fmt.Errorf("failed to check if release exists: %w", err)
 	}
 
 	if exists {
 		logger.Debugf("release %s already exists, upgrading instead", releaseName)
-		return hm.UpgradeChart(releaseName, chartName, namespace, values, timeout)
+		return hm.UpgradeChart(contextName, releaseName, chartName, version, namespace, values, timeout)
 	}
 
 	// Create action configuration
-	actionConfig, err := hm.getActionConfig(namespace)
+	actionConfig, err := hm.getActionConfig(contextName, namespace)
 	if err != nil {
 		return fmt.Errorf("failed to get action config: %w", err)
 	}
@@ -136,36 +160,17 @@ func (hm *HelmManager) InstallChart(releaseName, chartName, namespace string, va
 	install.CreateNamespace = true
 	install.Timeout = timeout
 	install.Wait = true
+	install.ChartPathOptions.Version = version
 
 	// Get chart
-	chartPath, err := install.ChartPathOptions.LocateChart(chartName, hm.settings)
+	chart, err := hm.loadChart(&install.ChartPathOptions, chartName)
 	if err != nil {
-		return fmt.Errorf("failed to locate chart: %w", err)
+		return err
 	}
 
-	chart, err := loader.Load(chartPath)
+	// Install chart, progress such as waiting for pods is reported through the action config's debug log
+	release, err := install.RunWithContext(hm.context(), chart, values)
 	if err != nil {
-		return fmt.Errorf("failed to load chart: %w", err)
-	}
-
-	// Install chart
-	// Temporarily suppress stderr to avoid kubectl warnings interfering with spinner
-	originalStderr := os.Stderr
-	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
-	if err == nil {
-		os.Stderr = devNull
-		defer func() {
-			os.Stderr = originalStderr
-			devNull.Close()
-		}()
-	}
-
-	release, err := install.RunWithContext(context.Background(), chart, values)
-	if err != nil {
-		// Restore stderr before returning error so it can be displayed
-		if devNull != nil {
-			os.Stderr = originalStderr
-		}
 		return fmt.Errorf("failed to install chart: %w", err)
 	}
 
@@ -173,12 +178,13 @@ func (hm *HelmManager) InstallChart(releaseName, chartName, namespace string, va
 	return nil
 }
 
-// UpgradeChart upgrades a Helm chart
-func (hm *HelmManager) UpgradeChart(releaseName, chartName, namespace string, values map[string]interface{}, timeout time.Duration) error {
-	logger.Debugf("upgrading Helm chart: %s/%s in namespace %s", chartName, releaseName, namespace)
+// UpgradeChart upgrades a Helm chart in the cluster of the given kubeconfig context, an empty version upgrades to the latest chart
+func (hm *HelmManager) UpgradeChart(contextName, releaseName, chartName, version, namespace string, values map[string]interface{}, timeout time.Duration) error {
+	logger.Debugf("upgrading Helm chart: %s/%s in namespace %s of context %s", chartName, releaseName, namespace, contextName)
+	values = hm.withOverrides(releaseName, values)
 
 	// Create action configuration
-	actionConfig, err := hm.getActionConfig(namespace)
+	actionConfig, err := hm.getActionConfig(contextName, namespace)
 	if err != nil {
 		return fmt.Errorf("failed to get action config: %w", err)
 	}
@@ -188,36 +194,17 @@ func (hm *HelmManager) UpgradeChart(releaseName, chartName, namespace string, va
 	upgrade.Namespace = namespace
 	upgrade.Timeout = timeout
 	upgrade.Wait = true
+	upgrade.ChartPathOptions.Version = version
 
 	// Get chart
-	chartPath, err := upgrade.ChartPathOptions.LocateChart(chartName, hm.settings)
+	chart, err := hm.loadChart(&upgrade.ChartPathOptions, chartName)
 	if err != nil {
-		return fmt.Errorf("failed to locate chart: %w", err)
+		return err
 	}
 
-	chart, err := loader.Load(chartPath)
+	// Upgrade chart, progress such as waiting for pods is reported through the action config's debug log
+	release, err := upgrade.RunWithContext(hm.context(), releaseName, chart, values)
 	if err != nil {
-		return fmt.Errorf("failed to load chart: %w", err)
-	}
-
-	// Upgrade chart
-	// Temporarily suppress stderr to avoid kubectl warnings interfering with spinner
-	originalStderr := os.Stderr
-	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
-	if err == nil {
-		os.Stderr = devNull
-		defer func() {
-			os.Stderr = originalStderr
-			devNull.Close()
-		}()
-	}
-
-	release, err := upgrade.RunWithContext(context.Background(), releaseName, chart, values)
-	if err != nil {
-		// Restore stderr before returning error so it can be displayed
-		if devNull != nil {
-			os.Stderr = originalStderr
-		}
 		return fmt.Errorf("failed to upgrade chart: %w", err)
 	}
 
@@ -225,12 +212,12 @@ func (hm *HelmManager) UpgradeChart(releaseName, chartName, namespace string, va
 	return nil
 }
 
-// UninstallChart uninstalls a Helm chart
-func (hm *HelmManager) UninstallChart(releaseName, namespace string) error {
+// UninstallChart uninstalls a Helm chart from the cluster of the given kubeconfig context
+func (hm *HelmManager) UninstallChart(contextName, releaseName, namespace string) error {
 	logger.Debugf("uninstalling Helm chart: %s in namespace %s", releaseName, namespace)
 
 	// Create action configuration
-	actionConfig, err := hm.getActionConfig(namespace)
+	actionConfig, err := hm.getActionConfig(contextName, namespace)
 	if err != nil {
 		return fmt.Errorf("failed to get action config: %w", err)
 	}
@@ -248,10 +235,10 @@ func (hm *HelmManager) UninstallChart(releaseName, namespace string) error {
 	return nil
 }
 
-// ReleaseExists checks if a Helm release exists
-func (hm *HelmManager) ReleaseExists(releaseName, namespace string) (bool, error) {
+// ReleaseExists checks if a Helm release exists in the cluster of the given kubeconfig context
+func (hm *HelmManager) ReleaseExists(contextName, releaseName, namespace string) (bool, error) {
 	// Create action configuration
-	actionConfig, err := hm.getActionConfig(namespace)
+	actionConfig, err := hm.getActionConfig(contextName, namespace)
 	if err != nil {
 		return false, fmt.Errorf("failed to get action config: %w", err)
 	}
@@ -276,12 +263,12 @@ func (hm *HelmManager) ReleaseExists(releaseName, namespace string) (bool, error
 	return false, nil
 }
 
-// WaitForReleaseReady waits for a Helm release to be ready
-func (hm *HelmManager) WaitForReleaseReady(releaseName, namespace string, timeout time.Duration) error {
+// WaitForReleaseReady waits for a Helm release to be ready in the cluster of the given kubeconfig context
+func (hm *HelmManager) WaitForReleaseReady(contextName, releaseName, namespace string, timeout time.Duration) error {
 	logger.Debugf("waiting for Helm release %s to be ready in namespace %s", releaseName, namespace)
 
 	// Get Kubernetes client
-	client, err := hm.GetKubernetesClient()
+	client, err := hm.GetKubernetesClient(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to get Kubernetes client: %w", err)
 	}
@@ -289,7 +276,7 @@ func (hm *HelmManager) WaitForReleaseReady(releaseName, namespace string, timeou
 	// Wait for release to be ready using retry mechanism
 	return util.LocalRetry(func() error {
 		// Check if release exists and is deployed
-		exists, err := hm.ReleaseExists(releaseName, namespace)
+		exists, err := hm.ReleaseExists(contextName, releaseName, namespace)
 		if err != nil {
 			return fmt.Errorf("failed to check release existence: %w", err)
 		}
@@ -299,7 +286,7 @@ func (hm *HelmManager) WaitForReleaseReady(releaseName, namespace string, timeou
 		}
 
 		// Get release status
-		actionConfig, err := hm.getActionConfig(namespace)
+		actionConfig, err := hm.getActionConfig(contextName, namespace)
 		if err != nil {
 			return fmt.Errorf("failed to get action config: %w", err)
 		}
@@ -340,13 +327,55 @@ func (hm *HelmManager) WaitForReleaseReady(releaseName, namespace string, timeou
 	}, timeout)
 }
 
-// getActionConfig creates a Helm action configuration
-func (hm *HelmManager) getActionConfig(namespace string) (*action.Configuration, error) {
+// isLocalChart reports whether the chart name refers to a chart directory or archive on disk
+func isLocalChart(chartName string) bool {
+	_, err := os.Stat(chartName)
+	return err == nil
+}
+
+// loadChart loads a chart from disk when the chart name is a local path, otherwise it is resolved through
+// the configured repositories, so vendored charts can be installed without network access
+func (hm *HelmManager) loadChart(chartPathOptions *action.ChartPathOptions, chartName string) (*chart.Chart, error) {
+	chartPath := chartName
+	if isLocalChart(chartName) {
+		if chartPathOptions.Version != "" {
+			logger.Debugf("ignoring chart version %s for local chart %s", chartPathOptions.Version, chartName)
+		}
+	} else {
+		var err error
+		chartPath, err = chartPathOptions.LocateChart(chartName, hm.settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate chart: %w", err)
+		}
+	}
+
+	loadedChart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	return loadedChart, nil
+}
+
+// getActionConfig creates a Helm action configuration for the given kubeconfig context, an empty context
+// uses the current one
+func (hm *HelmManager) getActionConfig(contextName, namespace string) (*action.Configuration, error) {
 	actionConfig := new(action.Configuration)
 
-	// Initialize with settings
-	if err := actionConfig.Init(hm.settings.RESTClientGetter(), namespace, "secret", func(format string, v ...interface{}) {
-		logger.Debugf(format, v...)
+	// build the client getter explicitly rather than from the environment so that
+	// the target cluster never depends on which context happens to be active
+	restClientGetter := genericclioptions.NewConfigFlags(true)
+	restClientGetter.KubeConfig = &hm.kubeconfigPath
+	restClientGetter.Context = &contextName
+	restClientGetter.Namespace = &namespace
+	// API server warnings (e.g. deprecated APIs) would otherwise be printed over the spinner
+	restClientGetter.WrapConfigFn = func(config *rest.Config) *rest.Config {
+		config.WarningHandler = debugWarningHandler{}
+		return config
+	}
+
+	if err := actionConfig.Init(restClientGetter, namespace, "secret", func(format string, v ...interface{}) {
+		logger.Debugf("helm [%s]: %s", contextName, fmt.Sprintf(format, v...))
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
@@ -354,9 +383,23 @@ func (hm *HelmManager) getActionConfig(namespace string) (*action.Configuration,
 	return actionConfig, nil
 }
 
-// GetKubernetesClient creates a Kubernetes client
-func (hm *HelmManager) GetKubernetesClient() (*kubernetes.Clientset, error) {
-	config, err := clientcmd.BuildConfigFromFlags("", hm.kubeconfigPath)
+// debugWarningHandler logs API server warnings at debug level rather than to stderr
+type debugWarningHandler struct{}
+
+// HandleWarningHeader implements rest.WarningHandler
+func (debugWarningHandler) HandleWarningHeader(code int, agent string, message string) {
+	if code != 299 || message == "" {
+		return
+	}
+	logger.Debugf("kubernetes warning: %s", message)
+}
+
+// GetKubernetesClient creates a Kubernetes client for the given kubeconfig context
+func (hm *HelmManager) GetKubernetesClient(contextName string) (*kubernetes.Clientset, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: hm.kubeconfigPath},
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	).ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
@@ -369,10 +412,10 @@ func (hm *HelmManager) GetKubernetesClient() (*kubernetes.Clientset, error) {
 	return client, nil
 }
 
-// ListReleases lists all Helm releases
-func (hm *HelmManager) ListReleases(namespace string) ([]*release.Release, error) {
+// ListReleases lists the Helm releases of a namespace in the cluster of the given kubeconfig context
+func (hm *HelmManager) ListReleases(contextName, namespace string) ([]*release.Release, error) {
 	// Create action configuration
-	actionConfig, err := hm.getActionConfig(namespace)
+	actionConfig, err := hm.getActionConfig(contextName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get action config: %w", err)
 	}
@@ -390,27 +433,30 @@ func (hm *HelmManager) ListReleases(namespace string) ([]*release.Release, error
 	return releases, nil
 }
 
-// TemplateChart renders a Helm chart to Kubernetes manifests using the Helm library
-func (hm *HelmManager) TemplateChart(releaseName, chartName, namespace string, values map[string]interface{}) ([]byte, error) {
+// TemplateChart renders a Helm chart to Kubernetes manifests using the Helm library, an empty version renders the latest chart
+func (hm *HelmManager) TemplateChart(releaseName, chartName, version, namespace string, values map[string]interface{}) ([]byte, error) {
 	logger.Debugf("rendering Helm chart: %s/%s to manifests", chartName, releaseName)
-
-	// ensure repository is added and updated
-	// extract repo name from chart (e.g., "cilium/cilium" -> "cilium")
-	chartParts := strings.Split(chartName, "/")
-	if len(chartParts) != 2 {
-		return nil, fmt.Errorf("invalid chart name format, expected repo/chart: %s", chartName)
-	}
-	repoName := chartParts[0]
-
-	// add cilium repository if needed
-	if repoName == "cilium" {
-		if err := hm.AddRepository("cilium", "https://helm.cilium.io/"); err != nil {
-			return nil, fmt.Errorf("failed to add cilium repository: %w", err)
+	values = hm.withOverrides(releaseName, values)
+
+	// ensure repository is added and updated, local charts need neither
+	if !isLocalChart(chartName) {
+		// extract repo name from chart (e.g., "cilium/cilium" -> "cilium")
+		chartParts := strings.Split(chartName, "/")
+		if len(chartParts) != 2 {
+			return nil, fmt.Errorf("invalid chart name format, expected repo/chart: %s", chartName)
 		}
-		// update repository to ensure we have the latest chart
-		cmd := exec.Command("helm", "repo", "update", "cilium")
-		if err := cmd.Run(); err != nil {
-			return nil, fmt.Errorf("failed to update cilium repository: %w", err)
+		repoName := chartParts[0]
+
+		// add known repository if needed
+		if repoURL, ok := knownRepositories[repoName]; ok {
+			if err := hm.AddRepository(repoName, repoURL); err != nil {
+				return nil, fmt.Errorf("failed to add %s repository: %w", repoName, err)
+			}
+			// update repository to ensure we have the latest chart
+			cmd := exec.Command("helm", "repo", "update", repoName)
+			if err := cmd.Run(); err != nil {
+				return nil, fmt.Errorf("failed to update %s repository: %w", repoName, err)
+			}
 		}
 	}
 
@@ -433,6 +479,9 @@ func (hm *HelmManager) TemplateChart(releaseName, chartName, namespace string, v
 	install.DryRun = true
 	install.Replace = true
 	install.ClientOnly = true
+	install.ChartPathOptions.Version = version
+	// include CRDs so charts shipping them under crds/ render a complete manifest
+	install.IncludeCRDs = true
 
 	// dummy versioning so override the conditions in the charts
 	install.KubeVersion = &chartutil.KubeVersion{
@@ -442,18 +491,13 @@ func (hm *HelmManager) TemplateChart(releaseName, chartName, namespace string, v
 	}
 
 	// locate and load the chart
-	chartPath, err := install.ChartPathOptions.LocateChart(chartName, hm.settings)
-	if err != nil {
-		return nil, fmt.Errorf("failed to locate chart: %w", err)
-	}
-
-	chart, err := loader.Load(chartPath)
+	chart, err := hm.loadChart(&install.ChartPathOptions, chartName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load chart: %w", err)
+		return nil, err
 	}
 
 	// use install.Run to generate the manifest (this handles ordering automatically)
-	release, err := install.RunWithContext(context.Background(), chart, values)
+	release, err := install.RunWithContext(hm.context(), chart, values)
 	if err != nil {
 		return nil, fmt.Errorf("failed to template chart: %w", err)
 	}
@@ -0,0 +1,95 @@
+package helm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var _ = Describe("HelmManager", func() {
+	var kubeconfigPath string
+
+	BeforeEach(func() {
+		kubeconfigPath = filepath.Join(GinkgoT().TempDir(), "config")
+		GinkgoT().Setenv("KUBECONFIG", "/nonexistent")
+
+		kubeconfig := clientcmdapi.NewConfig()
+		for name, server := range map[string]string{"myproject-1": "https://172.18.0.2:6443", "myproject-2": "https://172.18.0.3:6443"} {
+			kubeconfig.Clusters[name] = &clientcmdapi.Cluster{Server: server}
+			kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+			kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+		}
+		kubeconfig.CurrentContext = "myproject-1"
+		Expect(clientcmd.WriteToFile(*kubeconfig, kubeconfigPath)).To(Succeed())
+	})
+
+	It("should not change the KUBECONFIG environment variable", func() {
+		NewHelmManager(kubeconfigPath)
+		Expect(os.Getenv("KUBECONFIG")).To(Equal("/nonexistent"))
+	})
+
+	It("should target the given context regardless of the current one", func() {
+		actionConfig, err := NewHelmManager(kubeconfigPath).getActionConfig("myproject-2", "metallb-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		restConfig, err := actionConfig.RESTClientGetter.ToRESTConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restConfig.Host).To(Equal("https://172.18.0.3:6443"))
+	})
+
+	It("should log API server warnings rather than print them to stderr", func() {
+		actionConfig, err := NewHelmManager(kubeconfigPath).getActionConfig("myproject-1", "metallb-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		restConfig, err := actionConfig.RESTClientGetter.ToRESTConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restConfig.WarningHandler).To(Equal(debugWarningHandler{}))
+	})
+
+	It("should fall back to the current context", func() {
+		actionConfig, err := NewHelmManager(kubeconfigPath).getActionConfig("", "metallb-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		restConfig, err := actionConfig.RESTClientGetter.ToRESTConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restConfig.Host).To(Equal("https://172.18.0.2:6443"))
+	})
+
+	It("should run the Helm actions with the given context", func() {
+		hm := NewHelmManager(kubeconfigPath)
+		Expect(hm.context()).To(Equal(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		hm.SetContext(ctx)
+		Expect(hm.context()).To(Equal(ctx))
+	})
+
+	It("should template a chart from a local directory", func() {
+		chartDir := filepath.Join(GinkgoT().TempDir(), "metallb")
+		Expect(os.MkdirAll(filepath.Join(chartDir, "templates"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("apiVersion: v2\nname: metallb\nversion: 0.15.2\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(chartDir, "values.yaml"), []byte("speaker:\n  memory: 100Mi\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(chartDir, "templates", "configmap.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-speaker
+  namespace: {{ .Release.Namespace }}
+data:
+  memory: {{ .Values.speaker.memory }}
+`), 0644)).To(Succeed())
+
+		manifest, err := NewHelmManager(kubeconfigPath).TemplateChart("metallb", chartDir, "", "metallb-system", map[string]interface{}{
+			"speaker": map[string]interface{}{"memory": "200Mi"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(manifest)).To(ContainSubstring("name: metallb-speaker"))
+		Expect(string(manifest)).To(ContainSubstring("namespace: metallb-system"))
+		Expect(string(manifest)).To(ContainSubstring("memory: 200Mi"))
+	})
+})
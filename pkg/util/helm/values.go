@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+
+	"github.com/day0ops/lok8s/pkg/logger"
+)
+
+// ParseValueOverrides parses release.key=value overrides (e.g. metallb.speaker.frr.enabled=true) into values
+// keyed by release name, using the same syntax as helm --set for the part after the release
+func ParseValueOverrides(sets []string) (map[string]map[string]interface{}, error) {
+	overrides := make(map[string]map[string]interface{})
+	for _, set := range sets {
+		release, value, ok := strings.Cut(set, ".")
+		if !ok || release == "" || strings.Contains(release, "=") {
+			return nil, fmt.Errorf("invalid Helm value override %q, expected release.key=value", set)
+		}
+
+		if overrides[release] == nil {
+			overrides[release] = make(map[string]interface{})
+		}
+		if err := strvals.ParseInto(value, overrides[release]); err != nil {
+			return nil, fmt.Errorf("invalid Helm value override %q: %w", set, err)
+		}
+	}
+	return overrides, nil
+}
+
+// ReadValuesFile reads a Helm values file
+func ReadValuesFile(path string) (map[string]interface{}, error) {
+	values, err := chartutil.ReadValuesFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Helm values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// LoadValueOverrides combines values files, keyed by release name, with release.key=value overrides.
+// The overrides are merged over the values files, so single keys of a file can still be changed
+func LoadValueOverrides(valuesFiles map[string]string, sets []string) (map[string]map[string]interface{}, error) {
+	overrides, err := ParseValueOverrides(sets)
+	if err != nil {
+		return nil, err
+	}
+
+	for release, path := range valuesFiles {
+		if path == "" {
+			continue
+		}
+		values, err := ReadValuesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		overrides[release] = MergeValues(values, overrides[release])
+	}
+	return overrides, nil
+}
+
+// MergeValues returns a copy of base with overrides merged on top. Nested maps are merged key by key
+// so base settings remain unless explicitly overridden, any other value is replaced
+func MergeValues(base, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[key] = MergeValues(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// SetValueOverrides sets the values, keyed by release name, merged over the built-in values of every
+// chart installed or rendered by this manager
+func (hm *HelmManager) SetValueOverrides(overrides map[string]map[string]interface{}) {
+	hm.valueOverrides = overrides
+}
+
+// withOverrides merges the value overrides for a release over its values
+func (hm *HelmManager) withOverrides(releaseName string, values map[string]interface{}) map[string]interface{} {
+	overrides, ok := hm.valueOverrides[releaseName]
+	if !ok {
+		return values
+	}
+	logger.Debugf("applying Helm value overrides to %s: %v", releaseName, overrides)
+	return MergeValues(values, overrides)
+}
@@ -0,0 +1,102 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Values", func() {
+	Describe("ParseValueOverrides", func() {
+		It("should group the overrides by release", func() {
+			overrides, err := ParseValueOverrides([]string{
+				"metallb.speaker.frr.enabled=true",
+				"metallb.speaker.resources.requests.memory=200Mi",
+				"cilium.hubble.relay.enabled=false",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides).To(Equal(map[string]map[string]interface{}{
+				"metallb": {
+					"speaker": map[string]interface{}{
+						"frr":       map[string]interface{}{"enabled": true},
+						"resources": map[string]interface{}{"requests": map[string]interface{}{"memory": "200Mi"}},
+					},
+				},
+				"cilium": {
+					"hubble": map[string]interface{}{"relay": map[string]interface{}{"enabled": false}},
+				},
+			}))
+		})
+
+		It("should reject overrides without a release", func() {
+			_, err := ParseValueOverrides([]string{"replicas=2"})
+			Expect(err).To(MatchError(ContainSubstring("expected release.key=value")))
+		})
+	})
+
+	Describe("LoadValueOverrides", func() {
+		writeValues := func(content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), "values.yaml")
+			Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+			return path
+		}
+
+		It("should merge the overrides over the values files", func() {
+			ciliumValues := writeValues("hubble:\n  enabled: true\n  relay:\n    enabled: true\nencryption:\n  enabled: true\n")
+
+			overrides, err := LoadValueOverrides(map[string]string{"cilium": ciliumValues, "metallb": ""}, []string{"cilium.hubble.relay.enabled=false"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides).To(Equal(map[string]map[string]interface{}{
+				"cilium": {
+					"hubble":     map[string]interface{}{"enabled": true, "relay": map[string]interface{}{"enabled": false}},
+					"encryption": map[string]interface{}{"enabled": true},
+				},
+			}))
+		})
+
+		It("should fail for a values file that doesn't parse", func() {
+			_, err := LoadValueOverrides(map[string]string{"metallb": writeValues("speaker: [unterminated\n")}, nil)
+			Expect(err).To(MatchError(ContainSubstring("failed to read Helm values file")))
+
+			_, err = LoadValueOverrides(map[string]string{"metallb": "/nonexistent/values.yaml"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("/nonexistent/values.yaml")))
+		})
+	})
+
+	Describe("MergeValues", func() {
+		It("should keep the base values that aren't overridden", func() {
+			base := map[string]interface{}{
+				"speaker": map[string]interface{}{
+					"resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "100m", "memory": "100Mi"}},
+				},
+				"controller": map[string]interface{}{"enabled": true},
+			}
+
+			merged := MergeValues(base, map[string]interface{}{
+				"speaker": map[string]interface{}{
+					"resources": map[string]interface{}{"requests": map[string]interface{}{"memory": "200Mi"}},
+				},
+			})
+			Expect(merged).To(Equal(map[string]interface{}{
+				"speaker": map[string]interface{}{
+					"resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "100m", "memory": "200Mi"}},
+				},
+				"controller": map[string]interface{}{"enabled": true},
+			}))
+
+			// the base values are left untouched
+			Expect(base["speaker"]).To(HaveKeyWithValue("resources", HaveKeyWithValue("requests", HaveKeyWithValue("memory", "100Mi"))))
+		})
+
+		It("should only apply the overrides of the release being installed", func() {
+			hm := NewHelmManager("")
+			hm.SetValueOverrides(map[string]map[string]interface{}{"cilium": {"hubble": map[string]interface{}{"enabled": true}}})
+
+			values := map[string]interface{}{"ipam": "kubernetes"}
+			Expect(hm.withOverrides("metallb", values)).To(Equal(values))
+			Expect(hm.withOverrides("cilium", values)).To(HaveKeyWithValue("hubble", map[string]interface{}{"enabled": true}))
+		})
+	})
+})
@@ -23,30 +23,48 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/day0ops/lok8s/pkg/logger"
 )
 
+// DefaultStorageClassAnnotation marks a StorageClass as the cluster default
+const DefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// ProjectNodeLabel is set on the nodes of every cluster lok8s creates to the project the cluster belongs to
+const ProjectNodeLabel = "lok8s.dev/project"
+
+// GPUResource is the extended resource pods request to be scheduled onto a GPU node
+const GPUResource corev1.ResourceName = "nvidia.com/gpu"
+
 // ClientManager manages Kubernetes client operations
 type ClientManager struct {
 	clientset     *kubernetes.Clientset
 	dynamicClient dynamic.Interface
 	config        *rest.Config
 	contextName   string
+	mapper        *restmapper.DeferredDiscoveryRESTMapper // created on first use, see restMapping
 }
 
 // NewClientManagerForContext creates a new Kubernetes client manager for a specific context
@@ -89,6 +107,35 @@ func (cm *ClientManager) GetConfig() *rest.Config {
 	return cm.config
 }
 
+// CountReadyNodes returns the number of ready nodes and the total number of nodes in the cluster
+func (cm *ClientManager) CountReadyNodes() (int, int, error) {
+	nodes, err := cm.clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	ready := 0
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == "Ready" && condition.Status == "True" {
+				ready++
+				break
+			}
+		}
+	}
+
+	return ready, len(nodes.Items), nil
+}
+
+// GetServerVersion returns the Kubernetes version reported by the API server
+func (cm *ClientManager) GetServerVersion() (string, error) {
+	serverVersion, err := cm.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return serverVersion.GitVersion, nil
+}
+
 // WaitForNodesReady waits for all nodes in the cluster to be ready
 func (cm *ClientManager) WaitForNodesReady(timeout time.Duration) error {
 	logger.Debug("waiting for nodes to be ready...")
@@ -162,11 +209,196 @@ func (cm *ClientManager) WaitForNodesReadyWithCount(expectedNodes int, timeout t
 	return fmt.Errorf("expected %d ready nodes, timeout after %v", expectedNodes, timeout)
 }
 
+// readinessPollInterval is how often the readiness helpers check the workloads again
+var readinessPollInterval = 5 * time.Second
+
+// WaitForDeploymentReady waits for the deployments matching labelSelector in namespace to be ready
+func (cm *ClientManager) WaitForDeploymentReady(namespace, labelSelector string, timeout time.Duration) error {
+	return WaitForDeploymentReady(cm.clientset, namespace, labelSelector, timeout)
+}
+
+// WaitForDeploymentReady waits until at least one deployment matches labelSelector in namespace and every
+// matching deployment has all its replicas ready
+func WaitForDeploymentReady(client kubernetes.Interface, namespace, labelSelector string, timeout time.Duration) error {
+	return waitForReady(fmt.Sprintf("deployments %s in %s", labelSelector, namespace), timeout, func() (bool, error) {
+		deployments, err := client.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return false, err
+		}
+		if len(deployments.Items) == 0 {
+			return false, nil
+		}
+		for _, deployment := range deployments.Items {
+			desired := int32(1)
+			if deployment.Spec.Replicas != nil {
+				desired = *deployment.Spec.Replicas
+			}
+			if deployment.Status.ReadyReplicas == 0 || deployment.Status.ReadyReplicas != desired {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// WaitForDaemonSetReady waits for the daemonsets matching labelSelector in namespace to be ready
+func (cm *ClientManager) WaitForDaemonSetReady(namespace, labelSelector string, timeout time.Duration) error {
+	return WaitForDaemonSetReady(cm.clientset, namespace, labelSelector, timeout)
+}
+
+// WaitForDaemonSetReady waits until at least one daemonset matches labelSelector in namespace and every matching
+// daemonset has a ready pod on each node it is scheduled to
+func WaitForDaemonSetReady(client kubernetes.Interface, namespace, labelSelector string, timeout time.Duration) error {
+	return waitForReady(fmt.Sprintf("daemonsets %s in %s", labelSelector, namespace), timeout, func() (bool, error) {
+		daemonsets, err := client.AppsV1().DaemonSets(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return false, err
+		}
+		if len(daemonsets.Items) == 0 {
+			return false, nil
+		}
+		for _, ds := range daemonsets.Items {
+			if ds.Status.DesiredNumberScheduled == 0 || ds.Status.NumberReady != ds.Status.DesiredNumberScheduled {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// waitForReady polls ready until it reports true or timeout passes, errors from ready are retried as the API
+// server may not be answering yet
+func waitForReady(what string, timeout time.Duration, ready func() (bool, error)) error {
+	logger.Debugf("waiting for %s to be ready...", what)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ok, err := ready()
+		if err != nil {
+			logger.Debugf("failed to check %s: %v", what, err)
+		} else if ok {
+			logger.Debugf("%s are ready", what)
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("timeout waiting for %s to be ready after %v", what, timeout)
+		}
+		time.Sleep(readinessPollInterval)
+	}
+}
+
 // ApplyManifest applies a Kubernetes manifest using the dynamic client
 func (cm *ClientManager) ApplyManifest(manifest string) error {
+	_, err := cm.ApplyManifestResources(manifest)
+	return err
+}
+
+// ApplyManifestSSA applies a Kubernetes manifest with server-side apply as FieldManager, so applying it again
+// after it changed updates the resources in place
+func (cm *ClientManager) ApplyManifestSSA(manifest string) error {
+	_, err := cm.applyManifest(manifest, func(gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+		return serverSideApply(cm.dynamicClient, gvr, obj)
+	})
+	return err
+}
+
+// ApplyManifestResources applies a Kubernetes manifest using the dynamic client and returns the applied
+// resources as kind/name, or kind/namespace/name for namespaced ones
+func (cm *ClientManager) ApplyManifestResources(manifest string) ([]string, error) {
+	return cm.applyManifest(manifest, cm.applyResource)
+}
+
+// applyManifest applies every resource of a manifest with apply and returns the applied resources
+func (cm *ClientManager) applyManifest(manifest string, apply func(schema.GroupVersionResource, *unstructured.Unstructured) error) ([]string, error) {
 	logger.Debugf("applying Kubernetes manifest using client manager")
 
-	// parse the YAML manifest
+	objs, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		gvr, namespaced := cm.restMapping(obj)
+		// like kubectl, namespaced resources without a namespace go to the default one
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(metav1.NamespaceDefault)
+		}
+
+		// apply the resource
+		if err := apply(gvr, obj); err != nil {
+			return applied, fmt.Errorf("failed to apply resource %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		applied = append(applied, resourceName(obj))
+		logger.Debugf("applied resource: %s/%s", obj.GetKind(), obj.GetName())
+	}
+
+	logger.Debugf("manifest applied successfully")
+	return applied, nil
+}
+
+// restMapping resolves the resource of an object and whether it is namespaced through API discovery,
+// so any kind (including CRDs applied earlier in the same manifest) maps correctly. It falls back to
+// guessing from the kind when discovery can't resolve it
+func (cm *ClientManager) restMapping(obj *unstructured.Unstructured) (schema.GroupVersionResource, bool) {
+	if cm.mapper == nil {
+		cm.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(cm.clientset.Discovery()))
+	}
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := cm.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		// the kind may have been registered since discovery was cached
+		cm.mapper.Reset()
+		mapping, err = cm.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		logger.Debugf("failed to map %s, guessing its resource: %v", gvk, err)
+		return resourceForObject(obj), obj.GetNamespace() != ""
+	}
+
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+// resourceName formats an object as kind/name or kind/namespace/name
+func resourceName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// DeleteManifest deletes the resources in a Kubernetes manifest, skipping the ones that don't exist
+func (cm *ClientManager) DeleteManifest(manifest string) error {
+	logger.Debugf("deleting Kubernetes manifest using client manager")
+
+	objs, err := decodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, obj := range objs {
+		err := cm.dynamicClient.Resource(resourceForObject(obj)).Namespace(obj.GetNamespace()).Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			logger.Debugf("resource %s/%s not found, skipping", obj.GetKind(), obj.GetName())
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete resource %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		logger.Debugf("deleted resource: %s/%s", obj.GetKind(), obj.GetName())
+	}
+
+	return nil
+}
+
+// decodeManifest parses a multi document YAML or JSON manifest into unstructured objects
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
 	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
 	for {
 		var rawObj runtime.RawExtension
@@ -174,32 +406,30 @@ func (cm *ClientManager) ApplyManifest(manifest string) error {
 			if err.Error() == "EOF" {
 				break
 			}
-			return fmt.Errorf("failed to decode manifest: %w", err)
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		// skip empty documents, e.g. a trailing --- or one holding only comments
+		if raw := bytes.TrimSpace(rawObj.Raw); len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
 		}
 
 		// convert to unstructured object
 		obj := &unstructured.Unstructured{}
 		if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, rawObj.Raw, obj); err != nil {
-			return fmt.Errorf("failed to decode object: %w", err)
-		}
-
-		// get the resource
-		gvr := schema.GroupVersionResource{
-			Group:    obj.GroupVersionKind().Group,
-			Version:  obj.GroupVersionKind().Version,
-			Resource: getResourceFromKind(obj.GetKind()),
+			return nil, fmt.Errorf("failed to decode object: %w", err)
 		}
-
-		// apply the resource
-		if err := cm.applyResource(gvr, obj); err != nil {
-			return fmt.Errorf("failed to apply resource %s/%s: %w", obj.GetKind(), obj.GetName(), err)
-		}
-
-		logger.Debugf("applied resource: %s/%s", obj.GetKind(), obj.GetName())
+		objs = append(objs, obj)
 	}
+	return objs, nil
+}
 
-	logger.Debugf("manifest applied successfully")
-	return nil
+// resourceForObject returns the group, version and resource of an object
+func resourceForObject(obj *unstructured.Unstructured) schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    obj.GroupVersionKind().Group,
+		Version:  obj.GroupVersionKind().Version,
+		Resource: getResourceFromKind(obj.GetKind()),
+	}
 }
 
 // CheckNamespaceExists checks if a namespace exists
@@ -242,6 +472,209 @@ func (cm *ClientManager) CheckDaemonSetReady(namespace, name string) error {
 	return nil
 }
 
+// SetDefaultStorageClass marks the named StorageClass as the cluster default
+func (cm *ClientManager) SetDefaultStorageClass(name string) error {
+	return SetDefaultStorageClass(cm.clientset, name)
+}
+
+// SetDefaultStorageClass marks the named StorageClass as the cluster default and clears the
+// annotation from every other class so only one default remains
+func SetDefaultStorageClass(client kubernetes.Interface, name string) error {
+	ctx := context.Background()
+
+	storageClasses, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list storageclasses: %w", err)
+	}
+
+	found := false
+	for _, sc := range storageClasses.Items {
+		if sc.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("storageclass %s not found", name)
+	}
+
+	for i := range storageClasses.Items {
+		sc := &storageClasses.Items[i]
+		isDefault := sc.Annotations[DefaultStorageClassAnnotation] == "true"
+		if isDefault == (sc.Name == name) {
+			continue
+		}
+
+		if sc.Annotations == nil {
+			sc.Annotations = make(map[string]string)
+		}
+		if sc.Name == name {
+			sc.Annotations[DefaultStorageClassAnnotation] = "true"
+		} else {
+			delete(sc.Annotations, DefaultStorageClassAnnotation)
+		}
+
+		if _, err := client.StorageV1().StorageClasses().Update(ctx, sc, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to patch storageclass %s: %w", sc.Name, err)
+		}
+		logger.Debugf("updated default annotation on storageclass %s", sc.Name)
+	}
+
+	return nil
+}
+
+// LabelNodes sets the labels on every node of the cluster
+func (cm *ClientManager) LabelNodes(labels map[string]string) error {
+	return LabelNodes(cm.clientset, labels)
+}
+
+// LabelNodes sets the labels on every node of the cluster, nodes that already carry them are left alone
+func LabelNodes(client kubernetes.Interface, labels map[string]string) error {
+	return UpdateNodes(client, nil, func(node *corev1.Node) bool {
+		changed := false
+		for key, value := range labels {
+			if node.Labels[key] != value {
+				if node.Labels == nil {
+					node.Labels = make(map[string]string)
+				}
+				node.Labels[key] = value
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// RemoveNodeLabel removes the label from the nodes selected, every node when selected is nil
+func RemoveNodeLabel(client kubernetes.Interface, key string, selected func(corev1.Node) bool) error {
+	return UpdateNodes(client, selected, func(node *corev1.Node) bool {
+		if _, ok := node.Labels[key]; !ok {
+			return false
+		}
+		delete(node.Labels, key)
+		return true
+	})
+}
+
+// UpdateNodes applies update to the nodes selected, every node when selected is nil, and saves the ones it reports
+// as changed. The node controller and kubelet update nodes too, so each node is fetched fresh before its update and
+// the update is retried on a conflict
+func UpdateNodes(client kubernetes.Interface, selected func(corev1.Node) bool, update func(*corev1.Node) bool) error {
+	ctx := context.Background()
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, listed := range nodes.Items {
+		if selected != nil && !selected(listed) {
+			continue
+		}
+		name := listed.Name
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if !update(node) {
+				return nil
+			}
+
+			if _, err := client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+			logger.Debugf("updated node %s", name)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update node %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// LabelProjectNodes labels the nodes of the cluster behind the context with the project it belongs to
+func LabelProjectNodes(contextName, project string) error {
+	clientManager, err := NewClientManagerForContext(contextName)
+	if err != nil {
+		return err
+	}
+	return clientManager.LabelNodes(map[string]string{ProjectNodeLabel: project})
+}
+
+// SetNodeExtendedResource advertises quantity of an extended resource on the named node
+func (cm *ClientManager) SetNodeExtendedResource(nodeName string, name corev1.ResourceName, quantity int64) error {
+	return SetNodeExtendedResource(cm.clientset, nodeName, name, quantity)
+}
+
+// SetNodeExtendedResource advertises quantity of an extended resource on the named node by patching its capacity
+// and allocatable, the kubelet keeps extended resources it doesn't manage itself. The update is retried when it
+// races the kubelet's own status updates
+func SetNodeExtendedResource(client kubernetes.Interface, nodeName string, name corev1.ResourceName, quantity int64) error {
+	ctx := context.Background()
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		value := *resource.NewQuantity(quantity, resource.DecimalSI)
+		if node.Status.Capacity == nil {
+			node.Status.Capacity = corev1.ResourceList{}
+		}
+		if node.Status.Allocatable == nil {
+			node.Status.Allocatable = corev1.ResourceList{}
+		}
+		node.Status.Capacity[name] = value
+		node.Status.Allocatable[name] = value
+
+		_, err = client.CoreV1().Nodes().UpdateStatus(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set %s on node %s: %w", name, nodeName, err)
+	}
+
+	logger.Debugf("advertised %d %s on node %s", quantity, name, nodeName)
+	return nil
+}
+
+// NodesProject returns the project in the ProjectNodeLabel of the nodes, empty when no node carries it
+func NodesProject(client kubernetes.Interface) (string, error) {
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		if project := node.Labels[ProjectNodeLabel]; project != "" {
+			return project, nil
+		}
+	}
+	return "", nil
+}
+
+// ClusterProject returns the project the cluster of the kubeconfig belongs to, empty for clusters created before
+// lok8s labelled its nodes
+func ClusterProject(kubeconfig string) (string, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	// a cluster whose API server is down shouldn't hold up status or prune
+	restConfig.Timeout = 10 * time.Second
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return NodesProject(clientset)
+}
+
 // applyResource applies a single resource using the dynamic client
 func (cm *ClientManager) applyResource(gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
 	ctx := context.Background()
@@ -260,6 +693,18 @@ func (cm *ClientManager) applyResource(gvr schema.GroupVersionResource, obj *uns
 	return err
 }
 
+// FieldManager is the field manager lok8s applies resources as with server-side apply
+const FieldManager = "lok8s"
+
+// serverSideApply applies obj as FieldManager, forcing ownership of the fields last written by a plain update
+func serverSideApply(client dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+	_, err := client.Resource(gvr).Namespace(obj.GetNamespace()).Apply(context.Background(), obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+	})
+	return err
+}
+
 // getResourceFromKind maps Kubernetes resource kinds to their resource names
 func getResourceFromKind(kind string) string {
 	kindToResource := map[string]string{
@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Client", func() {
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	Describe("SetDefaultStorageClass", func() {
+		storageClass := func(name string, isDefault bool) *storagev1.StorageClass {
+			sc := &storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: name},
+				Provisioner: "example.com/" + name,
+			}
+			if isDefault {
+				sc.Annotations = map[string]string{DefaultStorageClassAnnotation: "true"}
+			}
+			return sc
+		}
+
+		isDefault := func(client *fake.Clientset, name string) bool {
+			sc, err := client.StorageV1().StorageClasses().Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return sc.Annotations[DefaultStorageClassAnnotation] == "true"
+		}
+
+		It("should mark the storageclass as default and clear the previous default", func() {
+			client := fake.NewSimpleClientset(storageClass("standard", true), storageClass("csi-hostpath-sc", false))
+
+			Expect(SetDefaultStorageClass(client, "csi-hostpath-sc")).To(Succeed())
+			Expect(isDefault(client, "csi-hostpath-sc")).To(BeTrue())
+			Expect(isDefault(client, "standard")).To(BeFalse())
+		})
+
+		It("should be a no-op when the storageclass is already the only default", func() {
+			client := fake.NewSimpleClientset(storageClass("standard", true))
+
+			Expect(SetDefaultStorageClass(client, "standard")).To(Succeed())
+			Expect(isDefault(client, "standard")).To(BeTrue())
+		})
+
+		It("should fail when the storageclass does not exist", func() {
+			client := fake.NewSimpleClientset(storageClass("standard", true))
+
+			err := SetDefaultStorageClass(client, "missing")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("storageclass missing not found"))
+			Expect(isDefault(client, "standard")).To(BeTrue())
+		})
+	})
+
+	Describe("Project node labels", func() {
+		It("should label every node with the project", func() {
+			client := fake.NewSimpleClientset(node("kind1-control-plane", nil), node("kind1-worker", map[string]string{"pool": "gpu"}))
+
+			Expect(LabelNodes(client, map[string]string{ProjectNodeLabel: "demo"})).To(Succeed())
+
+			worker, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-worker", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Labels).To(Equal(map[string]string{"pool": "gpu", ProjectNodeLabel: "demo"}))
+
+			project, err := NodesProject(client)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(project).To(Equal("demo"))
+		})
+
+		It("should retry the label update when the node changed in the meantime", func() {
+			client := fake.NewSimpleClientset(node("kind1-worker", nil))
+			conflicts := 0
+			client.PrependReactor("update", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if conflicts < 2 {
+					conflicts++
+					return true, nil, apierrors.NewConflict(corev1.Resource("nodes"), "kind1-worker", errors.New("the object has been modified"))
+				}
+				return false, nil, nil
+			})
+
+			Expect(LabelNodes(client, map[string]string{ProjectNodeLabel: "demo"})).To(Succeed())
+			Expect(conflicts).To(Equal(2))
+
+			worker, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-worker", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Labels).To(HaveKeyWithValue(ProjectNodeLabel, "demo"))
+		})
+
+		It("should report no project for unlabelled clusters", func() {
+			client := fake.NewSimpleClientset(node("kind1-control-plane", nil))
+
+			project, err := NodesProject(client)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(project).To(BeEmpty())
+		})
+
+		It("should only remove the label from the selected nodes", func() {
+			client := fake.NewSimpleClientset(node("kind1-control-plane", map[string]string{"pool": "system"}), node("kind1-worker", map[string]string{"pool": "apps"}))
+
+			Expect(RemoveNodeLabel(client, "pool", func(n corev1.Node) bool { return n.Name == "kind1-control-plane" })).To(Succeed())
+
+			controlPlane, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-control-plane", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controlPlane.Labels).To(BeEmpty())
+			worker, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-worker", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Labels).To(HaveKeyWithValue("pool", "apps"))
+		})
+	})
+
+	Describe("SetNodeExtendedResource", func() {
+		It("should advertise the resource in the node capacity and allocatable", func() {
+			client := fake.NewSimpleClientset(node("kind1-worker", nil))
+
+			Expect(SetNodeExtendedResource(client, "kind1-worker", GPUResource, 2)).To(Succeed())
+
+			worker, err := client.CoreV1().Nodes().Get(context.Background(), "kind1-worker", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			capacity := worker.Status.Capacity[GPUResource]
+			allocatable := worker.Status.Allocatable[GPUResource]
+			Expect(capacity.Value()).To(Equal(int64(2)))
+			Expect(allocatable.Value()).To(Equal(int64(2)))
+		})
+
+		It("should fail for a node that does not exist", func() {
+			client := fake.NewSimpleClientset()
+
+			Expect(SetNodeExtendedResource(client, "kind1-worker", GPUResource, 2)).To(MatchError(ContainSubstring("kind1-worker")))
+		})
+	})
+
+	Describe("serverSideApply", func() {
+		pool := func(address string) *unstructured.Unstructured {
+			return &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "metallb.io/v1beta1",
+				"kind":       "IPAddressPool",
+				"metadata":   map[string]interface{}{"name": "default-pool", "namespace": "metallb-system"},
+				"spec":       map[string]interface{}{"addresses": []interface{}{address}},
+			}}
+		}
+		gvr := schema.GroupVersionResource{Group: "metallb.io", Version: "v1beta1", Resource: "ipaddresspools"}
+
+		It("should send the resource as an apply patch every time", func() {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			var patches []clienttesting.PatchActionImpl
+			client.PrependReactor("patch", "ipaddresspools", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				patch := action.(clienttesting.PatchActionImpl)
+				patches = append(patches, patch)
+				return true, pool("applied"), nil
+			})
+
+			Expect(serverSideApply(client, gvr, pool("172.18.255.200-172.18.255.210"))).To(Succeed())
+			Expect(serverSideApply(client, gvr, pool("172.18.255.200-172.18.255.250"))).To(Succeed())
+
+			Expect(patches).To(HaveLen(2))
+			for _, patch := range patches {
+				Expect(patch.PatchType).To(Equal(types.ApplyPatchType))
+				Expect(patch.Namespace).To(Equal("metallb-system"))
+				Expect(patch.Name).To(Equal("default-pool"))
+			}
+			Expect(string(patches[1].Patch)).To(ContainSubstring("172.18.255.200-172.18.255.250"))
+		})
+	})
+
+	Describe("Readiness helpers", func() {
+		labels := map[string]string{"app.kubernetes.io/name": "metallb"}
+		selector := "app.kubernetes.io/name=metallb"
+
+		deployment := func(name string, replicas, ready int32) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "metallb-system", Labels: labels},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+			}
+		}
+		daemonSet := func(name string, desired, ready int32) *appsv1.DaemonSet {
+			return &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "metallb-system", Labels: labels},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+			}
+		}
+
+		BeforeEach(func() {
+			interval := readinessPollInterval
+			readinessPollInterval = time.Millisecond
+			DeferCleanup(func() { readinessPollInterval = interval })
+		})
+
+		It("should return once every matching deployment is ready", func() {
+			client := fake.NewSimpleClientset(deployment("controller", 2, 2))
+			Expect(WaitForDeploymentReady(client, "metallb-system", selector, time.Second)).To(Succeed())
+		})
+
+		It("should time out while a deployment is missing replicas", func() {
+			client := fake.NewSimpleClientset(deployment("controller", 2, 2), deployment("webhook", 1, 0))
+			err := WaitForDeploymentReady(client, "metallb-system", selector, 10*time.Millisecond)
+			Expect(err).To(MatchError(ContainSubstring("timeout waiting for deployments app.kubernetes.io/name=metallb in metallb-system")))
+		})
+
+		It("should time out when no deployment matches", func() {
+			client := fake.NewSimpleClientset(deployment("controller", 1, 1))
+			Expect(WaitForDeploymentReady(client, "metallb-system", "app=other", 10*time.Millisecond)).NotTo(Succeed())
+		})
+
+		It("should return once every node runs a ready daemonset pod", func() {
+			client := fake.NewSimpleClientset(daemonSet("speaker", 3, 3))
+			Expect(WaitForDaemonSetReady(client, "metallb-system", selector, time.Second)).To(Succeed())
+		})
+
+		It("should time out while daemonset pods are not ready", func() {
+			client := fake.NewSimpleClientset(daemonSet("speaker", 3, 2))
+			Expect(WaitForDaemonSetReady(client, "metallb-system", selector, 10*time.Millisecond)).NotTo(Succeed())
+		})
+	})
+})
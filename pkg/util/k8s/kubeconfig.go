@@ -26,8 +26,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/day0ops/lok8s/pkg/logger"
 )
@@ -42,6 +46,12 @@ func UpdateClusterServer(clusterName, serverURL string, insecureSkipTLSVerify bo
 		return err
 	}
 
+	unlock, err := lockKubeConfig(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// load existing kubeconfig
 	config, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
@@ -77,6 +87,12 @@ func RenameContext(oldContext, newContext string) error {
 		return err
 	}
 
+	unlock, err := lockKubeConfig(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// load existing kubeconfig
 	config, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
@@ -123,6 +139,12 @@ func DeleteContext(contextName string) error {
 		return err
 	}
 
+	unlock, err := lockKubeConfig(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// load existing kubeconfig
 	config, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
@@ -188,8 +210,135 @@ func DeleteContext(contextName string) error {
 	return nil
 }
 
-// GetKubeConfigPath get the kubeconfig path. First KUBECONFIG is looked at and if not looks at .kube/config
+// ListContexts returns the names of the contexts in the kubeconfig, sorted
+func ListContexts() ([]string, error) {
+	kubeconfigPath, err := GetKubeConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+// ExportContexts writes a standalone kubeconfig containing only the given contexts (and their
+// clusters and users) to outputPath. Referenced certificate files are inlined so the file is portable
+func ExportContexts(contextNames []string, outputPath string) error {
+	logger.Debugf("exporting contexts %v to %s", contextNames, outputPath)
+
+	// get kubeconfig path
+	kubeconfigPath, err := GetKubeConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// load existing kubeconfig
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	exported := clientcmdapi.NewConfig()
+	for _, contextName := range contextNames {
+		context, exists := config.Contexts[contextName]
+		if !exists {
+			return fmt.Errorf("context %s not found in kubeconfig", contextName)
+		}
+		exported.Contexts[contextName] = context
+
+		if cluster, ok := config.Clusters[context.Cluster]; ok {
+			exported.Clusters[context.Cluster] = cluster
+		}
+		if user, ok := config.AuthInfos[context.AuthInfo]; ok {
+			exported.AuthInfos[context.AuthInfo] = user
+		}
+	}
+	if len(contextNames) > 0 {
+		exported.CurrentContext = contextNames[0]
+	}
+
+	// inline certificate and key files so the exported kubeconfig doesn't depend on local paths
+	if err := clientcmdapi.FlattenConfig(exported); err != nil {
+		return fmt.Errorf("failed to flatten kubeconfig: %w", err)
+	}
+
+	if err := clientcmd.WriteToFile(*exported, outputPath); err != nil {
+		return fmt.Errorf("failed to write kubeconfig to %s: %w", outputPath, err)
+	}
+
+	logger.Debugf("exported %d context(s) to %s", len(contextNames), outputPath)
+	return nil
+}
+
+// kubeconfigMu serializes kubeconfig updates made from this process
+var kubeconfigMu sync.Mutex
+
+// lockKubeConfig takes the <kubeconfig>.lock file used by kind and client-go so a read-modify-write
+// of the kubeconfig can't interleave with clusters being created concurrently. Returns the unlock function
+func lockKubeConfig(kubeconfigPath string) (func(), error) {
+	kubeconfigMu.Lock()
+
+	lockPath := kubeconfigPath + ".lock"
+	for _, backoff := range []time.Duration{0, 10 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond, 500 * time.Millisecond, time.Second} {
+		time.Sleep(backoff)
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL, 0)
+		if err == nil {
+			f.Close()
+			return func() {
+				os.Remove(lockPath)
+				kubeconfigMu.Unlock()
+			}, nil
+		}
+		if !os.IsExist(err) {
+			kubeconfigMu.Unlock()
+			return nil, fmt.Errorf("failed to lock kubeconfig: %w", err)
+		}
+	}
+
+	kubeconfigMu.Unlock()
+	return nil, fmt.Errorf("failed to lock kubeconfig, %s is held by another process", lockPath)
+}
+
+// kubeconfigPathOverride is set by --kubeconfig and takes precedence over KUBECONFIG and .kube/config
+var kubeconfigPathOverride string
+
+// SetKubeConfigPath makes every kubeconfig, client and Helm operation use the given file, an empty path
+// restores the default lookup
+func SetKubeConfigPath(path string) {
+	kubeconfigPathOverride = path
+}
+
+// ExplicitKubeConfigPath returns the kubeconfig path set with SetKubeConfigPath, empty when the default is used
+func ExplicitKubeConfigPath() string {
+	return kubeconfigPathOverride
+}
+
+// KubeConfigEnv returns the environment for commands that update the kubeconfig themselves (e.g. minikube),
+// pointing KUBECONFIG at the explicit kubeconfig path when one is set
+func KubeConfigEnv() []string {
+	env := os.Environ()
+	if kubeconfigPathOverride != "" {
+		env = append(env, "KUBECONFIG="+kubeconfigPathOverride)
+	}
+	return env
+}
+
+// GetKubeConfigPath get the kubeconfig path. The path set with SetKubeConfigPath is used first, then KUBECONFIG
+// and if not looks at .kube/config
 func GetKubeConfigPath() (string, error) {
+	if kubeconfigPathOverride != "" {
+		return kubeconfigPathOverride, nil
+	}
+
 	kubeconfigPath := os.Getenv("KUBECONFIG")
 	if kubeconfigPath == "" {
 		kubeconfigPath = filepath.Join(os.Getenv("HOME"), ".kube", "config")
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var _ = Describe("Kubeconfig", func() {
+	var kubeconfigPath string
+
+	BeforeEach(func() {
+		kubeconfigPath = filepath.Join(GinkgoT().TempDir(), "config")
+		GinkgoT().Setenv("KUBECONFIG", kubeconfigPath)
+
+		kubeconfig := clientcmdapi.NewConfig()
+		for i := 1; i <= 5; i++ {
+			name := fmt.Sprintf("kind-kind%d", i)
+			kubeconfig.Clusters[name] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:6443"}
+			kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+			kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+		}
+		Expect(clientcmd.WriteToFile(*kubeconfig, kubeconfigPath)).To(Succeed())
+	})
+
+	It("should not lose updates made concurrently", func() {
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := 1; i <= 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				name := fmt.Sprintf("kind-kind%d", i)
+				if err := UpdateClusterServer(name, fmt.Sprintf("https://172.18.0.%d:6443", i), false); err != nil {
+					errs[i-1] = err
+					return
+				}
+				errs[i-1] = RenameContext(name, fmt.Sprintf("myproject-%d", i))
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 1; i <= 5; i++ {
+			Expect(kubeconfig.Clusters[fmt.Sprintf("kind-kind%d", i)].Server).To(Equal(fmt.Sprintf("https://172.18.0.%d:6443", i)))
+			Expect(kubeconfig.Contexts).To(HaveKey(fmt.Sprintf("myproject-%d", i)))
+		}
+	})
+
+	It("should prefer the explicit kubeconfig path over KUBECONFIG", func() {
+		explicitPath := filepath.Join(GinkgoT().TempDir(), "lok8s")
+		SetKubeConfigPath(explicitPath)
+		DeferCleanup(SetKubeConfigPath, "")
+
+		path, err := GetKubeConfigPath()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(explicitPath))
+		Expect(KubeConfigEnv()).To(HaveLen(len(os.Environ()) + 1))
+		Expect(KubeConfigEnv()[len(os.Environ())]).To(Equal("KUBECONFIG=" + explicitPath))
+
+		SetKubeConfigPath("")
+		path, err = GetKubeConfigPath()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(kubeconfigPath))
+	})
+
+	It("should release the lock file once done", func() {
+		Expect(UpdateClusterServer("kind-kind1", "https://172.18.0.1:6443", true)).To(Succeed())
+
+		_, err := os.Stat(kubeconfigPath + ".lock")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
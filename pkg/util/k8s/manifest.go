@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// manifestExtensions are the file extensions read from a manifest directory
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// ReadManifests reads the manifests of a local file, of every YAML and JSON file in a local directory
+// (in lexical order, like kubectl apply -f) or of a http(s) URL
+func ReadManifests(source string) (string, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return downloadManifest(source)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest %s: %w", source, err)
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return "", fmt.Errorf("failed to read manifest %s: %w", source, err)
+		}
+		return string(data), nil
+	}
+
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest directory %s: %w", source, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !slices.Contains(manifestExtensions, ext) {
+			continue
+		}
+		files = append(files, filepath.Join(source, entry.Name()))
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no manifests found in directory %s", source)
+	}
+	sort.Strings(files)
+
+	documents := make([]string, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read manifest %s: %w", file, err)
+		}
+		documents = append(documents, string(data))
+	}
+	return strings.Join(documents, "\n---\n"), nil
+}
+
+// downloadManifest fetches a manifest from a URL
+func downloadManifest(url string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download manifest %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download manifest %s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest %s: %w", url, err)
+	}
+	return string(data), nil
+}
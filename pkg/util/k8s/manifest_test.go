@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifests", func() {
+	const namespace = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: apps\n"
+	const configMap = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: apps\n"
+
+	Describe("ReadManifests", func() {
+		It("should read a single file", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "namespace.yaml")
+			Expect(os.WriteFile(file, []byte(namespace), 0644)).To(Succeed())
+
+			Expect(ReadManifests(file)).To(Equal(namespace))
+		})
+
+		It("should read the manifests of a directory in lexical order", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "20-configmap.yml"), []byte(configMap), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "10-namespace.yaml"), []byte(namespace), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# notes"), 0644)).To(Succeed())
+
+			manifest, err := ReadManifests(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manifest).To(Equal(namespace + "\n---\n" + configMap))
+
+			objs, err := decodeManifest(manifest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objs).To(HaveLen(2))
+			Expect(resourceName(objs[0])).To(Equal("Namespace/apps"))
+			Expect(resourceName(objs[1])).To(Equal("ConfigMap/apps/settings"))
+		})
+
+		It("should fail for a directory without manifests", func() {
+			_, err := ReadManifests(GinkgoT().TempDir())
+			Expect(err).To(MatchError(ContainSubstring("no manifests found")))
+		})
+
+		It("should download a manifest from a URL", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/namespace.yaml" {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(namespace))
+			}))
+			defer server.Close()
+
+			Expect(ReadManifests(server.URL + "/namespace.yaml")).To(Equal(namespace))
+
+			_, err := ReadManifests(server.URL + "/missing.yaml")
+			Expect(err).To(MatchError(ContainSubstring("404")))
+		})
+
+		It("should fail for a missing path", func() {
+			_, err := ReadManifests(filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("decodeManifest", func() {
+		It("should skip empty documents", func() {
+			objs, err := decodeManifest("---\n# comment only\n---\n" + namespace + "---\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objs).To(HaveLen(1))
+		})
+	})
+})
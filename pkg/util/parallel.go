@@ -0,0 +1,45 @@
+// MIT License
+//
+// Copyright (c) 2025 lok8s
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package util
+
+import (
+	"golang.org/x/sync/errgroup"
+)
+
+// RunParallel calls fn for every index from 1 to n using at most limit goroutines and waits for all
+// of them to finish. The error returned by each call is kept, index i ends up at position i-1
+func RunParallel(n, limit int, fn func(i int) error) []error {
+	errs := make([]error, n)
+
+	var g errgroup.Group
+	g.SetLimit(limit)
+	for i := 1; i <= n; i++ {
+		g.Go(func() error {
+			errs[i-1] = fn(i)
+			return nil
+		})
+	}
+	g.Wait()
+
+	return errs
+}